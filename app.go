@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/ltphat2204/domain-driven-golang/grpcserver"
+	healthApplication "github.com/ltphat2204/domain-driven-golang/modules/health/application"
+	"github.com/ltphat2204/domain-driven-golang/worker"
+)
+
+// application is everything bootstrap started that shutdown has to stop.
+type application struct {
+	server          *http.Server
+	grpcServer      *grpcserver.Server // nil when gRPC is disabled
+	grpcAddr        string
+	workers         *worker.Group
+	health          healthApplication.HealthService
+	db              *gorm.DB // nil for the memory driver
+	shutdownTimeout time.Duration
+	shutdownDelay   time.Duration
+	flushTraces     func(context.Context) error
+}
+
+// serve runs the HTTP and gRPC servers and background workers until ctx is
+// cancelled, then shuts them down gracefully.
+func (a *application) serve(ctx context.Context) error {
+	var grpcListener net.Listener
+	if a.grpcServer != nil {
+		var err error
+		if grpcListener, err = net.Listen("tcp", a.grpcAddr); err != nil {
+			return errors.Join(fmt.Errorf("grpc server: %w", err), a.shutdown())
+		}
+	}
+	a.workers.Start()
+
+	serveErr := make(chan error, 2)
+	go func() {
+		serveErr <- fmt.Errorf("http server: %w", a.server.ListenAndServe())
+	}()
+	slog.Info("listening", "addr", a.server.Addr)
+	if grpcListener != nil {
+		go func() {
+			serveErr <- fmt.Errorf("grpc server: %w", a.grpcServer.Serve(grpcListener))
+		}()
+		slog.Info("listening for grpc", "addr", grpcListener.Addr().String())
+	}
+
+	select {
+	case err := <-serveErr:
+		return errors.Join(err, a.shutdown())
+	case <-ctx.Done():
+	}
+
+	a.health.SetShuttingDown()
+	if a.shutdownDelay > 0 {
+		slog.Info("shutting down: readiness is failing, still serving", "delay", a.shutdownDelay)
+		time.Sleep(a.shutdownDelay)
+	}
+	slog.Info("shutting down, draining in-flight requests", "timeout", a.shutdownTimeout)
+	if err := a.shutdown(); err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+	slog.Info("shutdown complete")
+	return nil
+}
+
+// shutdown stops accepting connections and drains in-flight requests and
+// calls, then stops the workers, flushes pending spans and closes the
+// database pool, all within shutdownTimeout.
+func (a *application) shutdown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), a.shutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	if err := a.server.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("draining requests: %w", err))
+	}
+	if a.grpcServer != nil {
+		if err := a.grpcServer.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("draining grpc calls: %w", err))
+		}
+	}
+	if err := a.workers.Stop(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	if err := a.flushTraces(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("flushing traces: %w", err))
+	}
+	if a.db != nil {
+		sqlDB, err := a.db.DB()
+		if err == nil {
+			err = sqlDB.Close()
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("closing database: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
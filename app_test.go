@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	healthApplication "github.com/ltphat2204/domain-driven-golang/modules/health/application"
+	"github.com/ltphat2204/domain-driven-golang/worker"
+)
+
+func TestServeDrainsRequestsOnShutdown(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		io.WriteString(w, "done")
+	})
+	workerStopped := make(chan struct{})
+	workers := &worker.Group{}
+	workers.Add("waiter", func(ctx context.Context) error {
+		<-ctx.Done()
+		close(workerStopped)
+		return nil
+	})
+	app := &application{
+		server:          &http.Server{Addr: addr, Handler: handler},
+		workers:         workers,
+		health:          healthApplication.NewHealthService(),
+		shutdownTimeout: 5 * time.Second,
+		flushTraces:     func(context.Context) error { return nil },
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- app.serve(ctx) }()
+
+	var resp *http.Response
+	requested := make(chan error, 1)
+	go func() {
+		for deadline := time.Now().Add(2 * time.Second); ; {
+			resp, err = http.Get("http://" + addr)
+			if err == nil || time.Now().After(deadline) {
+				requested <- err
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	<-started
+	cancel()
+	if err := <-requested; err != nil {
+		t.Fatalf("in-flight request failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "done" {
+		t.Errorf("in-flight request body = %q, want done", body)
+	}
+
+	if err := <-served; err != nil {
+		t.Errorf("serve = %v, want nil after a clean shutdown", err)
+	}
+	select {
+	case <-workerStopped:
+	default:
+		t.Error("shutdown did not stop the workers")
+	}
+	if _, err := http.Get("http://" + addr); err == nil {
+		t.Error("server still accepts connections after shutdown")
+	}
+}
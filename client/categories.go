@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/url"
+
+	"github.com/ltphat2204/domain-driven-golang/common"
+	"github.com/ltphat2204/domain-driven-golang/modules/category/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/category/dto"
+)
+
+// CategoryService calls the /categories endpoints.
+type CategoryService struct {
+	client *Client
+}
+
+func (s *CategoryService) Create(ctx context.Context, input dto.CategoryCreateDTO) (*domain.Category, error) {
+	var category domain.Category
+	if err := s.client.call(ctx, "POST", "/categories", nil, input, &category); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (s *CategoryService) Get(ctx context.Context, id uint) (*domain.Category, error) {
+	var category domain.Category
+	if err := s.client.call(ctx, "GET", idPath("/categories", id), nil, nil, &category); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// List returns one page of categories.
+func (s *CategoryService) List(ctx context.Context, query dto.CategoryQueryDTO) (*dto.CategoryListResponse, error) {
+	return s.list(ctx, encodeQuery(query))
+}
+
+// All iterates over every category matching query, fetching pages as needed.
+func (s *CategoryService) All(ctx context.Context, query dto.CategoryQueryDTO) iter.Seq2[*domain.Category, error] {
+	return paginate(ctx, encodeQuery(query), func(ctx context.Context, q url.Values) ([]*domain.Category, common.PaginationMeta, error) {
+		page, err := s.list(ctx, q)
+		if err != nil {
+			return nil, common.PaginationMeta{}, err
+		}
+		return page.Categories, page.Meta, nil
+	})
+}
+
+func (s *CategoryService) list(ctx context.Context, query url.Values) (*dto.CategoryListResponse, error) {
+	var page dto.CategoryListResponse
+	if err := s.client.call(ctx, "GET", "/categories", query, nil, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// Update changes the fields set in input. Color must be one of the
+// server's palette.
+func (s *CategoryService) Update(ctx context.Context, id uint, input dto.CategoryUpdateDTO) (*domain.Category, error) {
+	var category domain.Category
+	if err := s.client.call(ctx, "PATCH", idPath("/categories", id), nil, input, &category); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (s *CategoryService) Delete(ctx context.Context, id uint) error {
+	return s.client.call(ctx, "DELETE", idPath("/categories", id), nil, nil, nil)
+}
@@ -0,0 +1,276 @@
+// Package client is a typed Go client for the task manager API. Requests and
+// responses reuse the server's dto and domain types, list endpoints come
+// with iterators that follow the pagination cursors, and failed calls return
+// an *Error decoded from the API's error envelope.
+//
+//	c, err := client.New("http://localhost:8080", client.WithUserID("bot"))
+//	task, err := c.Tasks().Create(ctx, dto.TaskCreateDTO{Title: "Write report"})
+//	for task, err := range c.Tasks().All(ctx, dto.TaskQueryDTO{Status: "Doing"}) { ... }
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ltphat2204/domain-driven-golang/common"
+)
+
+const (
+	defaultRetries = 3
+	defaultBackoff = 200 * time.Millisecond
+	maxBackoff     = 5 * time.Second
+)
+
+// Client calls the API at a base URL. It is safe for concurrent use.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	userID     string
+	token      string
+	userAgent  string
+	retries    int
+	backoff    time.Duration
+}
+
+type Option func(*Client)
+
+// WithHTTPClient replaces http.DefaultClient, for example to set a timeout
+// or a custom transport.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithUserID sends X-User-ID on every request; the views endpoints need it.
+func WithUserID(userID string) Option {
+	return func(c *Client) { c.userID = userID }
+}
+
+// WithToken sends an Authorization: Bearer header on every request.
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) { c.userAgent = userAgent }
+}
+
+// WithRetries sets how many times a request is retried after a 429 or 5xx
+// response, waiting backoff before the first retry and twice as long before
+// each next one. Retry-After headers are honoured. Zero disables retries.
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(c *Client) { c.retries, c.backoff = retries, backoff }
+}
+
+// New returns a client for the API at baseURL, such as
+// http://localhost:8080.
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("client: invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("client: base URL %q must be http or https", baseURL)
+	}
+	c := &Client{
+		baseURL:    u,
+		httpClient: http.DefaultClient,
+		userAgent:  "domain-driven-golang-client",
+		retries:    defaultRetries,
+		backoff:    defaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+func (c *Client) Tasks() *TaskService {
+	return &TaskService{c}
+}
+
+func (c *Client) Categories() *CategoryService {
+	return &CategoryService{c}
+}
+
+func (c *Client) Views() *ViewService {
+	return &ViewService{c}
+}
+
+func (c *Client) Transfer() *TransferService {
+	return &TransferService{c}
+}
+
+// Error is a non-2xx response. Code, Message and Detail come from the API's
+// error envelope; RequestID is the server's X-Request-ID for the call.
+type Error struct {
+	StatusCode int
+	Code       int
+	Message    string
+	Detail     string
+	RequestID  string
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("api: %d %s", e.StatusCode, e.Message)
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	if e.RequestID != "" {
+		msg += " (request " + e.RequestID + ")"
+	}
+	return msg
+}
+
+// IsNotFound reports whether err is an API 404.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+// envelope is the success body every JSON endpoint except the probes
+// returns.
+type envelope struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// call sends a request and decodes the envelope's data into out, if not nil.
+func (c *Client) call(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	resp, err := c.do(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return decodeError(resp)
+	}
+
+	var env envelope
+	if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+		return fmt.Errorf("%s %s: decoding response: %w", method, path, err)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(env.Data, out); err != nil {
+		return fmt.Errorf("%s %s: decoding response data: %w", method, path, err)
+	}
+	return nil
+}
+
+// file is a request body sent as it is rather than encoded as JSON.
+type file struct {
+	contentType string
+	data        []byte
+}
+
+// do sends the request, retrying 429 and 5xx responses. The caller closes
+// the returned body.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body interface{}) (*http.Response, error) {
+	var payload []byte
+	contentType := "application/json"
+	if f, ok := body.(file); ok {
+		payload, contentType = f.data, f.contentType
+	} else if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return nil, fmt.Errorf("%s %s: encoding request: %w", method, path, err)
+		}
+	}
+	u := c.baseURL.JoinPath(path)
+	u.RawQuery = query.Encode()
+
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", contentType)
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("User-Agent", c.userAgent)
+		if c.userID != "" {
+			req.Header.Set("X-User-ID", c.userID)
+		}
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", method, path, err)
+		}
+		if attempt >= c.retries || !retryable(method, resp.StatusCode) {
+			return resp, nil
+		}
+		wait := retryAfter(resp, backoff)
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%s %s: %w", method, path, ctx.Err())
+		case <-time.After(wait):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// retryable reports whether a response is worth retrying. POST is not
+// idempotent, so it is only retried when the server cannot have processed
+// it.
+func retryable(method string, status int) bool {
+	switch {
+	case status == http.StatusTooManyRequests, status == http.StatusServiceUnavailable:
+		return true
+	case status >= http.StatusInternalServerError:
+		return method != http.MethodPost
+	}
+	return false
+}
+
+// retryAfter is how long to wait before retrying resp: its Retry-After
+// header in seconds if present, otherwise backoff.
+func retryAfter(resp *http.Response, backoff time.Duration) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		return min(time.Duration(seconds)*time.Second, maxBackoff)
+	}
+	return backoff
+}
+
+func decodeError(resp *http.Response) error {
+	apiErr := &Error{
+		StatusCode: resp.StatusCode,
+		Message:    http.StatusText(resp.StatusCode),
+		RequestID:  resp.Header.Get(common.RequestIDHeader),
+	}
+	var body common.ErrorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err == nil && body.Error.Message != "" {
+		apiErr.Code = body.Error.Code
+		apiErr.Message = body.Error.Message
+		apiErr.Detail = body.Error.Detail
+		if body.RequestID != "" {
+			apiErr.RequestID = body.RequestID
+		}
+	}
+	return apiErr
+}
+
+func idPath(prefix string, id uint) string {
+	return prefix + "/" + strconv.FormatUint(uint64(id), 10)
+}
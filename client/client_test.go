@@ -0,0 +1,316 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ltphat2204/domain-driven-golang/client"
+	"github.com/ltphat2204/domain-driven-golang/config"
+	"github.com/ltphat2204/domain-driven-golang/database"
+	categoryApplication "github.com/ltphat2204/domain-driven-golang/modules/category/application"
+	categoryDTO "github.com/ltphat2204/domain-driven-golang/modules/category/dto"
+	categoryInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/category/infrastructure"
+	healthApplication "github.com/ltphat2204/domain-driven-golang/modules/health/application"
+	healthDomain "github.com/ltphat2204/domain-driven-golang/modules/health/domain"
+	searchApplication "github.com/ltphat2204/domain-driven-golang/modules/search/application"
+	searchDTO "github.com/ltphat2204/domain-driven-golang/modules/search/dto"
+	taskApplication "github.com/ltphat2204/domain-driven-golang/modules/task/application"
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	taskDTO "github.com/ltphat2204/domain-driven-golang/modules/task/dto"
+	taskInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/task/infrastructure"
+	transferApplication "github.com/ltphat2204/domain-driven-golang/modules/transfer/application"
+	transferDomain "github.com/ltphat2204/domain-driven-golang/modules/transfer/domain"
+	transferDTO "github.com/ltphat2204/domain-driven-golang/modules/transfer/dto"
+	transferInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/transfer/infrastructure"
+	viewApplication "github.com/ltphat2204/domain-driven-golang/modules/view/application"
+	viewDTO "github.com/ltphat2204/domain-driven-golang/modules/view/dto"
+	viewInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/view/infrastructure"
+	"github.com/ltphat2204/domain-driven-golang/openapi"
+	"github.com/ltphat2204/domain-driven-golang/router"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	os.Exit(m.Run())
+}
+
+// newServer serves the API over memory storage and records every request as
+// "METHOD /path".
+func newServer(t *testing.T) (*httptest.Server, func() []string) {
+	categoryRepo := categoryInfrastructure.NewMemoryCategoryRepository()
+	taskRepo := taskInfrastructure.NewMemoryTaskRepository(categoryRepo)
+	refRepo := transferInfrastructure.NewMemoryExternalRefRepository()
+	transactor := database.NewMemoryTransactor(taskRepo.(database.Snapshotter), categoryRepo.(database.Snapshotter), refRepo.(database.Snapshotter))
+	taskService := taskApplication.NewTaskService(taskRepo)
+	categoryService := categoryApplication.NewCategoryService(categoryRepo, config.ColorPalette)
+	r := router.New(router.Services{
+		Tasks:      taskService,
+		Bulk:       taskApplication.NewBulkService(taskService, transactor, 100),
+		Categories: categoryService,
+		Views:      viewApplication.NewViewService(viewInfrastructure.NewMemoryViewRepository(), taskService),
+		Transfer:   transferApplication.NewTransferService(taskService, categoryService, refRepo, transactor, transferInfrastructure.Codecs(), transferInfrastructure.Importers()),
+		Search:     searchApplication.NewSearchService(taskService, categoryService),
+		Health:     healthApplication.NewHealthService(),
+	})
+
+	var mu sync.Mutex
+	var seen []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		seen = append(seen, req.Method+" "+req.URL.Path)
+		mu.Unlock()
+		r.ServeHTTP(w, req)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), seen...)
+	}
+}
+
+func TestClient(t *testing.T) {
+	srv, seen := newServer(t)
+	ctx := context.Background()
+	c, err := client.New(srv.URL, client.WithUserID("alice"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Categories
+	ops, err := c.Categories().Create(ctx, categoryDTO.CategoryCreateDTO{Name: "Ops"})
+	if err != nil {
+		t.Fatalf("Categories().Create: %v", err)
+	}
+	if got, err := c.Categories().Get(ctx, ops.ID); err != nil || got.Name != "Ops" {
+		t.Errorf("Categories().Get = %+v, %v", got, err)
+	}
+	renamed := "Operations"
+	if got, err := c.Categories().Update(ctx, ops.ID, categoryDTO.CategoryUpdateDTO{Name: &renamed}); err != nil || got.Name != renamed {
+		t.Errorf("Categories().Update = %+v, %v", got, err)
+	}
+	if page, err := c.Categories().List(ctx, categoryDTO.CategoryQueryDTO{}); err != nil || len(page.Categories) != 1 {
+		t.Errorf("Categories().List = %+v, %v", page, err)
+	}
+	scratch, _ := c.Categories().Create(ctx, categoryDTO.CategoryCreateDTO{Name: "Scratch"})
+	if err := c.Categories().Delete(ctx, scratch.ID); err != nil {
+		t.Errorf("Categories().Delete: %v", err)
+	}
+	var names []string
+	for category, err := range c.Categories().All(ctx, categoryDTO.CategoryQueryDTO{PageSize: 1}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, category.Name)
+	}
+	if strings.Join(names, ",") != renamed {
+		t.Errorf("Categories().All = %v", names)
+	}
+
+	// Tasks
+	var ids []uint
+	for _, title := range []string{"deploy", "rollback", "page on-call", "write postmortem", "close incident"} {
+		task, err := c.Tasks().Create(ctx, taskDTO.TaskCreateDTO{Title: title, CategoryID: &ops.ID})
+		if err != nil {
+			t.Fatalf("Tasks().Create: %v", err)
+		}
+		ids = append(ids, task.ID)
+	}
+	done := string(taskDomain.StatusDone)
+	task, err := c.Tasks().Update(ctx, ids[0], taskDTO.TaskUpdateDTO{Status: &done, CategoryID: &ops.ID})
+	if err != nil || task.Status != taskDomain.StatusDone {
+		t.Errorf("Tasks().Update = %+v, %v", task, err)
+	}
+	if task, err := c.Tasks().Get(ctx, ids[0]); err != nil || task.Status != taskDomain.StatusDone || task.Category == nil {
+		t.Errorf("Tasks().Get = %+v, %v", task, err)
+	}
+	page, err := c.Tasks().List(ctx, taskDTO.TaskQueryDTO{Status: "Pending", PageSize: 2})
+	if err != nil || len(page.Tasks) != 2 || page.Meta.Total == nil || *page.Meta.Total != 4 {
+		t.Errorf("Tasks().List = %+v, %v", page, err)
+	}
+	count := 0
+	for _, err := range c.Tasks().All(ctx, taskDTO.TaskQueryDTO{PageSize: 2}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		count++
+	}
+	if count != len(ids) {
+		t.Errorf("Tasks().All yielded %d tasks, want %d", count, len(ids))
+	}
+	bulk, err := c.Tasks().Bulk(ctx, taskDTO.TaskBulkDTO{Operations: []taskDTO.TaskBulkOperationDTO{
+		{Op: "set_status", ID: ids[4], Status: &done},
+		{Op: "move", ID: ids[4]},
+	}})
+	if err != nil || !bulk.Committed || bulk.Applied != 2 || bulk.Results[1].Task.CategoryID != nil {
+		t.Errorf("Tasks().Bulk = %+v, %v", bulk, err)
+	}
+	if err := c.Tasks().Delete(ctx, ids[4]); err != nil {
+		t.Errorf("Tasks().Delete: %v", err)
+	}
+
+	// Errors
+	_, err = c.Tasks().Get(ctx, ids[4])
+	var apiErr *client.Error
+	if !client.IsNotFound(err) || !errors.As(err, &apiErr) || apiErr.Message != "Task not found" || apiErr.RequestID == "" {
+		t.Errorf("Get of a deleted task = %#v, want a 404 *client.Error with a request ID", err)
+	}
+	_, err = c.Tasks().List(ctx, taskDTO.TaskQueryDTO{Filter: "status ="})
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || apiErr.Message != "Invalid filter" {
+		t.Errorf("List with a bad filter = %v", err)
+	}
+
+	// Views
+	view, err := c.Views().Create(ctx, viewDTO.ViewCreateDTO{Name: "Open", Status: "Pending"})
+	if err != nil {
+		t.Fatalf("Views().Create: %v", err)
+	}
+	pinned := true
+	if got, err := c.Views().Update(ctx, view.ID, viewDTO.ViewUpdateDTO{Pinned: &pinned}); err != nil || !got.Pinned {
+		t.Errorf("Views().Update = %+v, %v", got, err)
+	}
+	if got, err := c.Views().Get(ctx, view.ID); err != nil || got.Name != "Open" {
+		t.Errorf("Views().Get = %+v, %v", got, err)
+	}
+	if views, err := c.Views().List(ctx); err != nil || len(views) != 1 {
+		t.Errorf("Views().List = %v, %v", views, err)
+	}
+	if page, err := c.Views().Tasks(ctx, view.ID, viewDTO.ViewTasksQueryDTO{}); err != nil || len(page.Tasks) != 3 {
+		t.Errorf("Views().Tasks = %+v, %v", page, err)
+	}
+	count = 0
+	for _, err := range c.Views().AllTasks(ctx, view.ID, viewDTO.ViewTasksQueryDTO{PageSize: 1}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		count++
+	}
+	if count != 3 {
+		t.Errorf("Views().AllTasks yielded %d tasks, want 3", count)
+	}
+	if err := c.Views().Delete(ctx, view.ID); err != nil {
+		t.Errorf("Views().Delete: %v", err)
+	}
+	anonymous, _ := client.New(srv.URL)
+	if _, err := anonymous.Views().List(ctx); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Views().List without a user = %v, want 401", err)
+	}
+
+	// Transfer
+	var export bytes.Buffer
+	if err := c.Transfer().Export(ctx, transferDomain.FormatNDJSON, &export); err != nil {
+		t.Fatalf("Transfer().Export: %v", err)
+	}
+	rows := bytes.Count(export.Bytes(), []byte("\n"))
+	report, err := c.Transfer().Import(ctx, transferDomain.FormatNDJSON, &export, transferDTO.ImportQueryDTO{DryRun: true})
+	if err != nil || !report.DryRun || report.Rows != rows || report.Failed != 0 {
+		t.Errorf("Transfer().Import of the %d exported rows = %+v, %v", rows, report, err)
+	}
+
+	// Search and probes
+	result, err := c.Search(ctx, searchDTO.SearchQueryDTO{Q: "postmortem"})
+	if err != nil || len(result.Hits) != 1 || result.Hits[0].Title != "write postmortem" {
+		t.Errorf("Search = %+v, %v", result, err)
+	}
+	for name, probe := range map[string]func(context.Context) (*healthDomain.Report, error){
+		"Liveness": c.Liveness, "Readiness": c.Readiness,
+	} {
+		if report, err := probe(ctx); err != nil || !report.Healthy() {
+			t.Errorf("%s = %+v, %v", name, report, err)
+		}
+	}
+
+	assertEveryOperationCalled(t, srv.URL, seen())
+}
+
+// assertEveryOperationCalled fails if the OpenAPI document has an operation
+// the test did not exercise through the client, so new endpoints get a
+// client method.
+func assertEveryOperationCalled(t *testing.T, baseURL string, calls []string) {
+	t.Helper()
+	resp, err := http.Get(baseURL + "/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var doc openapi.Document
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		t.Fatal(err)
+	}
+
+	// GraphQL is served to GraphQL clients rather than wrapped.
+	notWrapped := map[string]bool{
+		"GET /openapi.json": true, "GET /docs": true, "GET /docs/swagger-ui-bundle.js": true, "GET /docs/swagger-ui.css": true,
+		"POST /graphql": true, "GET /graphql": true, "GET /graphql/schema.graphql": true,
+	}
+	for _, route := range doc.Routes() {
+		if notWrapped[route] {
+			continue
+		}
+		parts := strings.Split(regexp.QuoteMeta(route), "/")
+		for i, part := range parts {
+			if strings.HasPrefix(part, `\{`) {
+				parts[i] = `[^/]+`
+			}
+		}
+		pattern := regexp.MustCompile("^" + strings.Join(parts, "/") + "$")
+		called := false
+		for _, call := range calls {
+			called = called || pattern.MatchString(call)
+		}
+		if !called {
+			t.Errorf("%s has no client method exercised by this test", route)
+		}
+	}
+}
+
+func TestRetries(t *testing.T) {
+	var calls atomic.Int32
+	status := http.StatusServiceUnavailable
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(status)
+			return
+		}
+		io.WriteString(w, `{"success":true,"data":{"ID":7,"Title":"retried"}}`)
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	c, _ := client.New(srv.URL, client.WithRetries(3, time.Millisecond))
+	task, err := c.Tasks().Get(ctx, 7)
+	if err != nil || task.Title != "retried" || calls.Load() != 3 {
+		t.Errorf("Get after two 503s = %+v, %v after %d calls", task, err, calls.Load())
+	}
+
+	calls.Store(0)
+	status = http.StatusInternalServerError
+	_, err = c.Tasks().Create(ctx, taskDTO.TaskCreateDTO{Title: "once"})
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError || calls.Load() != 1 {
+		t.Errorf("Create on a 500 = %v after %d calls, want no retry", err, calls.Load())
+	}
+
+	calls.Store(0)
+	c, _ = client.New(srv.URL, client.WithRetries(1, time.Millisecond))
+	if _, err := c.Tasks().Get(ctx, 7); !errors.As(err, &apiErr) || calls.Load() != 2 {
+		t.Errorf("Get with one retry = %v after %d calls", err, calls.Load())
+	}
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/url"
+	"strconv"
+
+	"github.com/ltphat2204/domain-driven-golang/common"
+)
+
+// pageFetcher fetches one page of a list endpoint.
+type pageFetcher[T any] func(ctx context.Context, query url.Values) ([]T, common.PaginationMeta, error)
+
+// paginate yields every item of a list, following next_cursor, or the page
+// number where the sort order has no cursor (relevance). Totals are not
+// needed to iterate, so unless the query asks for them they are skipped.
+// Iteration stops at the first error, which is yielded with a zero item.
+func paginate[T any](ctx context.Context, query url.Values, fetch pageFetcher[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		if !query.Has("include_total") {
+			query.Set("include_total", "false")
+		}
+		for {
+			items, meta, err := fetch(ctx, query)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if !meta.HasMore || len(items) == 0 {
+				return
+			}
+			if meta.NextCursor != "" {
+				query.Set("cursor", meta.NextCursor)
+				query.Del("page")
+			} else {
+				query.Set("page", strconv.Itoa(max(meta.Page, 1)+1))
+			}
+		}
+	}
+}
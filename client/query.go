@@ -0,0 +1,34 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+)
+
+// encodeQuery turns a query DTO into URL parameters named by its form tags,
+// leaving out zero values so the server applies its defaults.
+func encodeQuery(dto interface{}) url.Values {
+	values := url.Values{}
+	v := reflect.ValueOf(dto)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("form")
+		field := v.Field(i)
+		if name == "" || name == "-" || field.IsZero() {
+			continue
+		}
+		switch field.Kind() {
+		case reflect.Pointer:
+			field = field.Elem()
+		case reflect.Slice:
+			// Repeated parameters.
+			for j := 0; j < field.Len(); j++ {
+				values.Add(name, fmt.Sprint(field.Index(j).Interface()))
+			}
+			continue
+		}
+		values.Set(name, fmt.Sprint(field.Interface()))
+	}
+	return values
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	healthDomain "github.com/ltphat2204/domain-driven-golang/modules/health/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/search/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/search/dto"
+)
+
+// Search searches tasks and categories. Hit.Data holds the matched entity as
+// decoded JSON.
+func (c *Client) Search(ctx context.Context, query dto.SearchQueryDTO) (*domain.Result, error) {
+	var result domain.Result
+	if err := c.call(ctx, "GET", "/search", encodeQuery(query), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Liveness fetches /healthz. A failing report is returned with a nil error;
+// check its Status.
+func (c *Client) Liveness(ctx context.Context) (*healthDomain.Report, error) {
+	return c.probe(ctx, "/healthz")
+}
+
+// Readiness fetches /readyz. A failing report is returned with a nil error;
+// check its Status.
+func (c *Client) Readiness(ctx context.Context) (*healthDomain.Report, error) {
+	return c.probe(ctx, "/readyz")
+}
+
+// probe is not retried: a 503 is the answer, not a transient failure.
+func (c *Client) probe(ctx context.Context, path string) (*healthDomain.Report, error) {
+	noRetries := *c
+	noRetries.retries = 0
+	resp, err := noRetries.do(ctx, "GET", path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusServiceUnavailable {
+		return nil, decodeError(resp)
+	}
+
+	var report healthDomain.Report
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return nil, fmt.Errorf("GET %s: decoding response: %w", path, err)
+	}
+	return &report, nil
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/url"
+
+	"github.com/ltphat2204/domain-driven-golang/common"
+	"github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/task/dto"
+)
+
+// TaskService calls the /tasks endpoints.
+type TaskService struct {
+	client *Client
+}
+
+func (s *TaskService) Create(ctx context.Context, input dto.TaskCreateDTO) (*domain.Task, error) {
+	var task domain.Task
+	if err := s.client.call(ctx, "POST", "/tasks", nil, input, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (s *TaskService) Get(ctx context.Context, id uint) (*domain.Task, error) {
+	var task domain.Task
+	if err := s.client.call(ctx, "GET", idPath("/tasks", id), nil, nil, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// List returns one page of tasks.
+func (s *TaskService) List(ctx context.Context, query dto.TaskQueryDTO) (*dto.TaskListResponse, error) {
+	return s.list(ctx, encodeQuery(query))
+}
+
+// All iterates over every task matching query, fetching pages as needed.
+func (s *TaskService) All(ctx context.Context, query dto.TaskQueryDTO) iter.Seq2[*domain.Task, error] {
+	return paginate(ctx, encodeQuery(query), func(ctx context.Context, q url.Values) ([]*domain.Task, common.PaginationMeta, error) {
+		page, err := s.list(ctx, q)
+		if err != nil {
+			return nil, common.PaginationMeta{}, err
+		}
+		return page.Tasks, page.Meta, nil
+	})
+}
+
+func (s *TaskService) list(ctx context.Context, query url.Values) (*dto.TaskListResponse, error) {
+	var page dto.TaskListResponse
+	if err := s.client.call(ctx, "GET", "/tasks", query, nil, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// Update changes the fields set in input. As on the server, a nil
+// CategoryID removes the task's category.
+func (s *TaskService) Update(ctx context.Context, id uint, input dto.TaskUpdateDTO) (*domain.Task, error) {
+	var task domain.Task
+	if err := s.client.call(ctx, "PATCH", idPath("/tasks", id), nil, input, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (s *TaskService) Delete(ctx context.Context, id uint) error {
+	return s.client.call(ctx, "DELETE", idPath("/tasks", id), nil, nil, nil)
+}
+
+// Bulk runs several operations in one request. Operations that fail are
+// reported in the result rather than as an error; an atomic request that
+// had one comes back with Committed false.
+func (s *TaskService) Bulk(ctx context.Context, input dto.TaskBulkDTO) (*domain.BulkResult, error) {
+	var result domain.BulkResult
+	if err := s.client.call(ctx, "POST", "/tasks/bulk", nil, input, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/dto"
+)
+
+// TransferService calls the /export and /import endpoints.
+type TransferService struct {
+	client *Client
+}
+
+// Export copies an export of every category and task in format to w.
+func (s *TransferService) Export(ctx context.Context, format domain.Format, w io.Writer) error {
+	resp, err := s.client.do(ctx, "GET", "/export", url.Values{"format": {string(format)}}, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return decodeError(resp)
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("GET /export: %w", err)
+	}
+	return nil
+}
+
+// Import sends the file r holds in format. It reads r whole first, so the
+// request can be retried. query.Format is set from format.
+func (s *TransferService) Import(ctx context.Context, format domain.Format, r io.Reader, query dto.ImportQueryDTO) (*domain.ImportReport, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("POST /import: reading file: %w", err)
+	}
+	query.Format = string(format)
+	var report domain.ImportReport
+	if err := s.client.call(ctx, "POST", "/import", encodeQuery(query), file{contentType: format.ContentType(), data: data}, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/url"
+
+	"github.com/ltphat2204/domain-driven-golang/common"
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/view/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/view/dto"
+)
+
+// ViewService calls the /views endpoints. They act for the caller, so the
+// client needs WithUserID.
+type ViewService struct {
+	client *Client
+}
+
+func (s *ViewService) Create(ctx context.Context, input dto.ViewCreateDTO) (*domain.View, error) {
+	var view domain.View
+	if err := s.client.call(ctx, "POST", "/views", nil, input, &view); err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+func (s *ViewService) Get(ctx context.Context, id uint) (*domain.View, error) {
+	var view domain.View
+	if err := s.client.call(ctx, "GET", idPath("/views", id), nil, nil, &view); err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+// List returns the caller's views and the shared ones.
+func (s *ViewService) List(ctx context.Context) ([]*domain.View, error) {
+	var list dto.ViewListResponse
+	if err := s.client.call(ctx, "GET", "/views", nil, nil, &list); err != nil {
+		return nil, err
+	}
+	return list.Views, nil
+}
+
+func (s *ViewService) Update(ctx context.Context, id uint, input dto.ViewUpdateDTO) (*domain.View, error) {
+	var view domain.View
+	if err := s.client.call(ctx, "PATCH", idPath("/views", id), nil, input, &view); err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+func (s *ViewService) Delete(ctx context.Context, id uint) error {
+	return s.client.call(ctx, "DELETE", idPath("/views", id), nil, nil, nil)
+}
+
+// Tasks returns one page of the tasks the view matches, grouped if the view
+// groups them.
+func (s *ViewService) Tasks(ctx context.Context, id uint, query dto.ViewTasksQueryDTO) (*dto.ViewTasksResponse, error) {
+	return s.tasks(ctx, id, encodeQuery(query))
+}
+
+// AllTasks iterates over every task the view matches.
+func (s *ViewService) AllTasks(ctx context.Context, id uint, query dto.ViewTasksQueryDTO) iter.Seq2[*taskDomain.Task, error] {
+	return paginate(ctx, encodeQuery(query), func(ctx context.Context, q url.Values) ([]*taskDomain.Task, common.PaginationMeta, error) {
+		page, err := s.tasks(ctx, id, q)
+		if err != nil {
+			return nil, common.PaginationMeta{}, err
+		}
+		return page.Tasks, page.Meta, nil
+	})
+}
+
+func (s *ViewService) tasks(ctx context.Context, id uint, query url.Values) (*dto.ViewTasksResponse, error) {
+	var page dto.ViewTasksResponse
+	if err := s.client.call(ctx, "GET", idPath("/views", id)+"/tasks", query, nil, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
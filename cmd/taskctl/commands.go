@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ltphat2204/domain-driven-golang/client"
+	categoryDomain "github.com/ltphat2204/domain-driven-golang/modules/category/domain"
+	categoryDTO "github.com/ltphat2204/domain-driven-golang/modules/category/dto"
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	taskDTO "github.com/ltphat2204/domain-driven-golang/modules/task/dto"
+)
+
+type command struct {
+	client *client.Client
+	out    io.Writer
+}
+
+func (c *command) taskAdd(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("task add", flag.ContinueOnError)
+	due := fs.String("due", "", "due date, such as tomorrow 9am")
+	category := fs.String("category", "", "category name or ID")
+	description := fs.String("description", "", "description")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return usageError("task add: a title is required")
+	}
+
+	input := taskDTO.TaskCreateDTO{Title: strings.Join(positional, " "), Description: *description}
+	if *due != "" {
+		dueAt, err := parseDue(*due, now())
+		if err != nil {
+			return usageError("task add: " + err.Error())
+		}
+		input.DueAt = &dueAt
+	}
+	if *category != "" {
+		found, err := c.findCategory(ctx, *category)
+		if err != nil {
+			return err
+		}
+		input.CategoryID = &found.ID
+	}
+
+	task, err := c.client.Tasks().Create(ctx, input)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "created task %d: %s (due %s)\n", task.ID, task.Title, formatDue(task.DueAt))
+	return nil
+}
+
+func (c *command) taskList(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("task ls", flag.ContinueOnError)
+	var query taskDTO.TaskQueryDTO
+	fs.StringVar(&query.Status, "status", "", "Pending, Doing or Done")
+	fs.StringVar(&query.Search, "search", "", "full-text search")
+	fs.StringVar(&query.Filter, "filter", "", `filter expression, such as "due_at < now+7d"`)
+	category := fs.String("category", "", "category name or ID")
+	limit := fs.Int("limit", 50, "maximum number of tasks; 0 lists all")
+	format := fs.String("o", formatTable, "output format: table, json or yaml")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+	if *category != "" {
+		found, err := c.findCategory(ctx, *category)
+		if err != nil {
+			return err
+		}
+		condition := fmt.Sprintf("category_id = %d", found.ID)
+		if query.Filter != "" {
+			condition = "(" + query.Filter + ") and " + condition
+		}
+		query.Filter = condition
+	}
+
+	tasks := []*taskDomain.Task{}
+	for task, err := range c.client.Tasks().All(ctx, query) {
+		if err != nil {
+			return err
+		}
+		tasks = append(tasks, task)
+		if *limit > 0 && len(tasks) == *limit {
+			break
+		}
+	}
+	return write(c.out, *format, tasks, taskTable(tasks))
+}
+
+func (c *command) taskShow(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("task show", flag.ContinueOnError)
+	format := fs.String("o", formatTable, "output format: table, json or yaml")
+	id, err := parseID(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+	task, err := c.client.Tasks().Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	return write(c.out, *format, task, taskTable([]*taskDomain.Task{task}))
+}
+
+func (c *command) taskDone(ctx context.Context, args []string) error {
+	id, err := parseID(flag.NewFlagSet("task done", flag.ContinueOnError), args)
+	if err != nil {
+		return err
+	}
+	task, err := c.client.Tasks().Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	// The API clears the category of an update without one, so send it back.
+	done := string(taskDomain.StatusDone)
+	task, err = c.client.Tasks().Update(ctx, id, taskDTO.TaskUpdateDTO{Status: &done, CategoryID: task.CategoryID})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "task %d is done: %s\n", task.ID, task.Title)
+	return nil
+}
+
+func (c *command) taskRemove(ctx context.Context, args []string) error {
+	id, err := parseID(flag.NewFlagSet("task rm", flag.ContinueOnError), args)
+	if err != nil {
+		return err
+	}
+	if err := c.client.Tasks().Delete(ctx, id); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "deleted task %d\n", id)
+	return nil
+}
+
+func (c *command) categoryAdd(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("category add", flag.ContinueOnError)
+	description := fs.String("description", "", "description")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return usageError("category add: a name is required")
+	}
+	category, err := c.client.Categories().Create(ctx, categoryDTO.CategoryCreateDTO{
+		Name:        strings.Join(positional, " "),
+		Description: *description,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "created category %d: %s\n", category.ID, category.Name)
+	return nil
+}
+
+func (c *command) categoryList(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("category ls", flag.ContinueOnError)
+	format := fs.String("o", formatTable, "output format: table, json or yaml")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+	categories := []*categoryDomain.Category{}
+	for category, err := range c.client.Categories().All(ctx, categoryDTO.CategoryQueryDTO{SortBy: "name", SortOrder: "asc"}) {
+		if err != nil {
+			return err
+		}
+		categories = append(categories, category)
+	}
+	return write(c.out, *format, categories, categoryTable(categories))
+}
+
+// findCategory resolves a category by ID or by case-insensitive name.
+func (c *command) findCategory(ctx context.Context, nameOrID string) (*categoryDomain.Category, error) {
+	if id, err := strconv.ParseUint(nameOrID, 10, 32); err == nil {
+		return c.client.Categories().Get(ctx, uint(id))
+	}
+	for category, err := range c.client.Categories().All(ctx, categoryDTO.CategoryQueryDTO{}) {
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(category.Name, nameOrID) {
+			return category, nil
+		}
+	}
+	return nil, fmt.Errorf("no category named %q; see taskctl category ls", nameOrID)
+}
+
+// parseID parses the single task ID argument of a command.
+func parseID(fs *flag.FlagSet, args []string) (uint, error) {
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return 0, err
+	}
+	if len(positional) != 1 {
+		return 0, usageError(fs.Name() + ": expected one task ID")
+	}
+	id, err := strconv.ParseUint(positional[0], 10, 32)
+	if err != nil {
+		return 0, usageError(fmt.Sprintf("%s: invalid task ID %q", fs.Name(), positional[0]))
+	}
+	return uint(id), nil
+}
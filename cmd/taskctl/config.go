@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultServer = "http://localhost:8080"
+
+// settings say which server to talk to and as whom. Flags override the
+// TASKCTL_* environment variables, which override the config file.
+type settings struct {
+	Server string `yaml:"server"`
+	Token  string `yaml:"token"`
+	User   string `yaml:"user"`
+}
+
+// defaultConfigPath is $XDG_CONFIG_HOME/taskctl/config.yaml or the platform
+// equivalent.
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "taskctl", "config.yaml")
+}
+
+// loadSettings reads the config file at path, then applies the environment
+// and the flag values that were set. A missing file is only an error when
+// the path was given explicitly.
+func loadSettings(path string, explicit bool, flags settings) (settings, error) {
+	s := settings{Server: defaultServer}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist) && !explicit:
+		case err != nil:
+			return s, err
+		default:
+			decoder := yaml.NewDecoder(bytes.NewReader(data))
+			decoder.KnownFields(true)
+			if err := decoder.Decode(&s); err != nil && !errors.Is(err, io.EOF) {
+				return s, fmt.Errorf("%s: %w", path, err)
+			}
+		}
+	}
+
+	for _, override := range []settings{
+		{Server: os.Getenv("TASKCTL_SERVER"), Token: os.Getenv("TASKCTL_TOKEN"), User: os.Getenv("TASKCTL_USER")},
+		flags,
+	} {
+		if override.Server != "" {
+			s.Server = override.Server
+		}
+		if override.Token != "" {
+			s.Token = override.Token
+		}
+		if override.User != "" {
+			s.User = override.User
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultHour is the time of day given to due dates named only by their
+// day, such as "tomorrow" or "2025-06-15": the end of the working day.
+const defaultHour = 17
+
+var (
+	inPattern    = regexp.MustCompile(`^(?:in\s+)?\+?(\d+)\s*(m|min|mins|minutes?|h|hrs?|hours?|d|days?|w|wks?|weeks?|months?)$`)
+	clockPattern = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+)
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// parseDue reads a due date relative to now, in now's location:
+//
+//	2025-06-15, 2025-06-15 14:30, 2025-06-15T14:30:00Z
+//	now, today, tonight, tomorrow, next week, next month
+//	friday, next friday         the coming Friday, never today
+//	in 3 days, +2h, 90m, 1w
+//
+// Day expressions take an optional time, as in "tomorrow 9am", "friday at
+// 14:30" or "today noon"; without one they are due at 17:00.
+func parseDue(text string, now time.Time) (time.Time, error) {
+	text = strings.Join(strings.Fields(strings.ToLower(text)), " ")
+	if text == "" {
+		return time.Time{}, fmt.Errorf("empty due date")
+	}
+	if t, err := time.Parse(time.RFC3339, strings.ToUpper(text)); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02t15:04", "2006-01-02 15:04"} {
+		if t, err := time.ParseInLocation(layout, text, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	if text == "now" {
+		return now, nil
+	}
+	if m := inPattern.FindStringSubmatch(text); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		switch unit := m[2]; {
+		case strings.HasPrefix(unit, "mo"):
+			return now.AddDate(0, n, 0), nil
+		case strings.HasPrefix(unit, "m"):
+			return now.Add(time.Duration(n) * time.Minute), nil
+		case strings.HasPrefix(unit, "h"):
+			return now.Add(time.Duration(n) * time.Hour), nil
+		case strings.HasPrefix(unit, "d"):
+			return now.AddDate(0, 0, n), nil
+		default:
+			return now.AddDate(0, 0, 7*n), nil
+		}
+	}
+
+	day, clock := splitClock(text)
+	date, ok := parseDay(day, now)
+	if !ok {
+		return time.Time{}, fmt.Errorf("cannot understand due date %q; try tomorrow, friday 9am, in 3 days or 2025-06-15", text)
+	}
+	hour, minute := defaultHour, 0
+	if day == "tonight" {
+		hour = 20
+	}
+	if clock != "" {
+		var err error
+		if hour, minute, err = parseClock(clock); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, now.Location()), nil
+}
+
+// splitClock separates a trailing time of day ("9am", "14:30", "at 9",
+// "noon") from the day part. A bare number is only a time after "at", so
+// "jun 20" stays a date.
+func splitClock(text string) (day, clock string) {
+	words := strings.Fields(text)
+	if len(words) < 2 {
+		return text, ""
+	}
+	last, before := words[len(words)-1], words[len(words)-2]
+	at := before == "at" || before == "@"
+	m := clockPattern.FindStringSubmatch(last)
+	if last == "noon" || last == "midnight" || m != nil && (m[2] != "" || m[3] != "" || at) {
+		words = words[:len(words)-1]
+		if at {
+			words = words[:len(words)-1]
+		}
+		return strings.Join(words, " "), last
+	}
+	return text, ""
+}
+
+func parseClock(clock string) (hour, minute int, err error) {
+	switch clock {
+	case "noon":
+		return 12, 0, nil
+	case "midnight":
+		return 0, 0, nil
+	}
+	m := clockPattern.FindStringSubmatch(clock)
+	hour, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+	switch {
+	case m[3] == "" && hour < 24, m[3] != "" && hour >= 1 && hour <= 12:
+	default:
+		return 0, 0, fmt.Errorf("invalid time of day %q", clock)
+	}
+	if m[3] == "pm" && hour != 12 {
+		hour += 12
+	}
+	if m[3] == "am" && hour == 12 {
+		hour = 0
+	}
+	if minute > 59 {
+		return 0, 0, fmt.Errorf("invalid time of day %q", clock)
+	}
+	return hour, minute, nil
+}
+
+// parseDay resolves the day part of a due date.
+func parseDay(day string, now time.Time) (time.Time, bool) {
+	switch day {
+	case "today", "tonight", "eod":
+		return now, true
+	case "tomorrow", "tmr", "tmrw":
+		return now.AddDate(0, 0, 1), true
+	case "next week":
+		return now.AddDate(0, 0, 7), true
+	case "next month":
+		return now.AddDate(0, 1, 0), true
+	}
+	if weekday, ok := weekdays[strings.TrimPrefix(day, "next ")]; ok {
+		days := (int(weekday)-int(now.Weekday())+6)%7 + 1
+		return now.AddDate(0, 0, days), true
+	}
+	for _, layout := range []string{"2006-01-02", "01/02", "Jan 2", "January 2"} {
+		t, err := time.ParseInLocation(layout, day, now.Location())
+		if err != nil {
+			continue
+		}
+		if t.Year() == 0 {
+			// A month and day without a year is the next one to come.
+			t = t.AddDate(now.Year(), 0, 0)
+			if t.Before(truncateDay(now)) {
+				t = t.AddDate(1, 0, 0)
+			}
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
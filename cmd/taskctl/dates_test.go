@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDue(t *testing.T) {
+	// Wednesday 11 June 2025, 10:30.
+	now := time.Date(2025, time.June, 11, 10, 30, 0, 0, time.UTC)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		year := 2025
+		if month < time.June {
+			year = 2026
+		}
+		return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		text string
+		want time.Time
+	}{
+		{"2025-06-15", at(time.June, 15, 17, 0)},
+		{"2025-06-15 14:30", at(time.June, 15, 14, 30)},
+		{"2025-06-15T14:30", at(time.June, 15, 14, 30)},
+		{"2025-06-15T14:30:00Z", at(time.June, 15, 14, 30)},
+		{"now", now},
+		{"today", at(time.June, 11, 17, 0)},
+		{"tonight", at(time.June, 11, 20, 0)},
+		{"Tomorrow", at(time.June, 12, 17, 0)},
+		{"tomorrow 9am", at(time.June, 12, 9, 0)},
+		{"tomorrow at 2:15pm", at(time.June, 12, 14, 15)},
+		{"today noon", at(time.June, 11, 12, 0)},
+		{"friday at 9", at(time.June, 13, 9, 0)},
+		{"friday", at(time.June, 13, 17, 0)},
+		{"wed", at(time.June, 18, 17, 0)},
+		{"next monday 08:00", at(time.June, 16, 8, 0)},
+		{"next week", at(time.June, 18, 17, 0)},
+		{"next month", at(time.July, 11, 17, 0)},
+		{"in 3 days", at(time.June, 14, 10, 30)},
+		{"+2h", at(time.June, 11, 12, 30)},
+		{"90m", at(time.June, 11, 12, 0)},
+		{"1w", at(time.June, 18, 10, 30)},
+		{"jun 20", at(time.June, 20, 17, 0)},
+		{"january 5 12am", at(time.January, 5, 0, 0)},
+		{"06/01", at(time.June, 1, 17, 0).AddDate(1, 0, 0)},
+	}
+	for _, tt := range tests {
+		got, err := parseDue(tt.text, now)
+		if err != nil {
+			t.Errorf("parseDue(%q): %v", tt.text, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseDue(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+
+	for _, text := range []string{"", "someday", "tomorrow 25:00", "friday 13pm", "in 3 fortnights"} {
+		if got, err := parseDue(text, now); err == nil {
+			t.Errorf("parseDue(%q) = %v, want an error", text, got)
+		}
+	}
+}
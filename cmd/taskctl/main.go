@@ -0,0 +1,140 @@
+// Command taskctl manages tasks and categories from the terminal:
+//
+//	taskctl task add "Rotate certificates" --due "friday 10am" --category ops
+//	taskctl task ls --status Doing -o table
+//	taskctl task done 42
+//	taskctl category ls
+//
+// The server URL, token and user come from --server/--token/--user, the
+// TASKCTL_SERVER/TASKCTL_TOKEN/TASKCTL_USER environment variables or the
+// config file (--config, default ~/.config/taskctl/config.yaml).
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/ltphat2204/domain-driven-golang/client"
+)
+
+const usage = `usage: taskctl [--server URL] [--token TOKEN] [--user ID] [--config FILE] <command>
+
+commands:
+  task add <title> [--due WHEN] [--category NAME|ID] [--description TEXT]
+  task ls [--status S] [--category NAME|ID] [--search Q] [--filter EXPR] [--limit N] [-o table|json|yaml]
+  task show <id> [-o table|json|yaml]
+  task done <id>
+  task rm <id>
+  category add <name> [--description TEXT]
+  category ls [-o table|json|yaml]
+
+WHEN is a date such as 2025-06-15 14:00, or natural language such as
+tomorrow, friday 9am, next week or in 3 days.`
+
+// now is the reference time for relative due dates; tests pin it.
+var now = time.Now
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err := run(ctx, os.Args[1:], os.Stdout)
+	stop()
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, "taskctl:", err)
+	var usage usageError
+	if errors.As(err, &usage) {
+		os.Exit(2)
+	}
+	os.Exit(1)
+}
+
+// usageError is a command-line mistake; it exits with status 2.
+type usageError string
+
+func (e usageError) Error() string { return string(e) }
+
+func run(ctx context.Context, args []string, stdout io.Writer) error {
+	global := flag.NewFlagSet("taskctl", flag.ContinueOnError)
+	global.SetOutput(io.Discard)
+	var flags settings
+	configPath := global.String("config", os.Getenv("TASKCTL_CONFIG"), "config file")
+	global.StringVar(&flags.Server, "server", "", "API base URL")
+	global.StringVar(&flags.Token, "token", "", "API token")
+	global.StringVar(&flags.User, "user", "", "user ID sent as X-User-ID")
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(stdout, usage)
+			return err
+		}
+		return usageError(err.Error() + "\n" + usage)
+	}
+	args = global.Args()
+	if len(args) < 2 {
+		return usageError(usage)
+	}
+
+	explicit := *configPath != ""
+	if !explicit {
+		*configPath = defaultConfigPath()
+	}
+	s, err := loadSettings(*configPath, explicit, flags)
+	if err != nil {
+		return err
+	}
+	opts := []client.Option{client.WithUserAgent("taskctl")}
+	if s.Token != "" {
+		opts = append(opts, client.WithToken(s.Token))
+	}
+	if s.User != "" {
+		opts = append(opts, client.WithUserID(s.User))
+	}
+	c, err := client.New(s.Server, opts...)
+	if err != nil {
+		return err
+	}
+
+	cmd := &command{client: c, out: stdout}
+	switch args[0] + " " + args[1] {
+	case "task add":
+		return cmd.taskAdd(ctx, args[2:])
+	case "task ls", "task list":
+		return cmd.taskList(ctx, args[2:])
+	case "task show":
+		return cmd.taskShow(ctx, args[2:])
+	case "task done":
+		return cmd.taskDone(ctx, args[2:])
+	case "task rm", "task delete":
+		return cmd.taskRemove(ctx, args[2:])
+	case "category add":
+		return cmd.categoryAdd(ctx, args[2:])
+	case "category ls", "category list":
+		return cmd.categoryList(ctx, args[2:])
+	}
+	return usageError(fmt.Sprintf("unknown command %q\n%s", args[0]+" "+args[1], usage))
+}
+
+// parseFlags parses flags that may come before, between or after the
+// positional arguments, which it returns.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	fs.SetOutput(io.Discard)
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, usageError(fmt.Sprintf("%s: %v", fs.Name(), err))
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
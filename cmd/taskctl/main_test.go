@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ltphat2204/domain-driven-golang/config"
+	"github.com/ltphat2204/domain-driven-golang/database"
+	categoryApplication "github.com/ltphat2204/domain-driven-golang/modules/category/application"
+	categoryInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/category/infrastructure"
+	healthApplication "github.com/ltphat2204/domain-driven-golang/modules/health/application"
+	searchApplication "github.com/ltphat2204/domain-driven-golang/modules/search/application"
+	taskApplication "github.com/ltphat2204/domain-driven-golang/modules/task/application"
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	taskInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/task/infrastructure"
+	transferApplication "github.com/ltphat2204/domain-driven-golang/modules/transfer/application"
+	transferInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/transfer/infrastructure"
+	viewApplication "github.com/ltphat2204/domain-driven-golang/modules/view/application"
+	viewInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/view/infrastructure"
+	"github.com/ltphat2204/domain-driven-golang/router"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	os.Exit(m.Run())
+}
+
+// newConfig serves the API over memory storage and returns a config file
+// pointing at it.
+func newConfig(t *testing.T) string {
+	categoryRepo := categoryInfrastructure.NewMemoryCategoryRepository()
+	taskRepo := taskInfrastructure.NewMemoryTaskRepository(categoryRepo)
+	refRepo := transferInfrastructure.NewMemoryExternalRefRepository()
+	transactor := database.NewMemoryTransactor(taskRepo.(database.Snapshotter), categoryRepo.(database.Snapshotter), refRepo.(database.Snapshotter))
+	taskService := taskApplication.NewTaskService(taskRepo)
+	categoryService := categoryApplication.NewCategoryService(categoryRepo, config.ColorPalette)
+	srv := httptest.NewServer(router.New(router.Services{
+		Tasks:      taskService,
+		Bulk:       taskApplication.NewBulkService(taskService, transactor, 100),
+		Categories: categoryService,
+		Views:      viewApplication.NewViewService(viewInfrastructure.NewMemoryViewRepository(), taskService),
+		Transfer:   transferApplication.NewTransferService(taskService, categoryService, refRepo, transactor, transferInfrastructure.Codecs(), transferInfrastructure.Importers()),
+		Search:     searchApplication.NewSearchService(taskService, categoryService),
+		Health:     healthApplication.NewHealthService(),
+	}))
+	t.Cleanup(srv.Close)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("server: "+srv.URL+"\nuser: alice\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCommands(t *testing.T) {
+	now = func() time.Time { return time.Date(2025, time.June, 11, 10, 30, 0, 0, time.Local) }
+	t.Cleanup(func() { now = time.Now })
+	path := newConfig(t)
+
+	taskctl := func(args ...string) string {
+		t.Helper()
+		var out bytes.Buffer
+		if err := run(context.Background(), append([]string{"--config", path}, args...), &out); err != nil {
+			t.Fatalf("taskctl %s: %v", strings.Join(args, " "), err)
+		}
+		return out.String()
+	}
+
+	taskctl("category", "add", "Ops", "--description", "Operations")
+	if out := taskctl("task", "add", "Rotate certificates", "--due", "tomorrow", "--category", "ops"); !strings.Contains(out, "created task 1: Rotate certificates (due 2025-06-12 17:00)") {
+		t.Errorf("task add printed %q", out)
+	}
+	taskctl("task", "add", "Write", "runbook")
+
+	out := taskctl("task", "ls", "--category", "Ops")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[1], "Ops") || !strings.Contains(lines[1], "2025-06-12 17:00") {
+		t.Errorf("task ls --category Ops printed\n%s", out)
+	}
+
+	taskctl("task", "done", "1")
+	var tasks []taskDomain.Task
+	if err := json.Unmarshal([]byte(taskctl("task", "ls", "--status", "Done", "-o", "json")), &tasks); err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != 1 || tasks[0].CategoryID == nil {
+		t.Errorf("done tasks = %+v, want task 1 still in its category", tasks)
+	}
+
+	if out := taskctl("task", "ls", "-o", "yaml"); !strings.Contains(out, "Title: Write runbook") {
+		t.Errorf("task ls -o yaml printed\n%s", out)
+	}
+	if out := taskctl("category", "ls"); !strings.Contains(out, "Operations") {
+		t.Errorf("category ls printed\n%s", out)
+	}
+	taskctl("task", "rm", "2")
+
+	var usage usageError
+	for _, args := range [][]string{
+		{"task"},
+		{"task", "fly"},
+		{"task", "done"},
+		{"task", "ls", "-o", "xml"},
+		{"task", "add", "x", "--due", "someday"},
+	} {
+		err := run(context.Background(), append([]string{"--config", path}, args...), io.Discard)
+		if !errors.As(err, &usage) {
+			t.Errorf("taskctl %s: got %v, want a usage error", strings.Join(args, " "), err)
+		}
+	}
+	err := run(context.Background(), []string{"--config", path, "task", "show", "2"}, io.Discard)
+	if err == nil || !strings.Contains(err.Error(), "404") && !strings.Contains(strings.ToLower(err.Error()), "not found") {
+		t.Errorf("task show 2 after rm: %v", err)
+	}
+	err = run(context.Background(), []string{"--config", path, "task", "add", "x", "--category", "nope"}, io.Discard)
+	if err == nil || !strings.Contains(err.Error(), `no category named "nope"`) {
+		t.Errorf("task add --category nope: %v", err)
+	}
+}
+
+func TestLoadSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("server: http://file\ntoken: file-token\nuser: file-user\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TASKCTL_TOKEN", "env-token")
+	t.Setenv("TASKCTL_USER", "env-user")
+
+	s, err := loadSettings(path, true, settings{User: "flag-user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (settings{Server: "http://file", Token: "env-token", User: "flag-user"}); s != want {
+		t.Errorf("settings = %+v, want %+v", s, want)
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing.yaml")
+	if s, err := loadSettings(missing, false, settings{}); err != nil || s.Server != defaultServer {
+		t.Errorf("implicit missing file: %+v, %v", s, err)
+	}
+	if _, err := loadSettings(missing, true, settings{}); err == nil {
+		t.Error("explicit missing file: want an error")
+	}
+	if err := os.WriteFile(path, []byte("sever: typo\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadSettings(path, true, settings{}); err == nil {
+		t.Error("unknown key: want an error")
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	categoryDomain "github.com/ltphat2204/domain-driven-golang/modules/category/domain"
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+func checkFormat(format string) error {
+	switch format {
+	case formatTable, formatJSON, formatYAML:
+		return nil
+	}
+	return usageError(fmt.Sprintf("unknown output format %q; use table, json or yaml", format))
+}
+
+// write prints value as JSON or YAML with the API's field names, or calls
+// table to print it as aligned columns.
+func write(w io.Writer, format string, value interface{}, table func(tw *tabwriter.Writer)) error {
+	switch format {
+	case formatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	case formatYAML:
+		// Round-trip through JSON so keys match the API rather than yaml's
+		// lowercased field names.
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		var generic interface{}
+		if err := json.Unmarshal(data, &generic); err != nil {
+			return err
+		}
+		return yaml.NewEncoder(w).Encode(generic)
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	table(tw)
+	return tw.Flush()
+}
+
+func taskTable(tasks []*taskDomain.Task) func(*tabwriter.Writer) {
+	return func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "ID\tSTATUS\tDUE\tCATEGORY\tTITLE")
+		for _, t := range tasks {
+			category := "-"
+			if t.Category != nil {
+				category = t.Category.Name
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", t.ID, t.Status, formatDue(t.DueAt), category, t.Title)
+		}
+	}
+}
+
+func categoryTable(categories []*categoryDomain.Category) func(*tabwriter.Writer) {
+	return func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "ID\tNAME\tCOLOR\tDESCRIPTION")
+		for _, c := range categories {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", c.ID, c.Name, c.Color, c.Description)
+		}
+	}
+}
+
+func formatDue(due *time.Time) string {
+	if due == nil {
+		return "-"
+	}
+	return due.Local().Format("2006-01-02 15:04")
+}
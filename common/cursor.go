@@ -0,0 +1,90 @@
+package common
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor marks the position of the last row of a page for keyset pagination.
+// Value is the sort key of that row (nil when the key is NULL) and ID breaks ties.
+type Cursor struct {
+	SortBy    string  `json:"s"`
+	SortOrder string  `json:"o"`
+	Value     *string `json:"v"`
+	ID        uint    `json:"id"`
+}
+
+var (
+	cursorSecretMu sync.RWMutex
+	cursorSecret   []byte
+)
+
+// SetCursorSecret sets the key used to sign cursors. When it is never called a
+// random key is generated, which means cursors do not survive a restart.
+func SetCursorSecret(secret []byte) {
+	cursorSecretMu.Lock()
+	defer cursorSecretMu.Unlock()
+	cursorSecret = append([]byte(nil), secret...)
+}
+
+func getCursorSecret() []byte {
+	cursorSecretMu.RLock()
+	secret := cursorSecret
+	cursorSecretMu.RUnlock()
+	if len(secret) > 0 {
+		return secret
+	}
+
+	cursorSecretMu.Lock()
+	defer cursorSecretMu.Unlock()
+	if len(cursorSecret) == 0 {
+		cursorSecret = make([]byte, 32)
+		if _, err := rand.Read(cursorSecret); err != nil {
+			panic(err)
+		}
+	}
+	return cursorSecret
+}
+
+func signCursor(payload string) string {
+	mac := hmac.New(sha256.New, getCursorSecret())
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func EncodeCursor(cursor Cursor) string {
+	raw, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+	payload := base64.RawURLEncoding.EncodeToString(raw)
+	return payload + "." + signCursor(payload)
+}
+
+func DecodeCursor(token string) (*Cursor, error) {
+	payload, signature, found := strings.Cut(token, ".")
+	if !found || payload == "" || signature == "" {
+		return nil, ErrInvalidCursor
+	}
+	if !hmac.Equal([]byte(signature), []byte(signCursor(payload))) {
+		return nil, ErrInvalidCursor
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
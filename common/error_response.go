@@ -1,38 +1,46 @@
 package common
 
-import "net/http"
+import (
+	"context"
+	"net/http"
+)
 
-type errorFormat struct {
+type ErrorFormat struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 	Detail  string `json:"detail,omitempty"`
 }
 
-type errorResponse struct {
-	Success bool        `json:"success"`
-	Error   errorFormat `json:"error"`
+type ErrorResponse struct {
+	Success   bool        `json:"success"`
+	Error     ErrorFormat `json:"error"`
+	RequestID string      `json:"request_id,omitempty"`
 }
 
-func NewErrorResponse(code int, message string, detail string) *errorResponse {
-	err := errorFormat{
+// NewErrorResponse builds an error body. ctx is the request's context, whose
+// request ID is included so clients can quote it when reporting problems.
+func NewErrorResponse(ctx context.Context, code int, message string, detail string) *ErrorResponse {
+	err := ErrorFormat{
 		Code:    code,
 		Message: message,
 		Detail:  detail,
 	}
-	return &errorResponse{
-		Success: false,
-		Error:   err,
+	return &ErrorResponse{
+		Success:   false,
+		Error:     err,
+		RequestID: RequestID(ctx),
 	}
 }
 
-func NewSimpleErrorResponse(message string) *errorResponse {
-	err := errorFormat{
+func NewSimpleErrorResponse(ctx context.Context, message string) *ErrorResponse {
+	err := ErrorFormat{
 		Code:    http.StatusBadRequest,
 		Message: "Error",
 		Detail: message,
 	}
-	return &errorResponse{
-		Success: false,
-		Error:   err,
+	return &ErrorResponse{
+		Success:   false,
+		Error:     err,
+		RequestID: RequestID(ctx),
 	}
 }
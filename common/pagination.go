@@ -1,13 +1,66 @@
 package common
 
+import "sync/atomic"
+
 type BaseQuery struct {
 	Page     int `form:"page" binding:"omitempty,gte=1"`
 	PageSize int `form:"page_size" binding:"omitempty,gte=1"`
+	// Cursor switches the query to keyset pagination; Page is ignored when set.
+	Cursor    *Cursor
+	SkipTotal bool
+	// More is set by the repository: whether rows follow the page it
+	// returned, found by fetching one row beyond PageSize.
+	More bool `form:"-"`
 }
 
 type PaginationMeta struct {
-	Total      int `json:"total"`
-	Page       int `json:"page"`
-	PageSize   int `json:"page_size"`
-	TotalPages int `json:"total_pages"`
-}
\ No newline at end of file
+	Total      *int   `json:"total,omitempty"`
+	Page       int    `json:"page,omitempty"`
+	PageSize   int    `json:"page_size"`
+	TotalPages *int   `json:"total_pages,omitempty"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// NewPaginationMeta builds the meta block of a list response from the query
+// the repository ran. last is the cursor of the final row, if any.
+func NewPaginationMeta(query BaseQuery, total int, last *Cursor) PaginationMeta {
+	meta := PaginationMeta{
+		PageSize: query.PageSize,
+	}
+	if query.Cursor == nil {
+		meta.Page = query.Page
+	}
+
+	if !query.SkipTotal {
+		totalPages := 0
+		if query.PageSize > 0 {
+			totalPages = (total + query.PageSize - 1) / query.PageSize
+		}
+		meta.Total = &total
+		meta.TotalPages = &totalPages
+	}
+
+	meta.HasMore = query.More
+	if query.More && last != nil {
+		meta.NextCursor = EncodeCursor(*last)
+	}
+
+	return meta
+}
+
+var defaultPageSize atomic.Int64
+
+func init() {
+	defaultPageSize.Store(10)
+}
+
+// SetDefaultPageSize sets the page size list endpoints use when the request
+// does not give one.
+func SetDefaultPageSize(size int) {
+	defaultPageSize.Store(int64(size))
+}
+
+func DefaultPageSize() int {
+	return int(defaultPageSize.Load())
+}
@@ -0,0 +1,565 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the complete application configuration. Load fills it from, in
+// increasing order of precedence: defaults, a YAML or TOML file, environment
+// variables and command-line flags.
+type Config struct {
+	Server     ServerConfig     `yaml:"server" toml:"server"`
+	Database   DatabaseConfig   `yaml:"database" toml:"database"`
+	Migrations MigrationsConfig `yaml:"migrations" toml:"migrations"`
+	Pagination PaginationConfig `yaml:"pagination" toml:"pagination"`
+	Categories CategoriesConfig `yaml:"categories" toml:"categories"`
+	Tasks      TasksConfig      `yaml:"tasks" toml:"tasks"`
+	GraphQL    GraphQLConfig    `yaml:"graphql" toml:"graphql"`
+	Stream     StreamConfig     `yaml:"stream" toml:"stream"`
+	Metrics    MetricsConfig    `yaml:"metrics" toml:"metrics"`
+	Tracing    TracingConfig    `yaml:"tracing" toml:"tracing"`
+	Logging    LoggingConfig    `yaml:"logging" toml:"logging"`
+}
+
+type ServerConfig struct {
+	Port              int      `yaml:"port" toml:"port"`
+	ReadTimeout       Duration `yaml:"read_timeout" toml:"read_timeout"`
+	ReadHeaderTimeout Duration `yaml:"read_header_timeout" toml:"read_header_timeout"`
+	WriteTimeout      Duration `yaml:"write_timeout" toml:"write_timeout"`
+	IdleTimeout       Duration `yaml:"idle_timeout" toml:"idle_timeout"`
+	MaxHeaderBytes    int      `yaml:"max_header_bytes" toml:"max_header_bytes"`
+	// GRPCPort serves the gRPC API alongside HTTP; 0 disables it.
+	GRPCPort int `yaml:"grpc_port" toml:"grpc_port"`
+	// ShutdownTimeout bounds how long a SIGTERM or SIGINT waits for in-flight
+	// requests and background workers before the process exits anyway.
+	ShutdownTimeout Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+	// ShutdownDelay keeps serving after readiness starts failing, giving load
+	// balancers time to stop sending traffic before connections close.
+	ShutdownDelay Duration `yaml:"shutdown_delay" toml:"shutdown_delay"`
+}
+
+type MigrationsConfig struct {
+	// OnStart applies pending migrations when the server starts. It defaults
+	// to false so schema changes are deliberate.
+	OnStart bool `yaml:"on_start" toml:"on_start"`
+	// Dir is where `migrate create` writes new files; empty means the
+	// directory of the configured driver.
+	Dir string `yaml:"dir" toml:"dir"`
+}
+
+type PaginationConfig struct {
+	DefaultPageSize int `yaml:"default_page_size" toml:"default_page_size"`
+	// CursorSecret signs pagination cursors and must be shared by all
+	// replicas. When empty each process generates its own key.
+	CursorSecret string `yaml:"cursor_secret" toml:"cursor_secret"`
+}
+
+type CategoriesConfig struct {
+	// Palette lists the colors a category may have; new categories get a
+	// random one.
+	Palette []string `yaml:"palette" toml:"palette"`
+}
+
+type TasksConfig struct {
+	// BulkMaxOperations caps the operations in one POST /tasks/bulk.
+	BulkMaxOperations int `yaml:"bulk_max_operations" toml:"bulk_max_operations"`
+}
+
+type GraphQLConfig struct {
+	// MaxComplexity rejects queries whose fields, with list fields counted
+	// once per item they may return, add up to more than this.
+	MaxComplexity int `yaml:"max_complexity" toml:"max_complexity"`
+	MaxDepth      int `yaml:"max_depth" toml:"max_depth"`
+}
+
+type StreamConfig struct {
+	// ReplayBuffer is how many recent events are kept for clients that
+	// reconnect with Last-Event-ID.
+	ReplayBuffer int `yaml:"replay_buffer" toml:"replay_buffer"`
+	// HeartbeatInterval is how often idle streams get a keep-alive, so
+	// proxies do not close them.
+	HeartbeatInterval Duration `yaml:"heartbeat_interval" toml:"heartbeat_interval"`
+}
+
+type MetricsConfig struct {
+	// Enabled serves Prometheus metrics on /metrics.
+	Enabled bool `yaml:"enabled" toml:"enabled"`
+	// OverdueInterval is how often the overdue tasks gauge is recounted.
+	OverdueInterval Duration `yaml:"overdue_interval" toml:"overdue_interval"`
+}
+
+const (
+	TracingExporterNone   = "none"
+	TracingExporterOTLP   = "otlp"
+	TracingExporterStdout = "stdout"
+)
+
+type TracingConfig struct {
+	// Exporter is none, otlp (OTLP over HTTP to Endpoint) or stdout.
+	Exporter    string `yaml:"exporter" toml:"exporter"`
+	Endpoint    string `yaml:"endpoint" toml:"endpoint"`
+	ServiceName string `yaml:"service_name" toml:"service_name"`
+	// SampleRatio is the fraction of new traces recorded; traces started by
+	// a caller follow the caller's sampling decision.
+	SampleRatio float64 `yaml:"sample_ratio" toml:"sample_ratio"`
+}
+
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+)
+
+type LoggingConfig struct {
+	// Level is debug, info, warn or error. At debug every SQL statement is
+	// logged.
+	Level  string `yaml:"level" toml:"level"`
+	Format string `yaml:"format" toml:"format"`
+	// SlowQueryThreshold logs SQL statements taking longer than this as
+	// warnings; 0 disables it.
+	SlowQueryThreshold Duration `yaml:"slow_query_threshold" toml:"slow_query_threshold"`
+}
+
+// Duration is a time.Duration written as a string such as "15s" in config
+// files and environment variables.
+type Duration time.Duration
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+const redacted = "[redacted]"
+
+// Default returns the configuration used when nothing overrides it.
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Port:              8080,
+			GRPCPort:          9090,
+			ReadTimeout:       Duration(15 * time.Second),
+			ReadHeaderTimeout: Duration(5 * time.Second),
+			WriteTimeout:      Duration(15 * time.Second),
+			IdleTimeout:       Duration(60 * time.Second),
+			MaxHeaderBytes:    1 << 20,
+			ShutdownTimeout:   Duration(30 * time.Second),
+		},
+		Database: DatabaseConfig{
+			Driver:          DriverPostgres,
+			Host:            "localhost",
+			Port:            5432,
+			SSLMode:         "disable",
+			Path:            "tasks.db",
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: Duration(30 * time.Minute),
+			ConnMaxIdleTime: Duration(5 * time.Minute),
+			ConnectTimeout:  Duration(30 * time.Second),
+		},
+		Pagination: PaginationConfig{
+			DefaultPageSize: 10,
+		},
+		Categories: CategoriesConfig{
+			Palette: slices.Clone(ColorPalette),
+		},
+		Tasks: TasksConfig{
+			BulkMaxOperations: 100,
+		},
+		GraphQL: GraphQLConfig{
+			MaxComplexity: 1000,
+			MaxDepth:      10,
+		},
+		Stream: StreamConfig{
+			ReplayBuffer:      1000,
+			HeartbeatInterval: Duration(20 * time.Second),
+		},
+		Metrics: MetricsConfig{
+			Enabled:         true,
+			OverdueInterval: Duration(time.Minute),
+		},
+		Tracing: TracingConfig{
+			Exporter:    TracingExporterNone,
+			Endpoint:    "http://localhost:4318",
+			ServiceName: "domain-driven-golang",
+			SampleRatio: 1,
+		},
+		Logging: LoggingConfig{
+			Level:              "info",
+			Format:             LogFormatJSON,
+			SlowQueryThreshold: Duration(200 * time.Millisecond),
+		},
+	}
+}
+
+// option is a setting that can be given as an environment variable and, when
+// flag is set, as a command-line flag. Secrets have no flag so they never
+// show up in process listings.
+type option struct {
+	env    string
+	flag   string
+	usage  string
+	isBool bool
+	set    func(c *Config, value string) error
+}
+
+var options = []option{
+	intOption("PORT", "port", "HTTP port to listen on", func(c *Config) *int { return &c.Server.Port }),
+	intOption("GRPC_PORT", "grpc-port", "gRPC port to listen on (0 disables gRPC)", func(c *Config) *int { return &c.Server.GRPCPort }),
+	durationOption("SERVER_READ_TIMEOUT", "read-timeout", "maximum duration for reading a request", func(c *Config) *Duration { return &c.Server.ReadTimeout }),
+	durationOption("SERVER_READ_HEADER_TIMEOUT", "read-header-timeout", "maximum duration for reading request headers", func(c *Config) *Duration { return &c.Server.ReadHeaderTimeout }),
+	durationOption("SERVER_WRITE_TIMEOUT", "write-timeout", "maximum duration for writing a response", func(c *Config) *Duration { return &c.Server.WriteTimeout }),
+	durationOption("SERVER_IDLE_TIMEOUT", "idle-timeout", "how long idle keep-alive connections stay open", func(c *Config) *Duration { return &c.Server.IdleTimeout }),
+	intOption("SERVER_MAX_HEADER_BYTES", "max-header-bytes", "maximum size of request headers in bytes", func(c *Config) *int { return &c.Server.MaxHeaderBytes }),
+	durationOption("SERVER_SHUTDOWN_TIMEOUT", "shutdown-timeout", "how long shutdown waits for in-flight requests and workers", func(c *Config) *Duration { return &c.Server.ShutdownTimeout }),
+	durationOption("SERVER_SHUTDOWN_DELAY", "shutdown-delay", "how long to keep serving after readiness starts failing on shutdown", func(c *Config) *Duration { return &c.Server.ShutdownDelay }),
+
+	stringOption("DB_DRIVER", "db-driver", "database driver: postgres, sqlite or memory", func(c *Config) *string { return &c.Database.Driver }),
+	stringOption("DB_HOST", "db-host", "PostgreSQL host", func(c *Config) *string { return &c.Database.Host }),
+	intOption("DB_PORT", "db-port", "PostgreSQL port", func(c *Config) *int { return &c.Database.Port }),
+	stringOption("DB_USER", "db-user", "PostgreSQL user", func(c *Config) *string { return &c.Database.User }),
+	stringOption("DB_PASSWORD", "", "", func(c *Config) *string { return &c.Database.Password }),
+	stringOption("DB_NAME", "db-name", "PostgreSQL database name", func(c *Config) *string { return &c.Database.Name }),
+	stringOption("DB_SSLMODE", "db-sslmode", "PostgreSQL sslmode", func(c *Config) *string { return &c.Database.SSLMode }),
+	stringOption("DB_PATH", "db-path", "SQLite database file", func(c *Config) *string { return &c.Database.Path }),
+	intOption("DB_MAX_OPEN_CONNS", "db-max-open-conns", "maximum open database connections (0 is unlimited)", func(c *Config) *int { return &c.Database.MaxOpenConns }),
+	intOption("DB_MAX_IDLE_CONNS", "db-max-idle-conns", "maximum idle database connections", func(c *Config) *int { return &c.Database.MaxIdleConns }),
+	durationOption("DB_CONN_MAX_LIFETIME", "db-conn-max-lifetime", "maximum lifetime of a database connection (0 is forever)", func(c *Config) *Duration { return &c.Database.ConnMaxLifetime }),
+	durationOption("DB_CONN_MAX_IDLE_TIME", "db-conn-max-idle-time", "maximum idle time of a database connection (0 is forever)", func(c *Config) *Duration { return &c.Database.ConnMaxIdleTime }),
+	durationOption("DB_CONNECT_TIMEOUT", "db-connect-timeout", "how long to keep retrying the initial database connection (0 tries once)", func(c *Config) *Duration { return &c.Database.ConnectTimeout }),
+
+	boolOption("MIGRATE_ON_START", "migrate-on-start", "apply pending migrations on startup", func(c *Config) *bool { return &c.Migrations.OnStart }),
+	stringOption("MIGRATIONS_DIR", "migrations-dir", "where `migrate create` writes new migrations", func(c *Config) *string { return &c.Migrations.Dir }),
+
+	intOption("DEFAULT_PAGE_SIZE", "default-page-size", "page size of list endpoints when page_size is omitted", func(c *Config) *int { return &c.Pagination.DefaultPageSize }),
+	stringOption("CURSOR_SECRET", "", "", func(c *Config) *string { return &c.Pagination.CursorSecret }),
+
+	listOption("CATEGORY_PALETTE", "palette", "comma-separated category colors", func(c *Config) *[]string { return &c.Categories.Palette }),
+
+	intOption("BULK_MAX_OPERATIONS", "bulk-max-operations", "most operations in one bulk task request", func(c *Config) *int { return &c.Tasks.BulkMaxOperations }),
+
+	intOption("GRAPHQL_MAX_COMPLEXITY", "graphql-max-complexity", "highest complexity of a GraphQL query", func(c *Config) *int { return &c.GraphQL.MaxComplexity }),
+	intOption("GRAPHQL_MAX_DEPTH", "graphql-max-depth", "deepest nesting of a GraphQL query", func(c *Config) *int { return &c.GraphQL.MaxDepth }),
+
+	intOption("STREAM_REPLAY_BUFFER", "stream-replay-buffer", "recent events kept for reconnecting stream clients", func(c *Config) *int { return &c.Stream.ReplayBuffer }),
+	durationOption("STREAM_HEARTBEAT_INTERVAL", "stream-heartbeat-interval", "how often idle event streams get a keep-alive", func(c *Config) *Duration { return &c.Stream.HeartbeatInterval }),
+
+	boolOption("METRICS_ENABLED", "metrics", "serve Prometheus metrics on /metrics", func(c *Config) *bool { return &c.Metrics.Enabled }),
+	durationOption("METRICS_OVERDUE_INTERVAL", "metrics-overdue-interval", "how often the overdue tasks gauge is recounted", func(c *Config) *Duration { return &c.Metrics.OverdueInterval }),
+
+	stringOption("TRACING_EXPORTER", "tracing-exporter", "trace exporter: none, otlp or stdout", func(c *Config) *string { return &c.Tracing.Exporter }),
+	stringOption("TRACING_ENDPOINT", "tracing-endpoint", "OTLP/HTTP collector URL", func(c *Config) *string { return &c.Tracing.Endpoint }),
+	stringOption("TRACING_SERVICE_NAME", "tracing-service-name", "service.name reported on spans", func(c *Config) *string { return &c.Tracing.ServiceName }),
+	floatOption("TRACING_SAMPLE_RATIO", "tracing-sample-ratio", "fraction of new traces to record, 0 to 1", func(c *Config) *float64 { return &c.Tracing.SampleRatio }),
+
+	stringOption("LOG_LEVEL", "log-level", "minimum log level: debug, info, warn or error", func(c *Config) *string { return &c.Logging.Level }),
+	stringOption("LOG_FORMAT", "log-format", "log format: json or text", func(c *Config) *string { return &c.Logging.Format }),
+	durationOption("LOG_SLOW_QUERY_THRESHOLD", "log-slow-query-threshold", "log SQL statements slower than this as warnings (0 disables)", func(c *Config) *Duration { return &c.Logging.SlowQueryThreshold }),
+}
+
+func stringOption(env, flag, usage string, field func(*Config) *string) option {
+	return option{env: env, flag: flag, usage: usage, set: func(c *Config, value string) error {
+		*field(c) = value
+		return nil
+	}}
+}
+
+func intOption(env, flag, usage string, field func(*Config) *int) option {
+	return option{env: env, flag: flag, usage: usage, set: func(c *Config, value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a whole number", value)
+		}
+		*field(c) = n
+		return nil
+	}}
+}
+
+func floatOption(env, flag, usage string, field func(*Config) *float64) option {
+	return option{env: env, flag: flag, usage: usage, set: func(c *Config, value string) error {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+		*field(c) = f
+		return nil
+	}}
+}
+
+func boolOption(env, flag, usage string, field func(*Config) *bool) option {
+	return option{env: env, flag: flag, usage: usage, isBool: true, set: func(c *Config, value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not true or false", value)
+		}
+		*field(c) = b
+		return nil
+	}}
+}
+
+func durationOption(env, flag, usage string, field func(*Config) *Duration) option {
+	return option{env: env, flag: flag, usage: usage, set: func(c *Config, value string) error {
+		return field(c).UnmarshalText([]byte(value))
+	}}
+}
+
+func listOption(env, flag, usage string, field func(*Config) *[]string) option {
+	return option{env: env, flag: flag, usage: usage, set: func(c *Config, value string) error {
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		*field(c) = items
+		return nil
+	}}
+}
+
+// Load builds the configuration from args (without the program name) and the
+// environment. The config file is named by -config or CONFIG_FILE and its
+// format follows the extension: .yaml, .yml or .toml. The remaining
+// non-flag arguments are returned, so subcommands follow the flags.
+func Load(args []string) (*Config, []string, error) {
+	fs := flag.NewFlagSet("domain-driven-golang", flag.ContinueOnError)
+	configFile := fs.String("config", "", "path to a YAML or TOML config file (env CONFIG_FILE)")
+	flagValues := make(map[string]*flagValue)
+	for _, opt := range options {
+		if opt.flag != "" {
+			flagValues[opt.flag] = &flagValue{isBool: opt.isBool}
+			fs.Var(flagValues[opt.flag], opt.flag, opt.usage+" (env "+opt.env+")")
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	cfg := Default()
+
+	path := *configFile
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var errs []error
+	for _, opt := range options {
+		if value := os.Getenv(opt.env); value != "" {
+			if err := opt.set(cfg, value); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", opt.env, err))
+			}
+		}
+	}
+	fs.Visit(func(f *flag.Flag) {
+		for _, opt := range options {
+			if opt.flag == f.Name {
+				if err := opt.set(cfg, flagValues[f.Name].value); err != nil {
+					errs = append(errs, fmt.Errorf("-%s: %w", f.Name, err))
+				}
+			}
+		}
+	})
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return cfg, fs.Args(), nil
+}
+
+// flagValue holds a flag's raw text until it is applied on top of the file
+// and environment.
+type flagValue struct {
+	value  string
+	isBool bool
+}
+
+func (v *flagValue) String() string     { return v.value }
+func (v *flagValue) Set(s string) error { v.value = s; return nil }
+func (v *flagValue) IsBoolFlag() bool   { return v.isBool }
+
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("parsing %s: %w", path, err)
+		}
+	case ".toml":
+		decoder := toml.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(c); err != nil {
+			var strict *toml.StrictMissingError
+			if errors.As(err, &strict) {
+				return fmt.Errorf("parsing %s: unknown fields:\n%s", path, strict.String())
+			}
+			return fmt.Errorf("parsing %s: %w", path, err)
+		}
+	default:
+		return fmt.Errorf("config file %s: unsupported format %q, use .yaml, .yml or .toml", path, ext)
+	}
+	return nil
+}
+
+var (
+	sslModes   = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	colorRegex = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+)
+
+// Validate reports every invalid setting at once.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Server.Port > 0 && c.Server.Port <= 65535, "server.port must be between 1 and 65535, got %d", c.Server.Port)
+	check(c.Server.GRPCPort >= 0 && c.Server.GRPCPort <= 65535, "server.grpc_port must be between 0 and 65535, got %d", c.Server.GRPCPort)
+	check(c.Server.GRPCPort != c.Server.Port, "server.grpc_port must differ from server.port")
+	check(c.Server.ReadTimeout >= 0, "server.read_timeout must not be negative")
+	check(c.Server.ReadHeaderTimeout >= 0, "server.read_header_timeout must not be negative")
+	check(c.Server.WriteTimeout >= 0, "server.write_timeout must not be negative")
+	check(c.Server.IdleTimeout >= 0, "server.idle_timeout must not be negative")
+	check(c.Server.MaxHeaderBytes > 0, "server.max_header_bytes must be positive, got %d", c.Server.MaxHeaderBytes)
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout must be positive")
+	check(c.Server.ShutdownDelay >= 0, "server.shutdown_delay must not be negative")
+
+	db := c.Database
+	switch db.Driver {
+	case DriverPostgres:
+		check(db.Host != "", "database.host is required for the postgres driver")
+		check(db.User != "", "database.user is required for the postgres driver")
+		check(db.Name != "", "database.name is required for the postgres driver")
+		check(db.Port > 0 && db.Port <= 65535, "database.port must be between 1 and 65535, got %d", db.Port)
+		check(slices.Contains(sslModes, db.SSLMode), "database.sslmode must be one of %v, got %q", sslModes, db.SSLMode)
+	case DriverSQLite:
+		check(db.Path != "", "database.path is required for the sqlite driver")
+	case DriverMemory:
+	default:
+		check(false, "database.driver must be %s, %s or %s, got %q", DriverPostgres, DriverSQLite, DriverMemory, db.Driver)
+	}
+	check(db.MaxOpenConns >= 0, "database.max_open_conns must not be negative")
+	check(db.MaxIdleConns >= 0, "database.max_idle_conns must not be negative")
+	check(db.ConnMaxLifetime >= 0, "database.conn_max_lifetime must not be negative")
+	check(db.ConnMaxIdleTime >= 0, "database.conn_max_idle_time must not be negative")
+	check(db.ConnectTimeout >= 0, "database.connect_timeout must not be negative")
+
+	check(c.Pagination.DefaultPageSize >= 1 && c.Pagination.DefaultPageSize <= 1000,
+		"pagination.default_page_size must be between 1 and 1000, got %d", c.Pagination.DefaultPageSize)
+
+	check(len(c.Categories.Palette) > 0, "categories.palette must list at least one color")
+	for _, color := range c.Categories.Palette {
+		check(colorRegex.MatchString(color), "categories.palette: %q is not a #rrggbb color", color)
+	}
+
+	check(c.Tasks.BulkMaxOperations >= 1 && c.Tasks.BulkMaxOperations <= 1000,
+		"tasks.bulk_max_operations must be between 1 and 1000, got %d", c.Tasks.BulkMaxOperations)
+
+	check(c.GraphQL.MaxComplexity > 0, "graphql.max_complexity must be positive, got %d", c.GraphQL.MaxComplexity)
+	check(c.GraphQL.MaxDepth > 0, "graphql.max_depth must be positive, got %d", c.GraphQL.MaxDepth)
+
+	check(c.Stream.ReplayBuffer > 0, "stream.replay_buffer must be positive, got %d", c.Stream.ReplayBuffer)
+	check(c.Stream.HeartbeatInterval > 0, "stream.heartbeat_interval must be positive")
+
+	check(!c.Metrics.Enabled || c.Metrics.OverdueInterval > 0, "metrics.overdue_interval must be positive")
+
+	switch c.Tracing.Exporter {
+	case TracingExporterNone, TracingExporterStdout:
+	case TracingExporterOTLP:
+		check(c.Tracing.Endpoint != "", "tracing.endpoint is required for the otlp exporter")
+	default:
+		check(false, "tracing.exporter must be %s, %s or %s, got %q", TracingExporterNone, TracingExporterOTLP, TracingExporterStdout, c.Tracing.Exporter)
+	}
+	var level slog.Level
+	check(level.UnmarshalText([]byte(c.Logging.Level)) == nil, "logging.level must be debug, info, warn or error, got %q", c.Logging.Level)
+	check(c.Logging.Format == LogFormatJSON || c.Logging.Format == LogFormatText, "logging.format must be %s or %s, got %q", LogFormatJSON, LogFormatText, c.Logging.Format)
+	check(c.Logging.SlowQueryThreshold >= 0, "logging.slow_query_threshold must not be negative")
+
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1, got %v", c.Tracing.SampleRatio)
+
+	return errors.Join(errs...)
+}
+
+// MigrationsDir returns Migrations.Dir, defaulting to the SQL directory of
+// the configured driver.
+func (c *Config) MigrationsDir() string {
+	if c.Migrations.Dir != "" {
+		return c.Migrations.Dir
+	}
+	if c.Database.Driver == DriverSQLite {
+		return "migrations/sql/sqlite"
+	}
+	return "migrations/sql/postgres"
+}
+
+// Redacted returns a copy of the configuration with secrets masked, safe to
+// log.
+func (c *Config) Redacted() *Config {
+	r := *c
+	r.Categories.Palette = slices.Clone(c.Categories.Palette)
+	if r.Database.Password != "" {
+		r.Database.Password = redacted
+	}
+	if r.Pagination.CursorSecret != "" {
+		r.Pagination.CursorSecret = redacted
+	}
+	return &r
+}
+
+// String renders the redacted configuration as YAML.
+func (c *Config) String() string {
+	out, err := yaml.Marshal(c.Redacted())
+	if err != nil {
+		return err.Error()
+	}
+	return string(out)
+}
+
+// LogValue logs the redacted configuration as nested attributes, keyed like
+// the YAML file.
+func (c *Config) LogValue() slog.Value {
+	var fields map[string]interface{}
+	out, err := yaml.Marshal(c.Redacted())
+	if err == nil {
+		err = yaml.Unmarshal(out, &fields)
+	}
+	if err != nil {
+		return slog.StringValue(err.Error())
+	}
+	return slog.AnyValue(fields)
+}
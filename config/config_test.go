@@ -0,0 +1,205 @@
+package config
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clearEnv hides any configuration from the environment running the tests.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("CONFIG_FILE", "")
+	for _, opt := range options {
+		t.Setenv(opt.env, "")
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("DB_USER", "app")
+	t.Setenv("DB_NAME", "tasks")
+
+	cfg, args, err := Load([]string{"migrate", "up"})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !slices.Equal(args, []string{"migrate", "up"}) {
+		t.Errorf("args = %v, want [migrate up]", args)
+	}
+	if cfg.Server.Port != 8080 || cfg.Database.Driver != DriverPostgres || cfg.Pagination.DefaultPageSize != 10 {
+		t.Errorf("defaults = %+v", cfg)
+	}
+	if !slices.Equal(cfg.Categories.Palette, ColorPalette) {
+		t.Errorf("default palette = %v, want ColorPalette", cfg.Categories.Palette)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	clearEnv(t)
+	path := writeFile(t, "app.yaml", `
+server:
+  port: 9000
+  read_timeout: 5s
+  write_timeout: 6s
+database:
+  driver: sqlite
+  path: file.db
+pagination:
+  default_page_size: 20
+`)
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("PORT", "9001")
+	t.Setenv("SERVER_WRITE_TIMEOUT", "7s")
+	t.Setenv("DB_PATH", "env.db")
+
+	cfg, _, err := Load([]string{"-port", "9002", "-migrate-on-start"})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Server.Port != 9002 {
+		t.Errorf("port = %d, want the flag value 9002", cfg.Server.Port)
+	}
+	if cfg.Server.WriteTimeout != Duration(7*time.Second) {
+		t.Errorf("write timeout = %v, want the env value 7s", cfg.Server.WriteTimeout)
+	}
+	if cfg.Database.Path != "env.db" {
+		t.Errorf("path = %q, want the env value", cfg.Database.Path)
+	}
+	if cfg.Server.ReadTimeout != Duration(5*time.Second) || cfg.Database.Driver != DriverSQLite || cfg.Pagination.DefaultPageSize != 20 {
+		t.Errorf("file values not applied: %+v", cfg)
+	}
+	if !cfg.Migrations.OnStart {
+		t.Error("bare -migrate-on-start did not enable it")
+	}
+}
+
+func TestLoadTOML(t *testing.T) {
+	clearEnv(t)
+	path := writeFile(t, "app.toml", `
+[database]
+driver = "memory"
+
+[categories]
+palette = ["#000000", "#FFFFFF"]
+`)
+
+	cfg, _, err := Load([]string{"-config", path, "-idle-timeout", "2m"})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Database.Driver != DriverMemory || !slices.Equal(cfg.Categories.Palette, []string{"#000000", "#FFFFFF"}) {
+		t.Errorf("toml values not applied: %+v", cfg)
+	}
+	if cfg.Server.IdleTimeout != Duration(2*time.Minute) {
+		t.Errorf("idle timeout = %v, want 2m", cfg.Server.IdleTimeout)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		args []string
+		env  map[string]string
+		want []string
+	}{
+		{
+			name: "unknown yaml field",
+			file: writeFile(t, "app.yaml", "server:\n  prot: 80\n"),
+			want: []string{"prot"},
+		},
+		{
+			name: "unknown toml field",
+			file: writeFile(t, "app.toml", "[server]\nprot = 80\n"),
+			want: []string{"prot"},
+		},
+		{
+			name: "unsupported extension",
+			file: writeFile(t, "app.json", "{}"),
+			want: []string{"unsupported format"},
+		},
+		{
+			name: "malformed values",
+			args: []string{"-port", "http", "-read-timeout", "soon"},
+			env:  map[string]string{"MIGRATE_ON_START": "maybe"},
+			want: []string{"MIGRATE_ON_START", "-port", "-read-timeout"},
+		},
+		{
+			name: "invalid settings",
+			args: []string{"-port", "70000", "-db-sslmode", "sometimes", "-default-page-size", "0", "-palette", "#fff,red", "-bulk-max-operations", "5000", "-graphql-max-depth", "0", "-stream-replay-buffer", "0", "-log-level", "loud", "-log-format", "xml"},
+			want: []string{"server.port", "database.sslmode", "pagination.default_page_size", `"#fff"`, `"red"`, "tasks.bulk_max_operations", "graphql.max_depth", "stream.replay_buffer", "logging.level", "logging.format"},
+		},
+		{
+			name: "postgres without credentials",
+			args: []string{"-db-host", "", "-db-user", "", "-db-name", ""},
+			want: []string{"database.host", "database.user", "database.name"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			t.Setenv("DB_USER", "app")
+			t.Setenv("DB_NAME", "tasks")
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			args := tt.args
+			if tt.file != "" {
+				args = append([]string{"-config", tt.file}, args...)
+			}
+
+			_, _, err := Load(args)
+			if err == nil {
+				t.Fatal("Load succeeded")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := Default()
+	cfg.Database.Password = "hunter2"
+	cfg.Pagination.CursorSecret = "s3cret"
+
+	out := cfg.String()
+	if strings.Contains(out, "hunter2") || strings.Contains(out, "s3cret") {
+		t.Errorf("String() leaks a secret:\n%s", out)
+	}
+	if !strings.Contains(out, "password: '[redacted]'") {
+		t.Errorf("String() does not show the redacted password:\n%s", out)
+	}
+	if cfg.Database.Password != "hunter2" {
+		t.Error("Redacted modified the original config")
+	}
+
+	var logged bytes.Buffer
+	slog.New(slog.NewJSONHandler(&logged, nil)).Info("config", "config", cfg)
+	if strings.Contains(logged.String(), "hunter2") || !strings.Contains(logged.String(), `"password":"[redacted]"`) {
+		t.Errorf("logged config does not redact the password: %s", logged.String())
+	}
+
+	cfg.Pagination.CursorSecret = ""
+	if !strings.Contains(cfg.String(), `cursor_secret: ""`) {
+		t.Error("an unset secret should print as empty")
+	}
+}
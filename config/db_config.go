@@ -1,74 +1,140 @@
 package config
 
 import (
+	"context"
 	"fmt"
-	"log"
-	"os"
+	"log/slog"
+	"strings"
+	"time"
 
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
-type DBConfig struct {
-	Host     string
-	User     string
-	Password string
-	Name     string
-	Port     string
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	DriverMemory   = "memory"
+)
+
+type DatabaseConfig struct {
+	Driver   string `yaml:"driver" toml:"driver"`
+	Host     string `yaml:"host" toml:"host"`
+	Port     int    `yaml:"port" toml:"port"`
+	User     string `yaml:"user" toml:"user"`
+	Password string `yaml:"password" toml:"password"`
+	Name     string `yaml:"name" toml:"name"`
+	SSLMode  string `yaml:"sslmode" toml:"sslmode"`
+	// Path is the SQLite database file; :memory: gives a throwaway database.
+	Path string `yaml:"path" toml:"path"`
+
+	// The pool settings apply to Postgres; SQLite keeps one connection open.
+	MaxOpenConns    int      `yaml:"max_open_conns" toml:"max_open_conns"`
+	MaxIdleConns    int      `yaml:"max_idle_conns" toml:"max_idle_conns"`
+	ConnMaxLifetime Duration `yaml:"conn_max_lifetime" toml:"conn_max_lifetime"`
+	ConnMaxIdleTime Duration `yaml:"conn_max_idle_time" toml:"conn_max_idle_time"`
+	// ConnectTimeout bounds how long Connect keeps retrying, so the app can
+	// start before the database is ready.
+	ConnectTimeout Duration `yaml:"connect_timeout" toml:"connect_timeout"`
 }
 
-func (c *DBConfig) ConnectionString() string {
-	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		c.Host, c.Port, c.User, c.Password, c.Name)
+func (c *DatabaseConfig) ConnectionString() string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		c.Host, c.Port, c.User, c.Password, c.Name, c.SSLMode)
 }
 
-func GetDBConfig() (*DBConfig, error) {
-	host := os.Getenv("DB_HOST")
-	if host == "" {
-		return nil, fmt.Errorf("DB_HOST is not set")
-	}
+const (
+	initialConnectBackoff = 500 * time.Millisecond
+	maxConnectBackoff     = 5 * time.Second
+)
 
-	user := os.Getenv("DB_USER")
-	if user == "" {
-		return nil, fmt.Errorf("DB_USER is not set")
-	}
+// Connect opens the database like Open, retrying failures with exponential
+// backoff until ConnectTimeout has passed or ctx is done.
+func (c *DatabaseConfig) Connect(ctx context.Context, opts ...gorm.Option) (*gorm.DB, error) {
+	deadline := time.Now().Add(time.Duration(c.ConnectTimeout))
+	backoff := initialConnectBackoff
+	for attempt := 1; ; attempt++ {
+		db, err := c.Open(opts...)
+		if err == nil {
+			return db, nil
+		}
+		if time.Now().Add(backoff).After(deadline) {
+			return nil, fmt.Errorf("connecting to %s: giving up after %d attempt(s): %w", c, attempt, err)
+		}
+		slog.WarnContext(ctx, "connecting to database failed, retrying",
+			"database", c.String(), "attempt", attempt, "backoff", backoff, "error", err)
 
-	password := os.Getenv("DB_PASSWORD")
-	if password == "" {
-		return nil, fmt.Errorf("DB_PASSWORD is not set")
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("connecting to %s: %w (last error: %v)", c, ctx.Err(), err)
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxConnectBackoff)
 	}
+}
 
-	name := os.Getenv("DB_NAME")
-	if name == "" {
-		return nil, fmt.Errorf("DB_NAME is not set")
+// String describes the database without credentials.
+func (c *DatabaseConfig) String() string {
+	switch c.Driver {
+	case DriverPostgres:
+		return fmt.Sprintf("postgres database %q at %s:%d", c.Name, c.Host, c.Port)
+	case DriverSQLite:
+		return fmt.Sprintf("sqlite database %s", c.Path)
+	default:
+		return c.Driver + " database"
 	}
+}
 
-	port := os.Getenv("DB_PORT")
-	if port == "" {
-		return nil, fmt.Errorf("DB_PORT is not set")
+// Open connects to the configured SQL database and applies the pool
+// settings. opts are passed to gorm.Open. The memory driver has no database
+// to open.
+func (c *DatabaseConfig) Open(opts ...gorm.Option) (*gorm.DB, error) {
+	var dialector gorm.Dialector
+	switch c.Driver {
+	case DriverPostgres:
+		dialector = postgres.Open(c.ConnectionString())
+	case DriverSQLite:
+		dialector = sqlite.Open(c.sqliteDSN())
+	case DriverMemory:
+		return nil, fmt.Errorf("database driver %s does not use a SQL database", c.Driver)
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %s", c.Driver)
 	}
 
-	return &DBConfig{
-		Host:     host,
-		User:     user,
-		Password: password,
-		Name:     name,
-		Port:     port,
-	}, nil
-}
-
-func GetDb() (*gorm.DB, error) {
-	dbConfig, err := GetDBConfig()
+	if len(opts) == 0 {
+		opts = []gorm.Option{&gorm.Config{}}
+	}
+	db, err := gorm.Open(dialector, opts...)
 	if err != nil {
-		log.Fatal(err)
 		return nil, err
 	}
-
-	db, err := gorm.Open(postgres.Open(dbConfig.ConnectionString()), &gorm.Config{})
+	sqlDB, err := db.DB()
 	if err != nil {
-		log.Fatal(err)
 		return nil, err
 	}
 
+	if c.Driver == DriverSQLite {
+		// SQLite serialises writers anyway, and a single connection that is
+		// never closed keeps a :memory: database alive and shared.
+		sqlDB.SetMaxOpenConns(1)
+		sqlDB.SetMaxIdleConns(1)
+		sqlDB.SetConnMaxLifetime(0)
+		sqlDB.SetConnMaxIdleTime(0)
+		return db, nil
+	}
+	sqlDB.SetMaxOpenConns(c.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(c.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(time.Duration(c.ConnMaxLifetime))
+	sqlDB.SetConnMaxIdleTime(time.Duration(c.ConnMaxIdleTime))
+
 	return db, nil
-}
\ No newline at end of file
+}
+
+// sqliteDSN turns foreign keys on, adding to any parameters Path has.
+func (c *DatabaseConfig) sqliteDSN() string {
+	if strings.Contains(c.Path, "?") {
+		return c.Path + "&_foreign_keys=on"
+	}
+	return c.Path + "?_foreign_keys=on"
+}
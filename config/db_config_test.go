@@ -0,0 +1,91 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConnect(t *testing.T) {
+	cfg := Default().Database
+	cfg.Driver = DriverSQLite
+	cfg.Path = ":memory:"
+
+	db, err := cfg.Connect(context.Background())
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	if got := sqlDB.Stats().MaxOpenConnections; got != 1 {
+		t.Errorf("sqlite MaxOpenConnections = %d, want 1", got)
+	}
+}
+
+// TestSQLitePool checks that pool settings meant for Postgres cannot close
+// the only connection to a :memory: database, which would drop it.
+func TestSQLitePool(t *testing.T) {
+	cfg := Default().Database
+	cfg.Driver = DriverSQLite
+	cfg.Path = ":memory:?_busy_timeout=5000"
+	cfg.MaxIdleConns = 0
+	cfg.ConnMaxLifetime = Duration(time.Nanosecond)
+	cfg.ConnMaxIdleTime = Duration(time.Nanosecond)
+
+	db, err := cfg.Open()
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+
+	if err := db.Exec("CREATE TABLE notes (id INTEGER PRIMARY KEY)").Error; err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+	if err := db.Exec("INSERT INTO notes (id) VALUES (1)").Error; err != nil {
+		t.Errorf("the table is gone after the connection sat idle: %v", err)
+	}
+
+	var foreignKeys, busyTimeout int
+	db.Raw("PRAGMA foreign_keys").Scan(&foreignKeys)
+	db.Raw("PRAGMA busy_timeout").Scan(&busyTimeout)
+	if foreignKeys != 1 || busyTimeout != 5000 {
+		t.Errorf("foreign_keys = %d, busy_timeout = %d, want 1 and 5000 from the path's parameters", foreignKeys, busyTimeout)
+	}
+}
+
+func TestConnectRetries(t *testing.T) {
+	cfg := Default().Database
+	cfg.Driver = DriverSQLite
+	cfg.Path = filepath.Join(t.TempDir(), "missing", "tasks.db")
+
+	cfg.ConnectTimeout = 0
+	_, err := cfg.Connect(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "after 1 attempt(s)") {
+		t.Errorf("Connect without retries = %v, want a failure after 1 attempt", err)
+	}
+
+	cfg.ConnectTimeout = Duration(time.Second)
+	_, err = cfg.Connect(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "after 2 attempt(s)") {
+		t.Errorf("Connect with a 1s timeout = %v, want a failure after 2 attempts", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cfg.ConnectTimeout = Duration(time.Minute)
+	_, err = cfg.Connect(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Connect with a cancelled context = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,35 @@
+package database
+
+import (
+	"iter"
+
+	"gorm.io/gorm"
+)
+
+// BatchSize is how many rows Batches reads per query.
+const BatchSize = 500
+
+// Batches yields every row db selects in ID order, reading BatchSize rows at
+// a time after the last ID seen, so a large table is never held in memory
+// and no cursor stays open while the caller works. id returns a row's ID.
+func Batches[T any](db *gorm.DB, id func(*T) uint) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		var last uint
+		for {
+			var rows []*T
+			if err := db.Session(&gorm.Session{}).Where("id > ?", last).Order("id").Limit(BatchSize).Find(&rows).Error; err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, row := range rows {
+				if !yield(row, nil) {
+					return
+				}
+			}
+			if len(rows) < BatchSize {
+				return
+			}
+			last = id(rows[len(rows)-1])
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package database
+
+import (
+	"cmp"
+	"strings"
+	"time"
+
+	"github.com/ltphat2204/domain-driven-golang/common"
+	"gorm.io/gorm"
+)
+
+// KeysetColumn describes a sortable column usable for keyset pagination.
+type KeysetColumn struct {
+	Name     string
+	Nullable bool
+	Time     bool
+}
+
+// OrderBy sorts by column and then by id in the same direction. NULLs are
+// always placed last so every dialect produces the same order.
+func OrderBy(column KeysetColumn, order string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Order(OrderClause(column, order))
+	}
+}
+
+// OrderClause is the ORDER BY list of OrderBy, for window functions.
+func OrderClause(column KeysetColumn, order string) string {
+	clause := column.Name + " " + order + ", id " + order
+	if column.Nullable {
+		clause = column.Name + " IS NULL, " + clause
+	}
+	return clause
+}
+
+// After restricts the query to rows that come after cursor in the ordering
+// produced by OrderBy.
+func After(column KeysetColumn, order string, cursor *common.Cursor) (func(*gorm.DB) *gorm.DB, error) {
+	op := ">"
+	if order == "desc" {
+		op = "<"
+	}
+
+	if err := CheckCursor(column, cursor); err != nil {
+		return nil, err
+	}
+
+	if cursor.Value == nil {
+		return func(db *gorm.DB) *gorm.DB {
+			return db.Where(column.Name+" IS NULL AND id "+op+" ?", cursor.ID)
+		}, nil
+	}
+
+	var value interface{} = *cursor.Value
+	if column.Time {
+		value, _ = time.Parse(time.RFC3339Nano, *cursor.Value)
+	}
+
+	condition := "(" + column.Name + " " + op + " ? OR (" + column.Name + " = ? AND id " + op + " ?))"
+	if column.Nullable {
+		condition = "(" + condition + " OR " + column.Name + " IS NULL)"
+	}
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(condition, value, value, cursor.ID)
+	}, nil
+}
+
+// CheckCursor reports whether cursor holds a valid position for column.
+func CheckCursor(column KeysetColumn, cursor *common.Cursor) error {
+	if cursor.Value == nil {
+		if !column.Nullable {
+			return common.ErrInvalidCursor
+		}
+		return nil
+	}
+	if column.Time {
+		if _, err := time.Parse(time.RFC3339Nano, *cursor.Value); err != nil {
+			return common.ErrInvalidCursor
+		}
+	}
+	return nil
+}
+
+// Compare orders two rows the way OrderBy does, for repositories that sort in
+// memory. Values are sort keys as stored in a cursor; nil means NULL.
+func Compare(column KeysetColumn, order string, aValue *string, aID uint, bValue *string, bID uint) int {
+	if (aValue == nil) != (bValue == nil) {
+		if aValue == nil {
+			return 1
+		}
+		return -1
+	}
+
+	c := 0
+	if aValue != nil {
+		c = compareValues(column, *aValue, *bValue)
+	}
+	if c == 0 {
+		c = cmp.Compare(aID, bID)
+	}
+	if order == "desc" {
+		c = -c
+	}
+	return c
+}
+
+func compareValues(column KeysetColumn, a, b string) int {
+	if column.Time {
+		at, errA := time.Parse(time.RFC3339Nano, a)
+		bt, errB := time.Parse(time.RFC3339Nano, b)
+		if errA == nil && errB == nil {
+			return at.Compare(bt)
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+// Window limits the query to the cursor's or page's rows of query, plus one
+// that Trim then removes to set query.More.
+func Window(query *common.BaseQuery) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if query.PageSize <= 0 || query.Cursor == nil && query.Page <= 0 {
+			return db
+		}
+		if query.Cursor == nil {
+			db = db.Offset((query.Page - 1) * query.PageSize)
+		}
+		return db.Limit(query.PageSize + 1)
+	}
+}
+
+// Trim drops the row fetched beyond the page by Window or Paginate, recording
+// in query.More whether there was one.
+func Trim[T any](rows []T, query *common.BaseQuery) []T {
+	windowed := query.Cursor != nil || query.Page > 0
+	query.More = windowed && query.PageSize > 0 && len(rows) > query.PageSize
+	if query.More {
+		rows = rows[:query.PageSize]
+	}
+	return rows
+}
+
+// Paginate returns the window of rows, already sorted with Compare, selected
+// by the cursor or page of query, and sets query.More. after reports whether a
+// row comes after the cursor.
+func Paginate[T any](rows []T, query *common.BaseQuery, after func(T) bool) []T {
+	if query.Cursor != nil {
+		start := len(rows)
+		for i, row := range rows {
+			if after(row) {
+				start = i
+				break
+			}
+		}
+		rows = rows[start:]
+	} else if query.Page > 0 && query.PageSize > 0 {
+		offset := min((query.Page-1)*query.PageSize, len(rows))
+		rows = rows[offset:]
+	}
+	return Trim(rows, query)
+}
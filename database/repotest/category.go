@@ -0,0 +1,392 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/ltphat2204/domain-driven-golang/common"
+	"github.com/ltphat2204/domain-driven-golang/database"
+	"github.com/ltphat2204/domain-driven-golang/modules/category/domain"
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+)
+
+// RunCategoryRepository runs the CategoryRepository contract. newRepos must
+// return empty repositories each time it is called; the task repository only
+// serves to refer to categories.
+func RunCategoryRepository(t *testing.T, newRepos func(t *testing.T) TaskRepositories) {
+	ctx := context.Background()
+	newRepo := func(t *testing.T) domain.CategoryRepository { return newRepos(t).Categories }
+
+	t.Run("SaveAndFindByID", func(t *testing.T) {
+		repo := newRepo(t)
+
+		saved, err := repo.Save(ctx, &domain.Category{Name: "work", Description: "office things", Color: "#ff0000"})
+		if err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if saved.ID == 0 {
+			t.Fatal("Save did not assign an ID")
+		}
+		if saved.CreatedAt.IsZero() {
+			t.Error("Save did not set CreatedAt")
+		}
+
+		found, err := repo.FindByID(ctx, saved.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if found.Name != "work" || found.Description != "office things" || found.Color != "#ff0000" {
+			t.Errorf("FindByID = %+v, want the saved category", found)
+		}
+	})
+
+	t.Run("FindByIDNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.FindByID(ctx, 404)
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("FindByID(missing) error = %v, want gorm.ErrRecordNotFound", err)
+		}
+	})
+
+	t.Run("All", func(t *testing.T) {
+		repo := newRepo(t)
+		categories := seedCategories(t, repo)
+
+		var ids []uint
+		for category, err := range repo.All(ctx) {
+			if err != nil {
+				t.Fatalf("All: %v", err)
+			}
+			ids = append(ids, category.ID)
+		}
+		if want := sortedIDs(categoryIDs(categories)); !slices.Equal(ids, want) {
+			t.Errorf("All = %v, want %v", ids, want)
+		}
+	})
+
+	t.Run("FindByIDs", func(t *testing.T) {
+		repo := newRepo(t)
+		work := saveCategory(t, repo, "work", "")
+		saveCategory(t, repo, "home", "")
+		errands := saveCategory(t, repo, "errands", "")
+
+		found, err := repo.FindByIDs(ctx, []uint{errands.ID, 404, work.ID})
+		if err != nil {
+			t.Fatalf("FindByIDs: %v", err)
+		}
+		var names []string
+		for _, category := range found {
+			names = append(names, category.Name)
+		}
+		slices.Sort(names)
+		if !slices.Equal(names, []string{"errands", "work"}) {
+			t.Errorf("FindByIDs = %v, want [errands work]", names)
+		}
+
+		if found, err := repo.FindByIDs(ctx, nil); err != nil || len(found) != 0 {
+			t.Errorf("FindByIDs(nil) = %v, %v; want nothing", found, err)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := newRepo(t)
+		category := saveCategory(t, repo, "work", "")
+
+		category.Name = "office"
+		category.Color = "#00ff00"
+		if _, err := repo.Update(ctx, category); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+
+		found, err := repo.FindByID(ctx, category.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if found.Name != "office" || found.Color != "#00ff00" {
+			t.Errorf("after Update got %+v", found)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo(t)
+		category := saveCategory(t, repo, "work", "")
+
+		if err := repo.Delete(ctx, category.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := repo.FindByID(ctx, category.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("FindByID after Delete error = %v, want gorm.ErrRecordNotFound", err)
+		}
+		if err := repo.Delete(ctx, category.ID); err != nil {
+			t.Errorf("Delete(missing) = %v, want nil", err)
+		}
+	})
+
+	t.Run("DeleteReferenced", func(t *testing.T) {
+		repos := newRepos(t)
+		category := saveCategory(t, repos.Categories, "work", "")
+		task, err := repos.Tasks.Save(ctx, &taskDomain.Task{Title: "write report", Status: taskDomain.StatusPending, CategoryID: &category.ID})
+		if err != nil {
+			t.Fatalf("Save task: %v", err)
+		}
+
+		if err := repos.Categories.Delete(ctx, category.ID); !errors.Is(err, gorm.ErrForeignKeyViolated) {
+			t.Fatalf("Delete(referenced) = %v, want gorm.ErrForeignKeyViolated", err)
+		}
+		if _, err := repos.Categories.FindByID(ctx, category.ID); err != nil {
+			t.Errorf("FindByID after a refused Delete: %v", err)
+		}
+
+		if err := repos.Tasks.Delete(ctx, task.ID); err != nil {
+			t.Fatalf("Delete task: %v", err)
+		}
+		if err := repos.Categories.Delete(ctx, category.ID); err != nil {
+			t.Errorf("Delete once no task refers to it: %v", err)
+		}
+	})
+
+	t.Run("Sort", func(t *testing.T) {
+		repo := newRepo(t)
+		all := seedCategories(t, repo)
+
+		for _, sortBy := range []string{"name", "created_at"} {
+			for _, order := range []string{"asc", "desc"} {
+				query := &domain.CategoryQuery{SortBy: sortBy, SortOrder: order}
+				got, total, err := repo.FindCategories(ctx, query)
+				if err != nil {
+					t.Fatalf("FindCategories(%s %s): %v", sortBy, order, err)
+				}
+				want := sortedCategoryIDs(all, sortBy, order)
+				if !slices.Equal(categoryIDs(got), want) {
+					t.Errorf("sort %s %s = %v, want %v", sortBy, order, categoryIDs(got), want)
+				}
+				if total != len(all) {
+					t.Errorf("sort %s %s total = %d, want %d", sortBy, order, total, len(all))
+				}
+			}
+		}
+
+		got, _, err := repo.FindCategories(ctx, &domain.CategoryQuery{})
+		if err != nil {
+			t.Fatalf("FindCategories: %v", err)
+		}
+		if want := sortedCategoryIDs(all, "created_at", "desc"); !slices.Equal(categoryIDs(got), want) {
+			t.Errorf("default sort = %v, want newest first %v", categoryIDs(got), want)
+		}
+
+		if _, _, err := repo.FindCategories(ctx, &domain.CategoryQuery{SortBy: "color", SortOrder: "asc"}); err == nil {
+			t.Error("sorting by an unsupported field succeeded")
+		}
+	})
+
+	t.Run("OffsetPagination", func(t *testing.T) {
+		repo := newRepo(t)
+		all := seedCategories(t, repo)
+		want := sortedCategoryIDs(all, "name", "asc")
+
+		var got []uint
+		for page := 1; page <= 3; page++ {
+			query := &domain.CategoryQuery{BaseQuery: common.BaseQuery{Page: page, PageSize: 2}, SortBy: "name", SortOrder: "asc"}
+			categories, total, err := repo.FindCategories(ctx, query)
+			if err != nil {
+				t.Fatalf("page %d: %v", page, err)
+			}
+			if total != len(all) {
+				t.Errorf("page %d total = %d, want %d", page, total, len(all))
+			}
+			got = append(got, categoryIDs(categories)...)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("pages = %v, want %v", got, want)
+		}
+
+		categories, total, err := repo.FindCategories(ctx, &domain.CategoryQuery{BaseQuery: common.BaseQuery{Page: 10, PageSize: 2}})
+		if err != nil {
+			t.Fatalf("page past the end: %v", err)
+		}
+		if len(categories) != 0 || total != len(all) {
+			t.Errorf("page past the end = %d rows, total %d; want 0 rows, total %d", len(categories), total, len(all))
+		}
+
+		_, total, err = repo.FindCategories(ctx, &domain.CategoryQuery{BaseQuery: common.BaseQuery{Page: 1, PageSize: 2, SkipTotal: true}})
+		if err != nil {
+			t.Fatalf("SkipTotal: %v", err)
+		}
+		if total != 0 {
+			t.Errorf("SkipTotal total = %d, want 0", total)
+		}
+	})
+
+	t.Run("HasMore", func(t *testing.T) {
+		repo := newRepo(t)
+		all := seedCategories(t, repo)
+		n := len(all)
+		firstID := sortedCategoryIDs(all, "name", "asc")[0]
+		first := all[slices.IndexFunc(all, func(c *domain.Category) bool { return c.ID == firstID })]
+		cursor := &common.Cursor{SortBy: "name", SortOrder: "asc", Value: first.SortKey("name"), ID: first.ID}
+
+		// A last page that is exactly full has nothing after it.
+		for _, tt := range []struct {
+			base common.BaseQuery
+			want bool
+		}{
+			{common.BaseQuery{Page: 1, PageSize: n}, false},
+			{common.BaseQuery{Page: 1, PageSize: n, SkipTotal: true}, false},
+			{common.BaseQuery{Page: 1, PageSize: n - 1}, true},
+			{common.BaseQuery{Page: 2, PageSize: n - 1}, false},
+			{common.BaseQuery{PageSize: n - 1, Cursor: cursor}, false},
+			{common.BaseQuery{PageSize: n - 2, Cursor: cursor}, true},
+		} {
+			query := &domain.CategoryQuery{BaseQuery: tt.base, SortBy: "name", SortOrder: "asc"}
+			categories, _, err := repo.FindCategories(ctx, query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query.More != tt.want || len(categories) > tt.base.PageSize {
+				t.Errorf("%+v: %d rows, More = %v; want at most %d rows, More = %v", tt.base, len(categories), query.More, tt.base.PageSize, tt.want)
+			}
+		}
+	})
+
+	t.Run("CursorPagination", func(t *testing.T) {
+		repo := newRepo(t)
+		all := seedCategories(t, repo)
+
+		for _, sortBy := range []string{"name", "created_at"} {
+			for _, order := range []string{"asc", "desc"} {
+				query := &domain.CategoryQuery{BaseQuery: common.BaseQuery{PageSize: 2}, SortBy: sortBy, SortOrder: order}
+				var got []uint
+				for pages := 0; pages <= len(all); pages++ {
+					categories, _, err := repo.FindCategories(ctx, query)
+					if err != nil {
+						t.Fatalf("cursor %s %s: %v", sortBy, order, err)
+					}
+					got = append(got, categoryIDs(categories)...)
+					if !query.More {
+						break
+					}
+					last := categories[len(categories)-1]
+					query.Cursor = &common.Cursor{SortBy: sortBy, SortOrder: order, Value: last.SortKey(sortBy), ID: last.ID}
+				}
+				if want := sortedCategoryIDs(all, sortBy, order); !slices.Equal(got, want) {
+					t.Errorf("cursor %s %s = %v, want %v", sortBy, order, got, want)
+				}
+			}
+		}
+
+		query := &domain.CategoryQuery{BaseQuery: common.BaseQuery{PageSize: 2, Cursor: &common.Cursor{SortBy: "name", SortOrder: "asc", ID: 1}}, SortBy: "name", SortOrder: "asc"}
+		if _, _, err := repo.FindCategories(ctx, query); err == nil {
+			t.Error("a NULL cursor value on a NOT NULL column was accepted")
+		}
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		repo := newRepo(t)
+		work := saveCategory(t, repo, "work", "office and meetings")
+		saveCategory(t, repo, "home", "chores")
+		meetings := saveCategory(t, repo, "meetings", "recurring meetings")
+
+		got, total, err := repo.FindCategories(ctx, &domain.CategoryQuery{Search: "MEETINGS"})
+		if err != nil {
+			t.Fatalf("search: %v", err)
+		}
+		if ids := sortedIDs(categoryIDs(got)); !slices.Equal(ids, []uint{work.ID, meetings.ID}) || total != 2 {
+			t.Errorf("search = %v (total %d), want %v", ids, total, []uint{work.ID, meetings.ID})
+		}
+		for _, c := range got {
+			if c.SearchRank <= 0 {
+				t.Errorf("category %d SearchRank = %v, want > 0", c.ID, c.SearchRank)
+			}
+			if !strings.Contains(strings.ToLower(c.SearchHighlight), "<mark>meetings</mark>") {
+				t.Errorf("category %d SearchHighlight = %q, want the term marked", c.ID, c.SearchHighlight)
+			}
+		}
+
+		got, _, err = repo.FindCategories(ctx, &domain.CategoryQuery{Search: "meetings -office"})
+		if err != nil {
+			t.Fatalf("search with exclusion: %v", err)
+		}
+		if ids := categoryIDs(got); !slices.Equal(ids, []uint{meetings.ID}) {
+			t.Errorf("search with exclusion = %v, want %v", ids, []uint{meetings.ID})
+		}
+
+		got, _, err = repo.FindCategories(ctx, &domain.CategoryQuery{Search: "meetings", SortBy: domain.SortByRelevance})
+		if err != nil {
+			t.Fatalf("relevance: %v", err)
+		}
+		for i := 1; i < len(got); i++ {
+			if got[i].SearchRank > got[i-1].SearchRank {
+				t.Errorf("relevance order has rank %v after %v", got[i].SearchRank, got[i-1].SearchRank)
+			}
+		}
+
+		if _, _, err := repo.FindCategories(ctx, &domain.CategoryQuery{SortBy: domain.SortByRelevance}); err == nil {
+			t.Error("relevance without a search term succeeded")
+		}
+		query := &domain.CategoryQuery{Search: "meetings", SortBy: domain.SortByRelevance}
+		query.Cursor = &common.Cursor{SortBy: domain.SortByRelevance, SortOrder: "desc", ID: 1}
+		if _, _, err := repo.FindCategories(ctx, query); err == nil {
+			t.Error("relevance with a cursor succeeded")
+		}
+	})
+}
+
+func saveCategory(t *testing.T, repo domain.CategoryRepository, name, description string) *domain.Category {
+	t.Helper()
+	category, err := repo.Save(context.Background(), &domain.Category{Name: name, Description: description})
+	if err != nil {
+		t.Fatalf("Save category: %v", err)
+	}
+	return category
+}
+
+// seedCategories saves categories with repeated names and creation times so
+// sorting has to fall back to the id.
+func seedCategories(t *testing.T, repo domain.CategoryRepository) []*domain.Category {
+	t.Helper()
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	names := []string{"work", "home", "work", "errands", "hobby"}
+	var categories []*domain.Category
+	for i, name := range names {
+		category, err := repo.Save(context.Background(), &domain.Category{Name: name, CreatedAt: base.Add(time.Duration(i%2) * time.Hour)})
+		if err != nil {
+			t.Fatalf("Save category: %v", err)
+		}
+		categories = append(categories, category)
+	}
+	return categories
+}
+
+var categoryColumns = map[string]database.KeysetColumn{
+	"name":       {Name: "name"},
+	"created_at": {Name: "created_at", Time: true},
+}
+
+func sortedCategoryIDs(categories []*domain.Category, sortBy, order string) []uint {
+	sorted := slices.Clone(categories)
+	slices.SortFunc(sorted, func(a, b *domain.Category) int {
+		return database.Compare(categoryColumns[sortBy], order, a.SortKey(sortBy), a.ID, b.SortKey(sortBy), b.ID)
+	})
+	return categoryIDs(sorted)
+}
+
+func categoryIDs(categories []*domain.Category) []uint {
+	ids := make([]uint, len(categories))
+	for i, c := range categories {
+		ids[i] = c.ID
+	}
+	return ids
+}
+
+func sortedIDs(ids []uint) []uint {
+	sorted := slices.Clone(ids)
+	slices.Sort(sorted)
+	return sorted
+}
@@ -0,0 +1,79 @@
+// Package repotest holds the contract every TaskRepository,
+// CategoryRepository and ViewRepository implementation must satisfy, and
+// helpers to open the SQL databases they run against.
+package repotest
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/ltphat2204/domain-driven-golang/migrations"
+)
+
+// PostgresDSNEnv names the variable holding the DSN of a disposable Postgres
+// database. Postgres runs are skipped when it is unset.
+const PostgresDSNEnv = "TEST_POSTGRES_DSN"
+
+// OpenSQLite returns a migrated, empty in-memory SQLite database.
+func OpenSQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:?_foreign_keys=on"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	// Every connection to :memory: is a separate database.
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	migrate(t, db)
+	return db
+}
+
+// OpenPostgres returns the database named by TEST_POSTGRES_DSN, migrated and
+// with every table emptied, or skips the test when the variable is unset.
+func OpenPostgres(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv(PostgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", PostgresDSNEnv)
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open postgres: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("open postgres: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	migrate(t, db)
+	if err := db.Exec("TRUNCATE tasks, categories, views, view_pins, external_refs RESTART IDENTITY CASCADE").Error; err != nil {
+		t.Fatalf("truncate: %v", err)
+	}
+	return db
+}
+
+func migrate(t *testing.T, db *gorm.DB) {
+	t.Helper()
+
+	migrator, err := migrations.New(db)
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+}
@@ -0,0 +1,576 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/ltphat2204/domain-driven-golang/common"
+	"github.com/ltphat2204/domain-driven-golang/database"
+	categoryDomain "github.com/ltphat2204/domain-driven-golang/modules/category/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+)
+
+// TaskRepositories is a task repository together with the category
+// repository backing its Category association.
+type TaskRepositories struct {
+	Tasks      domain.TaskRepository
+	Categories categoryDomain.CategoryRepository
+}
+
+// RunTaskRepository runs the TaskRepository contract. newRepos must return
+// empty repositories each time it is called.
+func RunTaskRepository(t *testing.T, newRepos func(t *testing.T) TaskRepositories) {
+	ctx := context.Background()
+
+	t.Run("SaveAndFindByID", func(t *testing.T) {
+		repos := newRepos(t)
+		category := saveCategory(t, repos.Categories, "work", "")
+		due := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+		saved, err := repos.Tasks.Save(ctx, &domain.Task{Title: "write report", Description: "quarterly", DueAt: &due, CategoryID: &category.ID})
+		if err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if saved.ID == 0 {
+			t.Fatal("Save did not assign an ID")
+		}
+		if saved.CreatedAt.IsZero() || saved.UpdatedAt.IsZero() {
+			t.Error("Save did not set CreatedAt and UpdatedAt")
+		}
+
+		found, err := repos.Tasks.FindByID(ctx, saved.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if found.Title != "write report" || found.Description != "quarterly" {
+			t.Errorf("FindByID = %+v, want the saved task", found)
+		}
+		if found.Status != domain.StatusPending {
+			t.Errorf("Status = %q, want the default %q", found.Status, domain.StatusPending)
+		}
+		if found.DueAt == nil || !found.DueAt.Equal(due) {
+			t.Errorf("DueAt = %v, want %v", found.DueAt, due)
+		}
+		if found.Category == nil || found.Category.ID != category.ID || found.Category.Name != "work" {
+			t.Errorf("Category = %+v, want category %d preloaded", found.Category, category.ID)
+		}
+	})
+
+	t.Run("All", func(t *testing.T) {
+		repos := newRepos(t)
+		category := saveCategory(t, repos.Categories, "work", "")
+		// One more task than a batch, so iteration has to go past the first.
+		var want []uint
+		for i := range database.BatchSize + 1 {
+			task := &domain.Task{Title: "task " + strconv.Itoa(i)}
+			if i%2 == 0 {
+				task.CategoryID = &category.ID
+			}
+			want = append(want, saveTask(t, repos.Tasks, task).ID)
+		}
+
+		var ids []uint
+		for task, err := range repos.Tasks.All(ctx) {
+			if err != nil {
+				t.Fatalf("All: %v", err)
+			}
+			if task.CategoryID != nil && (task.Category == nil || task.Category.Name != "work") {
+				t.Errorf("task %d has Category %+v, want it preloaded", task.ID, task.Category)
+			}
+			ids = append(ids, task.ID)
+		}
+		if !slices.Equal(ids, want) {
+			t.Errorf("All yielded %d tasks, want %d in ID order", len(ids), len(want))
+		}
+	})
+
+	t.Run("FindByIDNotFound", func(t *testing.T) {
+		repos := newRepos(t)
+
+		_, err := repos.Tasks.FindByID(ctx, 404)
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("FindByID(missing) error = %v, want gorm.ErrRecordNotFound", err)
+		}
+	})
+
+	t.Run("SaveWithMissingCategory", func(t *testing.T) {
+		repos := newRepos(t)
+		missing := uint(404)
+
+		if _, err := repos.Tasks.Save(ctx, &domain.Task{Title: "orphan", CategoryID: &missing}); err == nil {
+			t.Error("Save with a missing category succeeded")
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repos := newRepos(t)
+		work := saveCategory(t, repos.Categories, "work", "")
+		home := saveCategory(t, repos.Categories, "home", "")
+		saved := saveTask(t, repos.Tasks, &domain.Task{Title: "write report", CategoryID: &work.ID})
+
+		// Update is given a task as returned by FindByID, with the old
+		// category still preloaded.
+		task, err := repos.Tasks.FindByID(ctx, saved.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		task.Title = "send report"
+		task.Status = domain.StatusDone
+		task.CategoryID = &home.ID
+		updated, err := repos.Tasks.Update(ctx, task)
+		if err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if updated.Category == nil || updated.Category.ID != home.ID {
+			t.Errorf("Update returned Category %+v, want category %d", updated.Category, home.ID)
+		}
+
+		found, err := repos.Tasks.FindByID(ctx, saved.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if found.Title != "send report" || found.Status != domain.StatusDone {
+			t.Errorf("after Update got %+v", found)
+		}
+		if found.CategoryID == nil || *found.CategoryID != home.ID || found.Category == nil || found.Category.ID != home.ID {
+			t.Errorf("after Update CategoryID = %v, Category = %+v; want category %d", found.CategoryID, found.Category, home.ID)
+		}
+
+		found.CategoryID = nil
+		if _, err := repos.Tasks.Update(ctx, found); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		found, err = repos.Tasks.FindByID(ctx, saved.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if found.CategoryID != nil || found.Category != nil {
+			t.Errorf("after clearing the category got CategoryID = %v, Category = %+v", found.CategoryID, found.Category)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repos := newRepos(t)
+		task := saveTask(t, repos.Tasks, &domain.Task{Title: "write report"})
+
+		if err := repos.Tasks.Delete(ctx, task.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := repos.Tasks.FindByID(ctx, task.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("FindByID after Delete error = %v, want gorm.ErrRecordNotFound", err)
+		}
+		if err := repos.Tasks.Delete(ctx, task.ID); err != nil {
+			t.Errorf("Delete(missing) = %v, want nil", err)
+		}
+	})
+
+	t.Run("Sort", func(t *testing.T) {
+		repos := newRepos(t)
+		all := seedTasks(t, repos)
+
+		for _, sortBy := range []string{"title", "due_at", "created_at"} {
+			for _, order := range []string{"asc", "desc"} {
+				got, total, err := repos.Tasks.FindTasks(ctx, &domain.TaskQuery{SortBy: sortBy, SortOrder: order})
+				if err != nil {
+					t.Fatalf("FindTasks(%s %s): %v", sortBy, order, err)
+				}
+				if want := sortedTaskIDs(all, sortBy, order); !slices.Equal(taskIDs(got), want) {
+					t.Errorf("sort %s %s = %v, want %v", sortBy, order, taskIDs(got), want)
+				}
+				if total != len(all) {
+					t.Errorf("sort %s %s total = %d, want %d", sortBy, order, total, len(all))
+				}
+			}
+		}
+
+		got, _, err := repos.Tasks.FindTasks(ctx, &domain.TaskQuery{SortBy: "title"})
+		if err != nil {
+			t.Fatalf("FindTasks: %v", err)
+		}
+		if want := sortedTaskIDs(all, "created_at", "desc"); !slices.Equal(taskIDs(got), want) {
+			t.Errorf("sort without an order = %v, want the default newest first %v", taskIDs(got), want)
+		}
+
+		if _, _, err := repos.Tasks.FindTasks(ctx, &domain.TaskQuery{SortBy: "status", SortOrder: "asc"}); err == nil {
+			t.Error("sorting by an unsupported field succeeded")
+		}
+	})
+
+	t.Run("PerCategory", func(t *testing.T) {
+		repos := newRepos(t)
+		all := seedTasks(t, repos)
+		status := domain.StatusPending
+
+		for _, sortBy := range []string{"title", "due_at"} {
+			got, _, err := repos.Tasks.FindTasks(ctx, &domain.TaskQuery{SortBy: sortBy, SortOrder: "asc", Status: &status, PerCategory: 2})
+			if err != nil {
+				t.Fatalf("FindTasks(%s): %v", sortBy, err)
+			}
+			counts := make(map[uint]int)
+			var want []uint
+			for _, id := range sortedTaskIDs(all, sortBy, "asc") {
+				task := all[slices.IndexFunc(all, func(t *domain.Task) bool { return t.ID == id })]
+				var categoryID uint
+				if task.CategoryID != nil {
+					categoryID = *task.CategoryID
+				}
+				if task.Status == status {
+					if counts[categoryID]++; counts[categoryID] <= 2 {
+						want = append(want, id)
+					}
+				}
+			}
+			if !slices.Equal(taskIDs(got), want) {
+				t.Errorf("two per category by %s = %v, want %v", sortBy, taskIDs(got), want)
+			}
+			for _, task := range got {
+				if task.CategoryID != nil && task.Category == nil {
+					t.Errorf("task %d was not loaded with its category", task.ID)
+				}
+			}
+		}
+
+		if _, _, err := repos.Tasks.FindTasks(ctx, &domain.TaskQuery{Search: "alpha", SortBy: domain.SortByRelevance, PerCategory: 1}); err == nil {
+			t.Error("a per-category limit with relevance sort succeeded")
+		}
+	})
+
+	t.Run("PreloadsCategory", func(t *testing.T) {
+		repos := newRepos(t)
+		seedTasks(t, repos)
+
+		got, _, err := repos.Tasks.FindTasks(ctx, &domain.TaskQuery{})
+		if err != nil {
+			t.Fatalf("FindTasks: %v", err)
+		}
+		for _, task := range got {
+			if task.CategoryID == nil {
+				if task.Category != nil {
+					t.Errorf("task %d has no category but Category = %+v", task.ID, task.Category)
+				}
+				continue
+			}
+			if task.Category == nil || task.Category.ID != *task.CategoryID {
+				t.Errorf("task %d Category = %+v, want category %d", task.ID, task.Category, *task.CategoryID)
+			}
+		}
+	})
+
+	t.Run("OffsetPagination", func(t *testing.T) {
+		repos := newRepos(t)
+		all := seedTasks(t, repos)
+		want := sortedTaskIDs(all, "due_at", "asc")
+
+		var got []uint
+		for page := 1; len(got) < len(all) && page <= len(all); page++ {
+			query := &domain.TaskQuery{BaseQuery: common.BaseQuery{Page: page, PageSize: 3}, SortBy: "due_at", SortOrder: "asc"}
+			tasks, total, err := repos.Tasks.FindTasks(ctx, query)
+			if err != nil {
+				t.Fatalf("page %d: %v", page, err)
+			}
+			if total != len(all) {
+				t.Errorf("page %d total = %d, want %d", page, total, len(all))
+			}
+			got = append(got, taskIDs(tasks)...)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("pages = %v, want %v", got, want)
+		}
+
+		tasks, total, err := repos.Tasks.FindTasks(ctx, &domain.TaskQuery{BaseQuery: common.BaseQuery{Page: 100, PageSize: 3}})
+		if err != nil {
+			t.Fatalf("page past the end: %v", err)
+		}
+		if len(tasks) != 0 || total != len(all) {
+			t.Errorf("page past the end = %d rows, total %d; want 0 rows, total %d", len(tasks), total, len(all))
+		}
+
+		tasks, total, err = repos.Tasks.FindTasks(ctx, &domain.TaskQuery{BaseQuery: common.BaseQuery{Page: 1, PageSize: 3, SkipTotal: true}})
+		if err != nil {
+			t.Fatalf("SkipTotal: %v", err)
+		}
+		if len(tasks) != 3 || total != 0 {
+			t.Errorf("SkipTotal = %d rows, total %d; want 3 rows, total 0", len(tasks), total)
+		}
+	})
+
+	t.Run("HasMore", func(t *testing.T) {
+		repos := newRepos(t)
+		n := len(seedTasks(t, repos))
+		first, _, err := repos.Tasks.FindTasks(ctx, &domain.TaskQuery{BaseQuery: common.BaseQuery{Page: 1, PageSize: 1}, SortBy: "title", SortOrder: "asc"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		cursor := &common.Cursor{SortBy: "title", SortOrder: "asc", Value: first[0].SortKey("title"), ID: first[0].ID}
+
+		// A last page that is exactly full has nothing after it.
+		for _, tt := range []struct {
+			base common.BaseQuery
+			want bool
+		}{
+			{common.BaseQuery{Page: 1, PageSize: n}, false},
+			{common.BaseQuery{Page: 1, PageSize: n, SkipTotal: true}, false},
+			{common.BaseQuery{Page: 1, PageSize: n - 1}, true},
+			{common.BaseQuery{Page: 2, PageSize: n - 1}, false},
+			{common.BaseQuery{PageSize: n - 1, Cursor: cursor}, false},
+			{common.BaseQuery{PageSize: n - 2, Cursor: cursor}, true},
+		} {
+			query := &domain.TaskQuery{BaseQuery: tt.base, SortBy: "title", SortOrder: "asc"}
+			tasks, _, err := repos.Tasks.FindTasks(ctx, query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query.More != tt.want || len(tasks) > tt.base.PageSize {
+				t.Errorf("%+v: %d rows, More = %v; want at most %d rows, More = %v", tt.base, len(tasks), query.More, tt.base.PageSize, tt.want)
+			}
+		}
+	})
+
+	t.Run("CursorPagination", func(t *testing.T) {
+		repos := newRepos(t)
+		all := seedTasks(t, repos)
+
+		for _, sortBy := range []string{"title", "due_at", "created_at"} {
+			for _, order := range []string{"asc", "desc"} {
+				query := &domain.TaskQuery{BaseQuery: common.BaseQuery{PageSize: 3}, SortBy: sortBy, SortOrder: order}
+				var got []uint
+				for pages := 0; pages <= len(all); pages++ {
+					tasks, _, err := repos.Tasks.FindTasks(ctx, query)
+					if err != nil {
+						t.Fatalf("cursor %s %s: %v", sortBy, order, err)
+					}
+					got = append(got, taskIDs(tasks)...)
+					if !query.More {
+						break
+					}
+					last := tasks[len(tasks)-1]
+					query.Cursor = &common.Cursor{SortBy: sortBy, SortOrder: order, Value: last.SortKey(sortBy), ID: last.ID}
+				}
+				if want := sortedTaskIDs(all, sortBy, order); !slices.Equal(got, want) {
+					t.Errorf("cursor %s %s = %v, want %v", sortBy, order, got, want)
+				}
+			}
+		}
+
+		invalid := []*common.Cursor{
+			{SortBy: "created_at", SortOrder: "desc", ID: 1},
+			{SortBy: "created_at", SortOrder: "desc", Value: stringPtr("yesterday"), ID: 1},
+		}
+		for _, cursor := range invalid {
+			query := &domain.TaskQuery{BaseQuery: common.BaseQuery{PageSize: 3, Cursor: cursor}}
+			if _, _, err := repos.Tasks.FindTasks(ctx, query); err == nil {
+				t.Errorf("cursor %+v was accepted", cursor)
+			}
+		}
+	})
+
+	t.Run("StatusAndFilter", func(t *testing.T) {
+		repos := newRepos(t)
+		all := seedTasks(t, repos)
+		now := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+
+		done := domain.StatusDone
+		got, total, err := repos.Tasks.FindTasks(ctx, &domain.TaskQuery{Status: &done})
+		if err != nil {
+			t.Fatalf("status: %v", err)
+		}
+		want := matchingTaskIDs(all, func(task *domain.Task) bool { return task.Status == domain.StatusDone })
+		if ids := sortedIDs(taskIDs(got)); !slices.Equal(ids, want) || total != len(want) {
+			t.Errorf("status done = %v (total %d), want %v", ids, total, want)
+		}
+
+		category := *all[1].CategoryID
+		cases := []struct {
+			filter string
+			match  func(task *domain.Task) bool
+		}{
+			{"category_id = " + uintString(category), func(task *domain.Task) bool {
+				return task.CategoryID != nil && *task.CategoryID == category
+			}},
+			// != and NOT IN keep tasks without a category...
+			{"category_id != " + uintString(category), func(task *domain.Task) bool {
+				return task.CategoryID == nil || *task.CategoryID != category
+			}},
+			{"category_id not in (" + uintString(category) + ")", func(task *domain.Task) bool {
+				return task.CategoryID == nil || *task.CategoryID != category
+			}},
+			// ...but negating a comparison against NULL does not.
+			{"not (category_id = " + uintString(category) + ")", func(task *domain.Task) bool {
+				return task.CategoryID != nil && *task.CategoryID != category
+			}},
+			{"due_at is null", func(task *domain.Task) bool { return task.DueAt == nil }},
+			{"not (due_at > today)", func(task *domain.Task) bool {
+				return task.DueAt != nil && !task.DueAt.After(now)
+			}},
+			{"title ~ ALPHA or status in (doing)", func(task *domain.Task) bool {
+				return strings.Contains(task.Title, "alpha") || task.Status == domain.StatusDoing
+			}},
+			{"due_at < today+2d and status != done", func(task *domain.Task) bool {
+				return task.DueAt != nil && task.DueAt.Before(now.AddDate(0, 0, 2)) && task.Status != domain.StatusDone
+			}},
+		}
+		for _, tc := range cases {
+			expr, err := domain.ParseFilter(tc.filter, now)
+			if err != nil {
+				t.Fatalf("ParseFilter(%q): %v", tc.filter, err)
+			}
+			got, total, err := repos.Tasks.FindTasks(ctx, &domain.TaskQuery{Filter: expr})
+			if err != nil {
+				t.Fatalf("filter %q: %v", tc.filter, err)
+			}
+			want := matchingTaskIDs(all, tc.match)
+			if ids := sortedIDs(taskIDs(got)); !slices.Equal(ids, want) || total != len(want) {
+				t.Errorf("filter %q = %v (total %d), want %v", tc.filter, ids, total, want)
+			}
+		}
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		repos := newRepos(t)
+		report := saveTask(t, repos.Tasks, &domain.Task{Title: "quarterly report", Description: "report for the board"})
+		slides := saveTask(t, repos.Tasks, &domain.Task{Title: "board slides", Description: "based on the report"})
+		saveTask(t, repos.Tasks, &domain.Task{Title: "groceries", Description: "milk and eggs"})
+
+		got, total, err := repos.Tasks.FindTasks(ctx, &domain.TaskQuery{Search: "Report"})
+		if err != nil {
+			t.Fatalf("search: %v", err)
+		}
+		if ids := sortedIDs(taskIDs(got)); !slices.Equal(ids, []uint{report.ID, slides.ID}) || total != 2 {
+			t.Errorf("search = %v (total %d), want %v", ids, total, []uint{report.ID, slides.ID})
+		}
+		for _, task := range got {
+			if task.SearchRank <= 0 {
+				t.Errorf("task %d SearchRank = %v, want > 0", task.ID, task.SearchRank)
+			}
+			if !strings.Contains(strings.ToLower(task.SearchHighlight), "<mark>report</mark>") {
+				t.Errorf("task %d SearchHighlight = %q, want the term marked", task.ID, task.SearchHighlight)
+			}
+		}
+
+		got, _, err = repos.Tasks.FindTasks(ctx, &domain.TaskQuery{Search: "report -board"})
+		if err != nil {
+			t.Fatalf("search with exclusion: %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("search with exclusion = %v, want none", taskIDs(got))
+		}
+
+		doing := domain.StatusDoing
+		got, _, err = repos.Tasks.FindTasks(ctx, &domain.TaskQuery{Search: "report", Status: &doing})
+		if err != nil {
+			t.Fatalf("search with status: %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("search with status = %v, want none", taskIDs(got))
+		}
+
+		got, _, err = repos.Tasks.FindTasks(ctx, &domain.TaskQuery{Search: "report", SortBy: domain.SortByRelevance})
+		if err != nil {
+			t.Fatalf("relevance: %v", err)
+		}
+		if len(got) != 2 || got[0].ID != report.ID {
+			t.Errorf("relevance = %v, want the task mentioning report twice first", taskIDs(got))
+		}
+		for i := 1; i < len(got); i++ {
+			if got[i].SearchRank > got[i-1].SearchRank {
+				t.Errorf("relevance order has rank %v after %v", got[i].SearchRank, got[i-1].SearchRank)
+			}
+		}
+
+		if _, _, err := repos.Tasks.FindTasks(ctx, &domain.TaskQuery{SortBy: domain.SortByRelevance}); err == nil {
+			t.Error("relevance without a search term succeeded")
+		}
+		query := &domain.TaskQuery{Search: "report", SortBy: domain.SortByRelevance}
+		query.Cursor = &common.Cursor{SortBy: domain.SortByRelevance, SortOrder: "desc", ID: 1}
+		if _, _, err := repos.Tasks.FindTasks(ctx, query); err == nil {
+			t.Error("relevance with a cursor succeeded")
+		}
+	})
+}
+
+func saveTask(t *testing.T, repo domain.TaskRepository, task *domain.Task) *domain.Task {
+	t.Helper()
+	saved, err := repo.Save(context.Background(), task)
+	if err != nil {
+		t.Fatalf("Save task: %v", err)
+	}
+	return saved
+}
+
+// seedTasks saves tasks with repeated titles, creation times and due dates,
+// some without a due date or category, so every sort hits ties and NULLs.
+func seedTasks(t *testing.T, repos TaskRepositories) []*domain.Task {
+	t.Helper()
+	categories := []*categoryDomain.Category{
+		saveCategory(t, repos.Categories, "work", ""),
+		saveCategory(t, repos.Categories, "home", ""),
+	}
+
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	titles := []string{"alpha", "beta", "alpha report", "gamma", "beta", "delta"}
+	statuses := []domain.TaskStatus{domain.StatusPending, domain.StatusDoing, domain.StatusDone}
+	var tasks []*domain.Task
+	for i := 0; i < 14; i++ {
+		task := &domain.Task{
+			Title:     titles[i%len(titles)],
+			Status:    statuses[i%len(statuses)],
+			CreatedAt: base.Add(time.Duration(i%4) * time.Minute),
+		}
+		if i%4 != 0 {
+			due := base.AddDate(0, 0, i%5)
+			task.DueAt = &due
+		}
+		if i%3 != 0 {
+			task.CategoryID = &categories[i%2].ID
+		}
+		tasks = append(tasks, saveTask(t, repos.Tasks, task))
+	}
+	return tasks
+}
+
+var taskColumns = map[string]database.KeysetColumn{
+	"title":      {Name: "title"},
+	"due_at":     {Name: "due_at", Nullable: true, Time: true},
+	"created_at": {Name: "created_at", Time: true},
+}
+
+func sortedTaskIDs(tasks []*domain.Task, sortBy, order string) []uint {
+	sorted := slices.Clone(tasks)
+	slices.SortFunc(sorted, func(a, b *domain.Task) int {
+		return database.Compare(taskColumns[sortBy], order, a.SortKey(sortBy), a.ID, b.SortKey(sortBy), b.ID)
+	})
+	return taskIDs(sorted)
+}
+
+func matchingTaskIDs(tasks []*domain.Task, match func(*domain.Task) bool) []uint {
+	var ids []uint
+	for _, task := range tasks {
+		if match(task) {
+			ids = append(ids, task.ID)
+		}
+	}
+	return sortedIDs(ids)
+}
+
+func taskIDs(tasks []*domain.Task) []uint {
+	ids := make([]uint, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
+
+func uintString(n uint) string {
+	return strconv.FormatUint(uint64(n), 10)
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,154 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ltphat2204/domain-driven-golang/modules/view/domain"
+)
+
+// RunViewRepository runs the ViewRepository contract. newRepo must return an
+// empty repository each time it is called.
+func RunViewRepository(t *testing.T, newRepo func(t *testing.T) domain.ViewRepository) {
+	ctx := context.Background()
+
+	save := func(t *testing.T, repo domain.ViewRepository, view *domain.View) *domain.View {
+		t.Helper()
+		saved, err := repo.Save(ctx, view)
+		if err != nil {
+			t.Fatalf("Save %q: %v", view.Name, err)
+		}
+		return saved
+	}
+	pin := func(t *testing.T, repo domain.ViewRepository, pin domain.ViewPin) {
+		t.Helper()
+		if err := repo.SavePin(ctx, &pin); err != nil {
+			t.Fatalf("SavePin %+v: %v", pin, err)
+		}
+	}
+
+	t.Run("SaveAndFindByID", func(t *testing.T) {
+		repo := newRepo(t)
+
+		saved := save(t, repo, &domain.View{Name: "overdue", OwnerID: "ann", Visibility: domain.VisibilityShared, Filter: "due_at < now"})
+		if saved.ID == 0 {
+			t.Fatal("Save did not assign an ID")
+		}
+
+		found, err := repo.FindByID(ctx, saved.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if found.Name != "overdue" || found.OwnerID != "ann" || found.Visibility != domain.VisibilityShared || found.Filter != "due_at < now" {
+			t.Errorf("FindByID = %+v, want the saved view", found)
+		}
+
+		if _, err := repo.FindByID(ctx, 404); !errors.Is(err, domain.ErrViewNotFound) {
+			t.Errorf("FindByID(missing) error = %v, want ErrViewNotFound", err)
+		}
+	})
+
+	t.Run("PinsArePerUser", func(t *testing.T) {
+		repo := newRepo(t)
+		shared := save(t, repo, &domain.View{Name: "shared", OwnerID: "ann", Visibility: domain.VisibilityShared})
+		save(t, repo, &domain.View{Name: "ann's", OwnerID: "ann", Visibility: domain.VisibilityPersonal})
+
+		pin(t, repo, domain.ViewPin{UserID: "bob", ViewID: shared.ID, Pinned: true, Position: 2})
+
+		got, err := repo.FindPin(ctx, "bob", shared.ID)
+		if err != nil {
+			t.Fatalf("FindPin: %v", err)
+		}
+		if !got.Pinned || got.Position != 2 {
+			t.Errorf("FindPin(bob) = %+v, want pinned at 2", got)
+		}
+		got, err = repo.FindPin(ctx, "ann", shared.ID)
+		if err != nil {
+			t.Fatalf("FindPin: %v", err)
+		}
+		if got.Pinned || got.Position != 0 || got.UserID != "ann" || got.ViewID != shared.ID {
+			t.Errorf("FindPin(ann) = %+v, want ann's unpinned pin", got)
+		}
+
+		bob, err := repo.FindVisibleTo(ctx, "bob")
+		if err != nil {
+			t.Fatalf("FindVisibleTo: %v", err)
+		}
+		if len(bob) != 1 || bob[0].ID != shared.ID || !bob[0].Pinned || bob[0].Position != 2 {
+			t.Errorf("FindVisibleTo(bob) = %v, want only the shared view, pinned at 2", viewNames(bob))
+		}
+		ann, err := repo.FindVisibleTo(ctx, "ann")
+		if err != nil {
+			t.Fatalf("FindVisibleTo: %v", err)
+		}
+		for _, view := range ann {
+			if view.Pinned || view.Position != 0 {
+				t.Errorf("FindVisibleTo(ann) has %q pinned at %d; bob's pin leaked", view.Name, view.Position)
+			}
+		}
+		if len(ann) != 2 {
+			t.Errorf("FindVisibleTo(ann) = %v, want both views", viewNames(ann))
+		}
+
+		// Saving the view must not overwrite anyone's pin.
+		shared.Name = "renamed"
+		if _, err := repo.Update(ctx, shared); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if got, _ := repo.FindPin(ctx, "bob", shared.ID); !got.Pinned {
+			t.Error("Update dropped bob's pin")
+		}
+	})
+
+	t.Run("SidebarOrder", func(t *testing.T) {
+		repo := newRepo(t)
+		var ids []uint
+		for _, name := range []string{"a", "b", "c", "d"} {
+			ids = append(ids, save(t, repo, &domain.View{Name: name, OwnerID: "ann", Visibility: domain.VisibilityShared}).ID)
+		}
+		pin(t, repo, domain.ViewPin{UserID: "ann", ViewID: ids[2], Pinned: true, Position: 1})
+		pin(t, repo, domain.ViewPin{UserID: "ann", ViewID: ids[3], Pinned: true, Position: 0})
+		pin(t, repo, domain.ViewPin{UserID: "ann", ViewID: ids[0], Position: 5})
+		// A second save moves the pin rather than adding one.
+		pin(t, repo, domain.ViewPin{UserID: "ann", ViewID: ids[0], Position: 3})
+		pin(t, repo, domain.ViewPin{UserID: "bob", ViewID: ids[1], Pinned: true})
+
+		views, err := repo.FindVisibleTo(ctx, "ann")
+		if err != nil {
+			t.Fatalf("FindVisibleTo: %v", err)
+		}
+		if got, want := viewNames(views), []string{"d", "c", "b", "a"}; !slices.Equal(got, want) {
+			t.Errorf("FindVisibleTo(ann) = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("DeleteRemovesPins", func(t *testing.T) {
+		repo := newRepo(t)
+		view := save(t, repo, &domain.View{Name: "gone", OwnerID: "ann", Visibility: domain.VisibilityShared})
+		pin(t, repo, domain.ViewPin{UserID: "bob", ViewID: view.ID, Pinned: true})
+
+		if err := repo.Delete(ctx, view.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := repo.FindByID(ctx, view.ID); !errors.Is(err, domain.ErrViewNotFound) {
+			t.Errorf("FindByID after Delete error = %v, want ErrViewNotFound", err)
+		}
+		got, err := repo.FindPin(ctx, "bob", view.ID)
+		if err != nil {
+			t.Fatalf("FindPin: %v", err)
+		}
+		if got.Pinned {
+			t.Error("Delete left bob's pin behind")
+		}
+	})
+}
+
+func viewNames(views []*domain.View) []string {
+	names := make([]string, len(views))
+	for i, view := range views {
+		names[i] = view.Name
+	}
+	return names
+}
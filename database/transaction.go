@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// Transactor runs work in a transaction that the repositories it calls take
+// part in, through the context it hands that work.
+type Transactor interface {
+	// Transaction runs fn and commits if it returns nil, or rolls back
+	// otherwise. Inside another transaction it nests, like a savepoint: a
+	// rollback undoes only fn's work, and a commit leaves it to the outer
+	// transaction.
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txKey struct{}
+
+type transaction struct {
+	db          *gorm.DB
+	afterCommit []func()
+}
+
+func fromContext(ctx context.Context) *transaction {
+	tx, _ := ctx.Value(txKey{}).(*transaction)
+	return tx
+}
+
+// Conn returns the connection repositories should use for ctx: the
+// transaction ctx carries, or db.
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx := fromContext(ctx); tx != nil && tx.db != nil {
+		return tx.db.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// AfterCommit runs fn once the transaction ctx carries commits, and never
+// if it rolls back; outside a transaction fn runs straight away. Side
+// effects such as events use it so they only announce changes that stuck.
+func AfterCommit(ctx context.Context, fn func()) {
+	if tx := fromContext(ctx); tx != nil {
+		tx.afterCommit = append(tx.afterCommit, fn)
+		return
+	}
+	fn()
+}
+
+// committed runs the after-commit hooks, or hands them to parent when tx
+// was nested in it.
+func (tx *transaction) committed(parent *transaction) {
+	if parent != nil {
+		parent.afterCommit = append(parent.afterCommit, tx.afterCommit...)
+		return
+	}
+	for _, hook := range tx.afterCommit {
+		hook()
+	}
+}
+
+type gormTransactor struct {
+	db *gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) Transactor {
+	return &gormTransactor{db: db}
+}
+
+func (t *gormTransactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// gorm nests a transaction started on one in a savepoint.
+	db := t.db
+	parent := fromContext(ctx)
+	if parent != nil {
+		db = parent.db
+	}
+	tx := &transaction{}
+	err := db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		tx.db = db
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+	if err != nil {
+		return err
+	}
+	tx.committed(parent)
+	return nil
+}
+
+// Snapshotter is an in-memory store that can be put back the way it was.
+type Snapshotter interface {
+	// Snapshot records the current contents and returns a function that
+	// restores them.
+	Snapshot() (restore func())
+}
+
+type memoryTransactor struct {
+	mu     sync.Mutex
+	stores []Snapshotter
+}
+
+// NewMemoryTransactor returns a Transactor for in-memory repositories, which
+// rolls back by restoring a snapshot of stores. Transactions run one at a
+// time but are not isolated from writes made outside them, which a
+// rollback discards; that is enough for development and tests.
+func NewMemoryTransactor(stores ...Snapshotter) Transactor {
+	return &memoryTransactor{stores: stores}
+}
+
+func (t *memoryTransactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// A nested transaction already holds the lock.
+	parent := fromContext(ctx)
+	if parent == nil {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	restores := make([]func(), len(t.stores))
+	for i, store := range t.stores {
+		restores[i] = store.Snapshot()
+	}
+	tx := &transaction{}
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		for _, restore := range restores {
+			restore()
+		}
+		return err
+	}
+	tx.committed(parent)
+	return nil
+}
@@ -0,0 +1,77 @@
+// Package dataloader batches and caches lookups by key for the length of one
+// request, so resolving a field on many objects costs one query instead of
+// one per object.
+package dataloader
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchFunc loads the values of keys in one go. Keys missing from the result
+// load as the zero value.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader collects keys until one of their values is needed, then loads every
+// collected key with a single call to its BatchFunc. Values are cached, so a
+// key is loaded at most once. A Loader is meant to live for one request.
+type Loader[K comparable, V any] struct {
+	batch BatchFunc[K, V]
+
+	mu      sync.Mutex
+	results map[K]*result[V]
+	pending []K
+}
+
+type result[V any] struct {
+	value  V
+	err    error
+	loaded bool
+}
+
+func New[K comparable, V any](batch BatchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{batch: batch, results: make(map[K]*result[V])}
+}
+
+// Load queues key and returns a function that waits for its value. Calling
+// it loads every key queued so far, so callers should queue all the keys they
+// will need before asking for any of them.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) func() (V, error) {
+	l.mu.Lock()
+	r, ok := l.results[key]
+	if !ok {
+		r = &result[V]{}
+		l.results[key] = r
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if !r.loaded {
+			l.dispatch(ctx)
+		}
+		return r.value, r.err
+	}
+}
+
+// Prime caches value for key, for values that came with another query.
+func (l *Loader[K, V]) Prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.results[key]; !ok {
+		l.results[key] = &result[V]{value: value, loaded: true}
+	}
+}
+
+// dispatch loads the pending keys; l.mu must be held.
+func (l *Loader[K, V]) dispatch(ctx context.Context) {
+	keys := l.pending
+	l.pending = nil
+	values, err := l.batch(ctx, keys)
+	for _, key := range keys {
+		r := l.results[key]
+		r.value, r.err, r.loaded = values[key], err, true
+	}
+}
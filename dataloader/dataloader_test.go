@@ -0,0 +1,69 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestLoaderBatchesAndCaches(t *testing.T) {
+	var batches [][]int
+	loader := New(func(ctx context.Context, keys []int) (map[int]string, error) {
+		batches = append(batches, slices.Clone(keys))
+		values := make(map[int]string)
+		for _, key := range keys {
+			if key != 3 {
+				values[key] = string(rune('a' + key))
+			}
+		}
+		return values, nil
+	})
+
+	ctx := context.Background()
+	thunks := []func() (string, error){loader.Load(ctx, 1), loader.Load(ctx, 2), loader.Load(ctx, 1), loader.Load(ctx, 3)}
+	var got []string
+	for _, thunk := range thunks {
+		value, err := thunk()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, value)
+	}
+	if want := []string{"b", "c", "b", ""}; !slices.Equal(got, want) {
+		t.Errorf("values = %q, want %q", got, want)
+	}
+
+	// Cached keys are not loaded again; new ones get a batch of their own.
+	if value, _ := loader.Load(ctx, 2)(); value != "c" {
+		t.Errorf("cached value = %q, want c", value)
+	}
+	if value, _ := loader.Load(ctx, 4)(); value != "e" {
+		t.Errorf("value = %q, want e", value)
+	}
+	if want := [][]int{{1, 2, 3}, {4}}; !slices.EqualFunc(batches, want, slices.Equal) {
+		t.Errorf("batches = %v, want %v", batches, want)
+	}
+}
+
+func TestLoaderErrorAndPrime(t *testing.T) {
+	calls := 0
+	errBoom := errors.New("boom")
+	loader := New(func(ctx context.Context, keys []string) (map[string]int, error) {
+		calls++
+		return nil, errBoom
+	})
+	loader.Prime("primed", 7)
+
+	ctx := context.Background()
+	primed, failed := loader.Load(ctx, "primed"), loader.Load(ctx, "other")
+	if value, err := primed(); value != 7 || err != nil {
+		t.Errorf("primed = %d, %v; want 7, nil", value, err)
+	}
+	if _, err := failed(); !errors.Is(err, errBoom) {
+		t.Errorf("err = %v, want %v", err, errBoom)
+	}
+	if calls != 1 {
+		t.Errorf("batch called %d times, want 1", calls)
+	}
+}
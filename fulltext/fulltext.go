@@ -0,0 +1,46 @@
+package fulltext
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Document describes the searchable text of a table.
+type Document struct {
+	Table   string
+	Columns []string
+	// Vector is the generated tsvector column used by the Postgres engine.
+	Vector string
+}
+
+// Engine is the port repositories use to apply a free-text search to a query.
+// Match filters rows, Annotate selects the search_rank and search_highlight
+// columns and OrderByRank sorts by relevance. OrderByRank replaces any
+// ordering already on the query, so it must be the only ORDER BY.
+type Engine interface {
+	Match(db *gorm.DB, doc Document, text string) *gorm.DB
+	Annotate(db *gorm.DB, doc Document, text string) *gorm.DB
+	OrderByRank(db *gorm.DB, doc Document, text string) *gorm.DB
+}
+
+// ForDB picks the engine matching the database dialect.
+func ForDB(db *gorm.DB) Engine {
+	if db != nil && db.Dialector != nil && db.Dialector.Name() == "postgres" {
+		return NewPostgresEngine("simple")
+	}
+	return NewLikeEngine()
+}
+
+func (d Document) column(name string) string {
+	return d.Table + "." + name
+}
+
+// concat joins the document columns into one text expression.
+func (d Document) concat() string {
+	parts := make([]string, 0, len(d.Columns))
+	for _, c := range d.Columns {
+		parts = append(parts, "coalesce("+d.column(c)+", '')")
+	}
+	return strings.Join(parts, " || ' ' || ")
+}
@@ -0,0 +1,78 @@
+package fulltext
+
+import (
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type likeEngine struct{}
+
+// NewLikeEngine is the portable fallback: every term must appear in one of
+// the document columns, compared case-insensitively. Rank counts the columns
+// each term appears in; highlights are added in Go by Highlight.
+func NewLikeEngine() Engine {
+	return likeEngine{}
+}
+
+func likePattern(term string) string {
+	return "%" + likeEscaper.Replace(strings.ToLower(term)) + "%"
+}
+
+func (likeEngine) anyColumn(doc Document, term string) (string, []interface{}) {
+	parts := make([]string, 0, len(doc.Columns))
+	args := make([]interface{}, 0, len(doc.Columns))
+	for _, c := range doc.Columns {
+		parts = append(parts, "LOWER("+doc.column(c)+`) LIKE ? ESCAPE '\'`)
+		args = append(args, likePattern(term))
+	}
+	return "(" + strings.Join(parts, " OR ") + ")", args
+}
+
+func (e likeEngine) Match(db *gorm.DB, doc Document, text string) *gorm.DB {
+	query := ParseQuery(text)
+	for _, term := range query.Include {
+		sql, args := e.anyColumn(doc, term)
+		db = db.Where(sql, args...)
+	}
+	for _, term := range query.Exclude {
+		sql, args := e.anyColumn(doc, term)
+		db = db.Not(sql, args...)
+	}
+	return db
+}
+
+func (likeEngine) rank(doc Document, text string) (string, []interface{}) {
+	query := ParseQuery(text)
+	var parts []string
+	var args []interface{}
+	for i, c := range doc.Columns {
+		weight := len(doc.Columns) - i
+		for _, term := range query.Include {
+			parts = append(parts, "CASE WHEN LOWER("+doc.column(c)+`) LIKE ? ESCAPE '\' THEN `+strconv.Itoa(weight)+" ELSE 0 END")
+			args = append(args, likePattern(term))
+		}
+	}
+	if len(parts) == 0 {
+		return "0", nil
+	}
+	return "(" + strings.Join(parts, " + ") + ")", args
+}
+
+func (e likeEngine) Annotate(db *gorm.DB, doc Document, text string) *gorm.DB {
+	rank, args := e.rank(doc, text)
+	return db.Select(doc.Table+".*, "+rank+" AS search_rank", args...)
+}
+
+func (e likeEngine) OrderByRank(db *gorm.DB, doc Document, text string) *gorm.DB {
+	rank, args := e.rank(doc, text)
+	return db.Clauses(clause.OrderBy{Expression: clause.Expr{
+		SQL:                rank + " DESC, " + doc.column("id") + " DESC",
+		Vars:               args,
+		WithoutParentheses: true,
+	}})
+}
@@ -0,0 +1,178 @@
+package fulltext
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Query is a parsed web-search style query: bare words and "quoted phrases"
+// must all match, words prefixed with - must not.
+type Query struct {
+	Include []string
+	Exclude []string
+}
+
+func ParseQuery(text string) Query {
+	var query Query
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		negate := false
+		if runes[i] == '-' {
+			negate = true
+			i++
+		}
+
+		var term string
+		if i < len(runes) && runes[i] == '"' {
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			term = string(runes[i+1 : end])
+			i = end + 1
+		} else {
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) {
+				i++
+			}
+			term = string(runes[start:i])
+		}
+
+		term = strings.TrimSpace(term)
+		if term == "" || (!negate && strings.EqualFold(term, "or")) {
+			continue
+		}
+		if negate {
+			query.Exclude = append(query.Exclude, term)
+		} else {
+			query.Include = append(query.Include, term)
+		}
+	}
+	return query
+}
+
+// Score returns how well fields match text, or 0 when they do not match.
+// Earlier fields weigh more, so a title hit outranks a description hit.
+func Score(text string, fields ...string) float64 {
+	query := ParseQuery(text)
+	if len(query.Include) == 0 && len(query.Exclude) == 0 {
+		return 0
+	}
+
+	lowered := make([]string, len(fields))
+	for i, f := range fields {
+		lowered[i] = strings.ToLower(f)
+	}
+
+	for _, term := range query.Exclude {
+		for _, f := range lowered {
+			if strings.Contains(f, strings.ToLower(term)) {
+				return 0
+			}
+		}
+	}
+
+	score := 0.0
+	for _, term := range query.Include {
+		t := strings.ToLower(term)
+		termScore := 0.0
+		for i, f := range lowered {
+			if n := strings.Count(f, t); n > 0 {
+				termScore += float64(n * (len(fields) - i))
+			}
+		}
+		if termScore == 0 {
+			return 0
+		}
+		score += termScore
+	}
+	if score == 0 {
+		// Only exclusions were given and none matched.
+		return 1
+	}
+	return score
+}
+
+// Highlight wraps every occurrence of the query terms in <mark> tags and
+// trims content to a window around the first hit.
+func Highlight(text, content string) string {
+	query := ParseQuery(text)
+	content = strings.TrimSpace(content)
+	if len(query.Include) == 0 || content == "" {
+		return ""
+	}
+
+	lower := strings.ToLower(content)
+	if len(lower) != len(content) {
+		// Lowercasing changed byte offsets; fall back to exact-case matching.
+		lower = content
+	}
+	type span struct{ start, end int }
+	var spans []span
+	for _, term := range query.Include {
+		t := strings.ToLower(term)
+		for offset := 0; ; {
+			idx := strings.Index(lower[offset:], t)
+			if idx < 0 {
+				break
+			}
+			start := offset + idx
+			spans = append(spans, span{start, start + len(t)})
+			offset = start + len(t)
+		}
+	}
+	if len(spans) == 0 {
+		return ""
+	}
+
+	marked := make([]bool, len(content)+1)
+	for _, s := range spans {
+		for i := s.start; i < s.end; i++ {
+			marked[i] = true
+		}
+	}
+
+	const window = 80
+	first := len(content)
+	for _, s := range spans {
+		first = min(first, s.start)
+	}
+	from := max(0, first-window/2)
+	to := min(len(content), first+window)
+	for from > 0 && !isBoundary(content, from) {
+		from--
+	}
+	for to < len(content) && !isBoundary(content, to) {
+		to++
+	}
+
+	var sb strings.Builder
+	if from > 0 {
+		sb.WriteString("…")
+	}
+	for i := from; i < to; i++ {
+		if marked[i] && (i == from || !marked[i-1]) {
+			sb.WriteString("<mark>")
+		}
+		sb.WriteByte(content[i])
+		if marked[i] && !marked[i+1] {
+			sb.WriteString("</mark>")
+		}
+	}
+	if marked[to-1] && to < len(content) && marked[to] {
+		sb.WriteString("</mark>")
+	}
+	if to < len(content) {
+		sb.WriteString("…")
+	}
+	return sb.String()
+}
+
+func isBoundary(s string, i int) bool {
+	return s[i] == ' ' || s[i] == '\n' || s[i] == '\t'
+}
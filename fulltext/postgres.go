@@ -0,0 +1,43 @@
+package fulltext
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2"
+
+type postgresEngine struct {
+	config string
+}
+
+// NewPostgresEngine searches a generated tsvector column using
+// websearch_to_tsquery, ranks with ts_rank and highlights with ts_headline.
+func NewPostgresEngine(config string) Engine {
+	return &postgresEngine{config: config}
+}
+
+func (e *postgresEngine) tsquery() string {
+	return "websearch_to_tsquery('" + e.config + "', ?)"
+}
+
+func (e *postgresEngine) rank(doc Document) string {
+	return "ts_rank(" + doc.column(doc.Vector) + ", " + e.tsquery() + ")"
+}
+
+func (e *postgresEngine) Match(db *gorm.DB, doc Document, text string) *gorm.DB {
+	return db.Where(doc.column(doc.Vector)+" @@ "+e.tsquery(), text)
+}
+
+func (e *postgresEngine) Annotate(db *gorm.DB, doc Document, text string) *gorm.DB {
+	headline := "ts_headline('" + e.config + "', " + doc.concat() + ", " + e.tsquery() + ", '" + headlineOptions + "')"
+	return db.Select(doc.Table+".*, "+e.rank(doc)+" AS search_rank, "+headline+" AS search_highlight", text, text)
+}
+
+func (e *postgresEngine) OrderByRank(db *gorm.DB, doc Document, text string) *gorm.DB {
+	return db.Clauses(clause.OrderBy{Expression: clause.Expr{
+		SQL:                e.rank(doc) + " DESC, " + doc.column("id") + " DESC",
+		Vars:               []interface{}{text},
+		WithoutParentheses: true,
+	}})
+}
@@ -0,0 +1,293 @@
+// Package api is the GraphQL schema of the task manager, resolved through
+// the same application services as the REST API.
+package api
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"gorm.io/gorm"
+
+	"github.com/ltphat2204/domain-driven-golang/common"
+	"github.com/ltphat2204/domain-driven-golang/graphql"
+	categoryApplication "github.com/ltphat2204/domain-driven-golang/modules/category/application"
+	taskApplication "github.com/ltphat2204/domain-driven-golang/modules/task/application"
+)
+
+type API struct {
+	schema     *graphql.Schema
+	tasks      taskApplication.TaskService
+	categories categoryApplication.CategoryService
+}
+
+func New(tasks taskApplication.TaskService, categories categoryApplication.CategoryService) (*API, error) {
+	a := &API{tasks: tasks, categories: categories}
+	task, category := a.taskType(), a.categoryType()
+	a.linkTypes(task, category)
+
+	schema, err := graphql.NewSchema(gql.SchemaConfig{
+		Query:    gql.NewObject(gql.ObjectConfig{Name: "Query", Fields: merge(a.taskQueries(task), a.categoryQueries(category))}),
+		Mutation: gql.NewObject(gql.ObjectConfig{Name: "Mutation", Fields: merge(a.taskMutations(task), a.categoryMutations(category))}),
+	}, graphql.Complexity{
+		"Query.tasks":      listComplexity,
+		"Query.categories": listComplexity,
+		"Category.tasks":   listComplexity,
+	})
+	if err != nil {
+		return nil, err
+	}
+	a.schema = schema
+	return a, nil
+}
+
+func (a *API) Schema() *graphql.Schema { return a.schema }
+
+// Handler serves the schema over HTTP with fresh dataloaders for every
+// request.
+func (a *API) Handler(limits graphql.Limits) gin.HandlerFunc {
+	serve := a.schema.Handler(limits)
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(a.withLoaders(c.Request.Context()))
+		serve(c)
+	}
+}
+
+// merge joins the fields of two modules into one root type's.
+func merge(a, b gql.Fields) gql.Fields {
+	for name, field := range b {
+		a[name] = field
+	}
+	return a
+}
+
+// dateTime serializes times as RFC 3339; graphql-go reports values it
+// cannot parse, returned as nil, as invalid.
+var dateTime = gql.NewScalar(gql.ScalarConfig{
+	Name:        "DateTime",
+	Description: "An RFC 3339 timestamp, such as 2025-06-15T17:00:00Z.",
+	Serialize: func(v interface{}) interface{} {
+		switch t := v.(type) {
+		case time.Time:
+			return t.Format(time.RFC3339Nano)
+		case *time.Time:
+			return t.Format(time.RFC3339Nano)
+		}
+		return nil
+	},
+	ParseValue: func(v interface{}) interface{} {
+		if s, ok := v.(string); ok {
+			return parseTime(s)
+		}
+		return nil
+	},
+	ParseLiteral: func(v ast.Value) interface{} {
+		if s, ok := v.(*ast.StringValue); ok {
+			return parseTime(s.Value)
+		}
+		return nil
+	},
+})
+
+func parseTime(s string) interface{} {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+	return t
+}
+
+var sortOrder = gql.NewEnum(gql.EnumConfig{
+	Name: "SortOrder",
+	Values: gql.EnumValueConfigMap{
+		"ASC":  {Value: "asc"},
+		"DESC": {Value: "desc"},
+	},
+})
+
+var pageInfo = gql.NewObject(gql.ObjectConfig{
+	Name:        "PageInfo",
+	Description: "Pagination of a list, as the meta block of REST list responses.",
+	Fields: gql.Fields{
+		"total": {Type: gql.Int, Description: "Null when includeTotal is false.",
+			Resolve: metaField(func(m common.PaginationMeta) interface{} { return intOrNil(m.Total) })},
+		"page": {Type: gql.Int, Description: "Null for cursor pagination.",
+			Resolve: metaField(func(m common.PaginationMeta) interface{} {
+				if m.Page == 0 {
+					return nil
+				}
+				return m.Page
+			})},
+		"pageSize": {Type: gql.NewNonNull(gql.Int),
+			Resolve: metaField(func(m common.PaginationMeta) interface{} { return m.PageSize })},
+		"totalPages": {Type: gql.Int,
+			Resolve: metaField(func(m common.PaginationMeta) interface{} { return intOrNil(m.TotalPages) })},
+		"hasMore": {Type: gql.NewNonNull(gql.Boolean),
+			Resolve: metaField(func(m common.PaginationMeta) interface{} { return m.HasMore })},
+		"nextCursor": {Type: gql.String, Description: "Pass as after to get the next page.",
+			Resolve: metaField(func(m common.PaginationMeta) interface{} {
+				if m.NextCursor == "" {
+					return nil
+				}
+				return m.NextCursor
+			})},
+	},
+})
+
+func metaField(get func(common.PaginationMeta) interface{}) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		return get(p.Source.(*page).meta), nil
+	}
+}
+
+func intOrNil(n *int) interface{} {
+	if n == nil {
+		return nil
+	}
+	return *n
+}
+
+// page is the value of a list field: one page of items and its pagination.
+type page struct {
+	items interface{}
+	meta  common.PaginationMeta
+}
+
+func pageType(name string, item *gql.Object) *gql.Object {
+	return gql.NewObject(gql.ObjectConfig{
+		Name: name,
+		Fields: gql.Fields{
+			"items": {Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(item))),
+				Resolve: func(p gql.ResolveParams) (interface{}, error) { return p.Source.(*page).items, nil }},
+			"pageInfo": {Type: gql.NewNonNull(pageInfo),
+				Resolve: func(p gql.ResolveParams) (interface{}, error) { return p.Source, nil }},
+		},
+	})
+}
+
+// pageArgs returns the pagination arguments of list fields, added to args.
+func pageArgs(args gql.FieldConfigArgument) gql.FieldConfigArgument {
+	args["first"] = &gql.ArgumentConfig{Type: gql.Int, Description: "Page size; the server's default page size when omitted."}
+	args["page"] = &gql.ArgumentConfig{Type: gql.Int, Description: "Page number, from 1. Ignored with after."}
+	args["after"] = &gql.ArgumentConfig{Type: gql.String, Description: "The nextCursor of the previous page."}
+	args["includeTotal"] = &gql.ArgumentConfig{Type: gql.Boolean, DefaultValue: true, Description: "Set to false to skip counting total and totalPages."}
+	return args
+}
+
+// pageSize is the page size a list field asks for.
+func pageSize(args map[string]interface{}) int {
+	if first, ok := args["first"].(int); ok {
+		return first
+	}
+	return common.DefaultPageSize()
+}
+
+// listComplexity weighs a list field as its selections times the number of
+// items it may return.
+func listComplexity(childComplexity int, args map[string]interface{}) int {
+	return 1 + childComplexity*max(pageSize(args), 1)
+}
+
+// baseQuery reads the pagination arguments. The cursor is decoded but its
+// sort is left to the caller to check.
+func baseQuery(args map[string]interface{}) (common.BaseQuery, error) {
+	query := common.BaseQuery{Page: 1, PageSize: pageSize(args), SkipTotal: args["includeTotal"] == false}
+	if query.PageSize < 1 {
+		return query, badInput("first must be at least 1")
+	}
+	if p, ok := args["page"].(int); ok {
+		if p < 1 {
+			return query, badInput("page must be at least 1")
+		}
+		query.Page = p
+	}
+	if after, ok := args["after"].(string); ok {
+		cursor, err := common.DecodeCursor(after)
+		if err != nil {
+			return query, badInput("invalid cursor")
+		}
+		query.Cursor = cursor
+	}
+	return query, nil
+}
+
+// checkCursor rejects a cursor on a sort without one, or from a list sorted
+// differently.
+func checkCursor(query common.BaseQuery, supported bool, sortBy, sortOrder string) error {
+	switch {
+	case query.Cursor == nil:
+		return nil
+	case !supported:
+		return badInput("cursor pagination is not supported with sortBy: RELEVANCE")
+	case query.Cursor.SortBy != sortBy || query.Cursor.SortOrder != sortOrder:
+		return badInput("cursor does not match sortBy and sortOrder")
+	}
+	return nil
+}
+
+// lastItem is the ID and sort key of the final item of a page, for the
+// cursor of the next one.
+type lastItem struct {
+	id  uint
+	key *string
+}
+
+func newPage(items interface{}, query common.BaseQuery, total int, sortBy, sortOrder string, last *lastItem) *page {
+	var cursor *common.Cursor
+	if last != nil {
+		cursor = &common.Cursor{SortBy: sortBy, SortOrder: sortOrder, Value: last.key, ID: last.id}
+	}
+	return &page{items: items, meta: common.NewPaginationMeta(query, total, cursor)}
+}
+
+func parseID(args map[string]interface{}, name string) (uint, error) {
+	id, err := strconv.ParseUint(args[name].(string), 10, 32)
+	if err != nil {
+		return 0, badInput("invalid " + name)
+	}
+	return uint(id), nil
+}
+
+// optionalID reads a nullable ID from an input object.
+func optionalID(input map[string]interface{}, name string) (*uint, error) {
+	if input[name] == nil {
+		return nil, nil
+	}
+	id, err := parseID(input, name)
+	return &id, err
+}
+
+func optionalString(input map[string]interface{}, name string) *string {
+	if s, ok := input[name].(string); ok {
+		return &s
+	}
+	return nil
+}
+
+func badInput(message string) error {
+	return graphql.NewError(graphql.CodeBadUserInput, message)
+}
+
+// serviceError reports a failed service call: NOT_FOUND for missing
+// records, otherwise the action that failed and why.
+func serviceError(err error, notFound, action string) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return graphql.NewError(graphql.CodeNotFound, notFound)
+	}
+	return graphql.NewError(graphql.CodeInternal, action+": "+err.Error())
+}
+
+// optional returns nil for records that do not exist, which nullable
+// lookups report as null rather than an error.
+func optional[T any](value *T, err error, notFound, action string) (interface{}, error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, serviceError(err, notFound, action)
+	}
+	return value, nil
+}
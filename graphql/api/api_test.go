@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/ltphat2204/domain-driven-golang/config"
+	"github.com/ltphat2204/domain-driven-golang/graphql"
+	categoryApplication "github.com/ltphat2204/domain-driven-golang/modules/category/application"
+	categoryDomain "github.com/ltphat2204/domain-driven-golang/modules/category/domain"
+	categoryInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/category/infrastructure"
+	taskApplication "github.com/ltphat2204/domain-driven-golang/modules/task/application"
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	taskInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/task/infrastructure"
+)
+
+// countingTasks counts list queries and drops the preloaded categories, so
+// Task.category has to go through the loader.
+type countingTasks struct {
+	taskApplication.TaskService
+	lists int
+}
+
+func (s *countingTasks) GetTasks(ctx context.Context, query *taskDomain.TaskQuery) ([]*taskDomain.Task, int, error) {
+	s.lists++
+	tasks, total, err := s.TaskService.GetTasks(ctx, query)
+	for _, task := range tasks {
+		task.Category = nil
+	}
+	return tasks, total, err
+}
+
+type countingCategories struct {
+	categoryApplication.CategoryService
+	lookups int
+}
+
+func (s *countingCategories) GetCategoryByID(ctx context.Context, id uint) (*categoryDomain.Category, error) {
+	s.lookups++
+	return s.CategoryService.GetCategoryByID(ctx, id)
+}
+
+func (s *countingCategories) GetCategoriesByIDs(ctx context.Context, ids []uint) ([]*categoryDomain.Category, error) {
+	s.lookups++
+	return s.CategoryService.GetCategoriesByIDs(ctx, ids)
+}
+
+func TestRelationsAreBatched(t *testing.T) {
+	ctx := context.Background()
+	categoryRepo := categoryInfrastructure.NewMemoryCategoryRepository()
+	categories := &countingCategories{CategoryService: categoryApplication.NewCategoryService(categoryRepo, config.ColorPalette)}
+	tasks := &countingTasks{TaskService: taskApplication.NewTaskService(taskInfrastructure.NewMemoryTaskRepository(categoryRepo))}
+	for i := 1; i <= 3; i++ {
+		if _, err := categories.CreateCategory(ctx, fmt.Sprintf("Category %d", i), ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 100; i++ {
+		categoryID := uint(i%3 + 1)
+		if _, err := tasks.CreateTask(ctx, fmt.Sprintf("Task %d", i), "", nil, &categoryID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	a, err := New(tasks, categories)
+	if err != nil {
+		t.Fatal(err)
+	}
+	run := func(query string) map[string]interface{} {
+		t.Helper()
+		tasks.lists, categories.lookups = 0, 0
+		resp := a.Schema().Execute(a.withLoaders(ctx), graphql.Request{Query: query}, graphql.Limits{})
+		if len(resp.Errors) > 0 {
+			t.Fatalf("%s: %v", query, resp.Errors[0])
+		}
+		var data map[string]interface{}
+		if err := json.Unmarshal(resp.Data, &data); err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	data := run(`{ tasks(first: 100) { items { title category { name } } } }`)
+	items := data["tasks"].(map[string]interface{})["items"].([]interface{})
+	if len(items) != 100 {
+		t.Fatalf("got %d tasks, want 100", len(items))
+	}
+	for _, item := range items {
+		if item.(map[string]interface{})["category"] == nil {
+			t.Fatalf("task without its category: %v", item)
+		}
+	}
+	if tasks.lists != 1 || categories.lookups != 1 {
+		t.Errorf("listing 100 tasks with their category ran %d task and %d category queries, want 1 and 1", tasks.lists, categories.lookups)
+	}
+
+	data = run(`{ categories { items { name tasks(first: 5, sortBy: TITLE, sortOrder: ASC) { title category { name } } done: tasks(status: DONE) { title } } } }`)
+	for _, item := range data["categories"].(map[string]interface{})["items"].([]interface{}) {
+		category := item.(map[string]interface{})
+		categoryTasks := category["tasks"].([]interface{})
+		if len(categoryTasks) != 5 {
+			t.Errorf("%s has %d tasks, want 5", category["name"], len(categoryTasks))
+		}
+		for _, task := range categoryTasks {
+			if name := task.(map[string]interface{})["category"].(map[string]interface{})["name"]; name != category["name"] {
+				t.Errorf("task of %s is in %s", category["name"], name)
+			}
+		}
+		if done := category["done"].([]interface{}); len(done) != 0 {
+			t.Errorf("%s has %d done tasks, want 0", category["name"], len(done))
+		}
+	}
+	// One query per distinct tasks(...) selection; the categories listed
+	// were primed into the loader, so task.category needs no lookup.
+	if tasks.lists != 2 || categories.lookups != 0 {
+		t.Errorf("listing categories with their tasks ran %d task and %d category queries, want 2 and 0", tasks.lists, categories.lookups)
+	}
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"errors"
+
+	gql "github.com/graphql-go/graphql"
+	"gorm.io/gorm"
+
+	"github.com/ltphat2204/domain-driven-golang/graphql"
+	"github.com/ltphat2204/domain-driven-golang/modules/category/domain"
+)
+
+var categorySortField = gql.NewEnum(gql.EnumConfig{
+	Name: "CategorySortField",
+	Values: gql.EnumValueConfigMap{
+		"NAME":       {Value: "name"},
+		"CREATED_AT": {Value: "created_at"},
+		"RELEVANCE":  {Value: domain.SortByRelevance, Description: "Best match first; requires search."},
+	},
+})
+
+var createCategoryInput = gql.NewInputObject(gql.InputObjectConfig{
+	Name: "CreateCategoryInput",
+	Fields: gql.InputObjectConfigFieldMap{
+		"name":        {Type: gql.NewNonNull(gql.String)},
+		"description": {Type: gql.String},
+	},
+})
+
+var updateCategoryInput = gql.NewInputObject(gql.InputObjectConfig{
+	Name:        "UpdateCategoryInput",
+	Description: "Fields left out keep their value. color must be one of the server's palette.",
+	Fields: gql.InputObjectConfigFieldMap{
+		"name":        {Type: gql.String},
+		"description": {Type: gql.String},
+		"color":       {Type: gql.String},
+	},
+})
+
+func (a *API) categoryType() *gql.Object {
+	return gql.NewObject(gql.ObjectConfig{
+		Name: "Category",
+		Fields: gql.Fields{
+			"id":          {Type: gql.NewNonNull(gql.ID), Resolve: categoryField(func(c *domain.Category) interface{} { return c.ID })},
+			"name":        {Type: gql.NewNonNull(gql.String), Resolve: categoryField(func(c *domain.Category) interface{} { return c.Name })},
+			"description": {Type: gql.NewNonNull(gql.String), Resolve: categoryField(func(c *domain.Category) interface{} { return c.Description })},
+			"color":       {Type: gql.NewNonNull(gql.String), Resolve: categoryField(func(c *domain.Category) interface{} { return c.Color })},
+			"createdAt":   {Type: gql.NewNonNull(dateTime), Resolve: categoryField(func(c *domain.Category) interface{} { return c.CreatedAt })},
+			"searchRank": {Type: gql.Float, Description: "Set when the list was searched.", Resolve: categoryField(func(c *domain.Category) interface{} {
+				if c.SearchHighlight == "" {
+					return nil
+				}
+				return c.SearchRank
+			})},
+			"searchHighlight": {Type: gql.String, Description: "Set when the list was searched.", Resolve: categoryField(func(c *domain.Category) interface{} {
+				if c.SearchHighlight == "" {
+					return nil
+				}
+				return c.SearchHighlight
+			})},
+		},
+	})
+}
+
+func categoryField(get func(*domain.Category) interface{}) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		return get(p.Source.(*domain.Category)), nil
+	}
+}
+
+// linkTypes adds the fields that lead from tasks to categories and back,
+// which need both types to exist.
+func (a *API) linkTypes(task, category *gql.Object) {
+	task.AddFieldConfig("category", &gql.Field{
+		Type:    category,
+		Resolve: a.taskCategory,
+	})
+	category.AddFieldConfig("tasks", &gql.Field{
+		Description: "The category's tasks, newest first unless sorted otherwise.",
+		Type:        gql.NewNonNull(gql.NewList(gql.NewNonNull(task))),
+		Args: taskFilterArgs(gql.FieldConfigArgument{
+			"first": {Type: gql.Int, Description: "The most tasks to return; the server's default page size when omitted."},
+		}),
+		Resolve: a.categoryTasks,
+	})
+}
+
+func (a *API) categoryQueries(category *gql.Object) gql.Fields {
+	return gql.Fields{
+		"category": {
+			Description: "The category with the given ID, or null if there is none.",
+			Type:        category,
+			Args:        gql.FieldConfigArgument{"id": {Type: gql.NewNonNull(gql.ID)}},
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				id, err := parseID(p.Args, "id")
+				if err != nil {
+					return nil, err
+				}
+				category, err := a.categories.GetCategoryByID(p.Context, id)
+				return optional(category, err, "category not found", "failed to get category")
+			},
+		},
+		"categories": {
+			Description: "A page of categories, newest first unless sorted otherwise.",
+			Type:        gql.NewNonNull(pageType("CategoryPage", category)),
+			Args: pageArgs(gql.FieldConfigArgument{
+				"search":    {Type: gql.String, Description: "Full-text search over name and description."},
+				"sortBy":    {Type: categorySortField},
+				"sortOrder": {Type: sortOrder, DefaultValue: "desc"},
+			}),
+			Resolve: a.listCategories,
+		},
+	}
+}
+
+func (a *API) listCategories(p gql.ResolveParams) (interface{}, error) {
+	base, err := baseQuery(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	query := &domain.CategoryQuery{BaseQuery: base}
+	query.Search, _ = p.Args["search"].(string)
+	query.SortBy, _ = p.Args["sortBy"].(string)
+	query.SortOrder, _ = p.Args["sortOrder"].(string)
+	if query.SortBy == domain.SortByRelevance && query.Search == "" {
+		return nil, badInput("sortBy: RELEVANCE requires search")
+	}
+	sortBy, sortOrder := query.EffectiveSort()
+	if err := checkCursor(query.BaseQuery, query.SupportsCursor(), sortBy, sortOrder); err != nil {
+		return nil, err
+	}
+
+	categories, total, err := a.categories.GetCategories(p.Context, query)
+	if err != nil {
+		return nil, serviceError(err, "category not found", "failed to list categories")
+	}
+	loader := loadersFrom(p.Context).categories
+	for _, category := range categories {
+		loader.Prime(category.ID, category)
+	}
+	var last *lastItem
+	if len(categories) > 0 && query.SupportsCursor() {
+		lastCategory := categories[len(categories)-1]
+		last = &lastItem{id: lastCategory.ID, key: lastCategory.SortKey(sortBy)}
+	}
+	return newPage(categories, query.BaseQuery, total, sortBy, sortOrder, last), nil
+}
+
+func (a *API) categoryMutations(category *gql.Object) gql.Fields {
+	return gql.Fields{
+		"createCategory": {
+			Description: "Creates a category with a color picked from the server's palette.",
+			Type:        gql.NewNonNull(category),
+			Args:        gql.FieldConfigArgument{"input": {Type: gql.NewNonNull(createCategoryInput)}},
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				input := p.Args["input"].(map[string]interface{})
+				description, _ := input["description"].(string)
+				category, err := a.categories.CreateCategory(p.Context, input["name"].(string), description)
+				if err != nil {
+					return nil, serviceError(err, "category not found", "failed to create category")
+				}
+				return category, nil
+			},
+		},
+		"updateCategory": {
+			Type: gql.NewNonNull(category),
+			Args: gql.FieldConfigArgument{
+				"id":    {Type: gql.NewNonNull(gql.ID)},
+				"input": {Type: gql.NewNonNull(updateCategoryInput)},
+			},
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				id, err := parseID(p.Args, "id")
+				if err != nil {
+					return nil, err
+				}
+				input := p.Args["input"].(map[string]interface{})
+				category, err := a.categories.UpdateCategory(p.Context, id, optionalString(input, "name"), optionalString(input, "description"), optionalString(input, "color"))
+				if err != nil {
+					// Besides missing categories, updates fail on colors
+					// outside the palette, which is the client's to fix.
+					if errors.Is(err, gorm.ErrRecordNotFound) {
+						return nil, graphql.NewError(graphql.CodeNotFound, "category not found")
+					}
+					return nil, badInput("failed to update category: " + err.Error())
+				}
+				return category, nil
+			},
+		},
+		"deleteCategory": {
+			Description: "Deletes a category and returns its ID.",
+			Type:        gql.NewNonNull(gql.ID),
+			Args:        gql.FieldConfigArgument{"id": {Type: gql.NewNonNull(gql.ID)}},
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				id, err := parseID(p.Args, "id")
+				if err != nil {
+					return nil, err
+				}
+				if err := a.categories.DeleteCategory(p.Context, id); err != nil {
+					return nil, serviceError(err, "category not found", "failed to delete category")
+				}
+				return id, nil
+			},
+		},
+	}
+}
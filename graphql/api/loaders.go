@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ltphat2204/domain-driven-golang/dataloader"
+	categoryDomain "github.com/ltphat2204/domain-driven-golang/modules/category/domain"
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+)
+
+// loaders batch the lookups of one request. Category tasks get a loader per
+// distinct set of arguments, since those change the query.
+type loaders struct {
+	categories *dataloader.Loader[uint, *categoryDomain.Category]
+
+	mu            sync.Mutex
+	categoryTasks map[string]*dataloader.Loader[uint, []*taskDomain.Task]
+}
+
+type loadersKey struct{}
+
+func (a *API) withLoaders(ctx context.Context) context.Context {
+	l := &loaders{
+		categories: dataloader.New(func(ctx context.Context, ids []uint) (map[uint]*categoryDomain.Category, error) {
+			categories, err := a.categories.GetCategoriesByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[uint]*categoryDomain.Category, len(categories))
+			for _, category := range categories {
+				byID[category.ID] = category
+			}
+			return byID, nil
+		}),
+		categoryTasks: make(map[string]*dataloader.Loader[uint, []*taskDomain.Task]),
+	}
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	l, ok := ctx.Value(loadersKey{}).(*loaders)
+	if !ok {
+		panic("graphql api: request context has no loaders; serve it with API.Handler")
+	}
+	return l
+}
+
+// tasksOf returns the loader of the tasks matching query, keyed by category.
+// Every category's tasks come from one query filtered on all the categories
+// requested, which returns at most limit of each. args are the field's
+// arguments, which identify the query.
+func (a *API) tasksOf(ctx context.Context, args map[string]interface{}, query taskDomain.TaskQuery, limit int) *dataloader.Loader[uint, []*taskDomain.Task] {
+	l := loadersFrom(ctx)
+	key := fmt.Sprint(args)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if loader, ok := l.categoryTasks[key]; ok {
+		return loader
+	}
+	loader := dataloader.New(func(ctx context.Context, categoryIDs []uint) (map[uint][]*taskDomain.Task, error) {
+		ids := make([]interface{}, len(categoryIDs))
+		for i, id := range categoryIDs {
+			ids[i] = id
+		}
+		byCategory := taskDomain.FilterCondition{Field: taskDomain.FilterFieldCategoryID, Op: taskDomain.FilterOpIn, Values: ids}
+		batch := query
+		batch.Filter = byCategory
+		if query.Filter != nil {
+			batch.Filter = taskDomain.FilterAnd{Exprs: []taskDomain.FilterExpr{query.Filter, byCategory}}
+		}
+		batch.SkipTotal = true
+		batch.PerCategory = limit
+
+		tasks, _, err := a.tasks.GetTasks(ctx, &batch)
+		if err != nil {
+			return nil, err
+		}
+		grouped := make(map[uint][]*taskDomain.Task, len(categoryIDs))
+		for _, task := range tasks {
+			grouped[*task.CategoryID] = append(grouped[*task.CategoryID], task)
+		}
+		return grouped, nil
+	})
+	l.categoryTasks[key] = loader
+	return loader
+}
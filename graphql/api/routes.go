@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ltphat2204/domain-driven-golang/graphql"
+	"github.com/ltphat2204/domain-driven-golang/openapi"
+)
+
+// The types below document the HTTP protocol in the OpenAPI document; the
+// handler itself uses graphql.Request and graphql.Response.
+
+type graphQLQuery struct {
+	Query         string `form:"query" binding:"required"`
+	OperationName string `form:"operationName"`
+	// Variables is a JSON object.
+	Variables string `form:"variables"`
+}
+
+type graphQLRequest struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type graphQLResponse struct {
+	Data   map[string]interface{} `json:"data,omitempty"`
+	Errors []graphQLError         `json:"errors,omitempty"`
+}
+
+type graphQLError struct {
+	Message    string                 `json:"message"`
+	Locations  []graphql.Location     `json:"locations,omitempty"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+func (a *API) SetupRoutes(r *gin.Engine, limits graphql.Limits) {
+	handler := a.Handler(limits)
+	r.POST("/graphql", handler)
+	r.GET("/graphql", handler)
+	r.GET("/graphql/schema.graphql", a.schema.SDLHandler())
+}
+
+// Operations documents the routes SetupRoutes registers. GraphQL answers
+// with its own body rather than the envelope, 400 for requests that fail
+// validation and 200 once the operation runs, even if some fields failed.
+func Operations() []openapi.Operation {
+	tags := []string{"graphql"}
+	statuses := []int{http.StatusOK, http.StatusBadRequest}
+	return []openapi.Operation{
+		{Method: "POST", Path: "/graphql", ID: "graphql", Summary: "Run a GraphQL query or mutation", Tags: tags,
+			Body: graphQLRequest{}, Response: graphQLResponse{}, Raw: true, Statuses: statuses},
+		{Method: "GET", Path: "/graphql", ID: "graphqlGet", Summary: "Run a GraphQL query", Tags: tags,
+			Query: graphQLQuery{}, Response: graphQLResponse{}, Raw: true,
+			Statuses: append(statuses, http.StatusMethodNotAllowed)},
+		{Method: "GET", Path: "/graphql/schema.graphql", ID: "graphqlSchema", Summary: "The GraphQL schema in SDL", Tags: tags,
+			ContentType: "text/plain"},
+	}
+}
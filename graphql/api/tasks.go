@@ -0,0 +1,313 @@
+package api
+
+import (
+	"time"
+
+	gql "github.com/graphql-go/graphql"
+
+	categoryDomain "github.com/ltphat2204/domain-driven-golang/modules/category/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+)
+
+var taskStatus = gql.NewEnum(gql.EnumConfig{
+	Name: "TaskStatus",
+	Values: gql.EnumValueConfigMap{
+		"PENDING": {Value: domain.StatusPending},
+		"DOING":   {Value: domain.StatusDoing},
+		"DONE":    {Value: domain.StatusDone},
+	},
+})
+
+var taskSortField = gql.NewEnum(gql.EnumConfig{
+	Name: "TaskSortField",
+	Values: gql.EnumValueConfigMap{
+		"TITLE":      {Value: "title"},
+		"DUE_AT":     {Value: "due_at"},
+		"CREATED_AT": {Value: "created_at"},
+		"RELEVANCE":  {Value: domain.SortByRelevance, Description: "Best match first; requires search."},
+	},
+})
+
+var createTaskInput = gql.NewInputObject(gql.InputObjectConfig{
+	Name: "CreateTaskInput",
+	Fields: gql.InputObjectConfigFieldMap{
+		"title":       {Type: gql.NewNonNull(gql.String)},
+		"description": {Type: gql.String},
+		"dueAt":       {Type: dateTime},
+		"categoryId":  {Type: gql.ID},
+	},
+})
+
+// updateTaskInput takes removeCategory rather than categoryId: null, since
+// graphql-go does not parse null.
+var updateTaskInput = gql.NewInputObject(gql.InputObjectConfig{
+	Name:        "UpdateTaskInput",
+	Description: "Fields left out keep their value.",
+	Fields: gql.InputObjectConfigFieldMap{
+		"title":          {Type: gql.String},
+		"description":    {Type: gql.String},
+		"status":         {Type: taskStatus},
+		"dueAt":          {Type: dateTime},
+		"categoryId":     {Type: gql.ID},
+		"removeCategory": {Type: gql.Boolean, Description: "Set to true to take the task out of its category."},
+	},
+})
+
+// taskFilterArgs returns the arguments that narrow a list of tasks, added
+// to args.
+func taskFilterArgs(args gql.FieldConfigArgument) gql.FieldConfigArgument {
+	args["status"] = &gql.ArgumentConfig{Type: taskStatus}
+	args["filter"] = &gql.ArgumentConfig{Type: gql.String, Description: "A filter expression, as the filter parameter of GET /tasks."}
+	args["sortBy"] = &gql.ArgumentConfig{Type: taskSortField}
+	args["sortOrder"] = &gql.ArgumentConfig{Type: sortOrder, DefaultValue: "desc"}
+	return args
+}
+
+func (a *API) taskType() *gql.Object {
+	return gql.NewObject(gql.ObjectConfig{
+		Name: "Task",
+		Fields: gql.Fields{
+			"id":          {Type: gql.NewNonNull(gql.ID), Resolve: taskField(func(t *domain.Task) interface{} { return t.ID })},
+			"title":       {Type: gql.NewNonNull(gql.String), Resolve: taskField(func(t *domain.Task) interface{} { return t.Title })},
+			"description": {Type: gql.NewNonNull(gql.String), Resolve: taskField(func(t *domain.Task) interface{} { return t.Description })},
+			"status":      {Type: gql.NewNonNull(taskStatus), Resolve: taskField(func(t *domain.Task) interface{} { return t.Status })},
+			"createdAt":   {Type: gql.NewNonNull(dateTime), Resolve: taskField(func(t *domain.Task) interface{} { return t.CreatedAt })},
+			"updatedAt":   {Type: gql.NewNonNull(dateTime), Resolve: taskField(func(t *domain.Task) interface{} { return t.UpdatedAt })},
+			"dueAt":       {Type: dateTime, Resolve: taskField(func(t *domain.Task) interface{} { return t.DueAt })},
+			"categoryId": {Type: gql.ID, Resolve: taskField(func(t *domain.Task) interface{} {
+				if t.CategoryID == nil {
+					return nil
+				}
+				return *t.CategoryID
+			})},
+			"searchRank": {Type: gql.Float, Description: "Set when the list was searched.", Resolve: taskField(func(t *domain.Task) interface{} {
+				if t.SearchHighlight == "" {
+					return nil
+				}
+				return t.SearchRank
+			})},
+			"searchHighlight": {Type: gql.String, Description: "Set when the list was searched.", Resolve: taskField(func(t *domain.Task) interface{} {
+				if t.SearchHighlight == "" {
+					return nil
+				}
+				return t.SearchHighlight
+			})},
+		},
+	})
+}
+
+func taskField(get func(*domain.Task) interface{}) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		return get(p.Source.(*domain.Task)), nil
+	}
+}
+
+// taskCategory resolves Task.category, from the task when the category was
+// loaded with it and through the request's category loader otherwise.
+func (a *API) taskCategory(p gql.ResolveParams) (interface{}, error) {
+	task := p.Source.(*domain.Task)
+	if task.Category != nil || task.CategoryID == nil {
+		return task.Category, nil
+	}
+	load := loadersFrom(p.Context).categories.Load(p.Context, *task.CategoryID)
+	return func() (interface{}, error) {
+		category, err := load()
+		if err != nil {
+			return nil, serviceError(err, "category not found", "failed to load category")
+		}
+		return category, nil
+	}, nil
+}
+
+func (a *API) taskQueries(task *gql.Object) gql.Fields {
+	return gql.Fields{
+		"task": {
+			Description: "The task with the given ID, or null if there is none.",
+			Type:        task,
+			Args:        gql.FieldConfigArgument{"id": {Type: gql.NewNonNull(gql.ID)}},
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				id, err := parseID(p.Args, "id")
+				if err != nil {
+					return nil, err
+				}
+				task, err := a.tasks.GetTaskByID(p.Context, id)
+				return optional(task, err, "task not found", "failed to get task")
+			},
+		},
+		"tasks": {
+			Description: "A page of tasks, newest first unless sorted otherwise.",
+			Type:        gql.NewNonNull(pageType("TaskPage", task)),
+			Args: pageArgs(taskFilterArgs(gql.FieldConfigArgument{
+				"search": {Type: gql.String, Description: "Full-text search over title and description."},
+			})),
+			Resolve: a.listTasks,
+		},
+	}
+}
+
+func (a *API) listTasks(p gql.ResolveParams) (interface{}, error) {
+	base, err := baseQuery(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	query, err := taskQuery(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	query.BaseQuery = base
+	if search, ok := p.Args["search"].(string); ok {
+		query.Search = search
+	}
+	if query.SortBy == domain.SortByRelevance && query.Search == "" {
+		return nil, badInput("sortBy: RELEVANCE requires search")
+	}
+	sortBy, sortOrder := query.EffectiveSort()
+	if err := checkCursor(query.BaseQuery, query.SupportsCursor(), sortBy, sortOrder); err != nil {
+		return nil, err
+	}
+
+	tasks, total, err := a.tasks.GetTasks(p.Context, query)
+	if err != nil {
+		return nil, serviceError(err, "task not found", "failed to list tasks")
+	}
+	var last *lastItem
+	if len(tasks) > 0 && query.SupportsCursor() {
+		lastTask := tasks[len(tasks)-1]
+		last = &lastItem{id: lastTask.ID, key: lastTask.SortKey(sortBy)}
+	}
+	return newPage(tasks, query.BaseQuery, total, sortBy, sortOrder, last), nil
+}
+
+// taskQuery reads the filter and sort arguments of a list of tasks.
+func taskQuery(args map[string]interface{}) (*domain.TaskQuery, error) {
+	query := &domain.TaskQuery{}
+	if status, ok := args["status"].(domain.TaskStatus); ok {
+		query.Status = &status
+	}
+	if filter, ok := args["filter"].(string); ok && filter != "" {
+		expr, err := domain.ParseFilter(filter, time.Now())
+		if err != nil {
+			return nil, badInput(err.Error())
+		}
+		query.Filter = expr
+	}
+	query.SortBy, _ = args["sortBy"].(string)
+	query.SortOrder, _ = args["sortOrder"].(string)
+	return query, nil
+}
+
+// categoryTasks resolves Category.tasks. The tasks of every category in the
+// response come from one query per distinct set of arguments.
+func (a *API) categoryTasks(p gql.ResolveParams) (interface{}, error) {
+	query, err := taskQuery(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	if query.SortBy == domain.SortByRelevance {
+		return nil, badInput("sortBy: RELEVANCE requires search, which Category.tasks does not take")
+	}
+	limit := pageSize(p.Args)
+	if limit < 1 {
+		return nil, badInput("first must be at least 1")
+	}
+
+	category := p.Source.(*categoryDomain.Category)
+	load := a.tasksOf(p.Context, p.Args, *query, limit).Load(p.Context, category.ID)
+	return func() (interface{}, error) {
+		tasks, err := load()
+		if err != nil {
+			return nil, serviceError(err, "task not found", "failed to list tasks")
+		}
+		if tasks == nil {
+			tasks = []*domain.Task{}
+		}
+		return tasks, nil
+	}, nil
+}
+
+func (a *API) taskMutations(task *gql.Object) gql.Fields {
+	return gql.Fields{
+		"createTask": {
+			Type: gql.NewNonNull(task),
+			Args: gql.FieldConfigArgument{"input": {Type: gql.NewNonNull(createTaskInput)}},
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				input := p.Args["input"].(map[string]interface{})
+				categoryID, err := optionalID(input, "categoryId")
+				if err != nil {
+					return nil, err
+				}
+				description, _ := input["description"].(string)
+				task, err := a.tasks.CreateTask(p.Context, input["title"].(string), description, optionalTime(input, "dueAt"), categoryID)
+				if err != nil {
+					return nil, serviceError(err, "task not found", "failed to create task")
+				}
+				return task, nil
+			},
+		},
+		"updateTask": {
+			Type: gql.NewNonNull(task),
+			Args: gql.FieldConfigArgument{
+				"id":    {Type: gql.NewNonNull(gql.ID)},
+				"input": {Type: gql.NewNonNull(updateTaskInput)},
+			},
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				id, err := parseID(p.Args, "id")
+				if err != nil {
+					return nil, err
+				}
+				input := p.Args["input"].(map[string]interface{})
+				var status *domain.TaskStatus
+				if s, ok := input["status"].(domain.TaskStatus); ok {
+					status = &s
+				}
+				// The service sets the category to whatever it is given, so an
+				// omitted categoryId has to pass on the current one.
+				var categoryID *uint
+				_, given := input["categoryId"]
+				switch {
+				case given && input["removeCategory"] == true:
+					return nil, badInput("set categoryId or removeCategory, not both")
+				case input["removeCategory"] == true:
+				case given:
+					if categoryID, err = optionalID(input, "categoryId"); err != nil {
+						return nil, err
+					}
+				default:
+					current, err := a.tasks.GetTaskByID(p.Context, id)
+					if err != nil {
+						return nil, serviceError(err, "task not found", "failed to update task")
+					}
+					categoryID = current.CategoryID
+				}
+
+				task, err := a.tasks.UpdateTask(p.Context, id, optionalString(input, "title"), optionalString(input, "description"), status, optionalTime(input, "dueAt"), categoryID)
+				if err != nil {
+					return nil, serviceError(err, "task not found", "failed to update task")
+				}
+				return task, nil
+			},
+		},
+		"deleteTask": {
+			Description: "Deletes a task and returns its ID.",
+			Type:        gql.NewNonNull(gql.ID),
+			Args:        gql.FieldConfigArgument{"id": {Type: gql.NewNonNull(gql.ID)}},
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				id, err := parseID(p.Args, "id")
+				if err != nil {
+					return nil, err
+				}
+				if err := a.tasks.DeleteTask(p.Context, id); err != nil {
+					return nil, serviceError(err, "task not found", "failed to delete task")
+				}
+				return id, nil
+			},
+		},
+	}
+}
+
+func optionalTime(input map[string]interface{}, name string) *time.Time {
+	if t, ok := input[name].(time.Time); ok {
+		return &t
+	}
+	return nil
+}
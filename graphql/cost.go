@@ -0,0 +1,138 @@
+package graphql
+
+import (
+	"strings"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// maxIntrospectionLists is how deeply the list fields of __Type that lead
+// to more types may nest, as in graphql-js. Introspection does not count
+// towards Limits, but fields { type { fields { ... } } } grows with every
+// level.
+const maxIntrospectionLists = 3
+
+var typeListFields = map[string]bool{"fields": true, "inputFields": true, "interfaces": true, "possibleTypes": true}
+
+// cost measures a validated operation for Limits.
+type cost struct {
+	schema    *Schema
+	fragments map[string]*ast.FragmentDefinition
+	vars      map[string]interface{}
+	// defaults are the default values of the variables the request left out.
+	defaults  map[string]ast.Value
+	typeLists int // list fields of __Type being expanded
+	err       *Error
+}
+
+func newCost(s *Schema, doc *ast.Document, op *ast.OperationDefinition, vars map[string]interface{}) *cost {
+	c := &cost{schema: s, fragments: make(map[string]*ast.FragmentDefinition), vars: vars, defaults: make(map[string]ast.Value)}
+	for _, def := range doc.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok {
+			c.fragments[fragment.Name.Value] = fragment
+		}
+	}
+	for _, def := range op.VariableDefinitions {
+		if _, given := vars[def.Variable.Name.Value]; !given && def.DefaultValue != nil {
+			c.defaults[def.Variable.Name.Value] = def.DefaultValue
+		}
+	}
+	return c
+}
+
+// selectionSet returns the complexity and depth of set on obj, expanding
+// fragments. Validation has ruled out cycles and fragments on other types.
+func (c *cost) selectionSet(obj *gql.Object, set *ast.SelectionSet) (complexity, depth int) {
+	if set == nil {
+		return 0, 0
+	}
+	for _, selection := range set.Selections {
+		var fieldComplexity, fieldDepth int
+		switch sel := selection.(type) {
+		case *ast.Field:
+			fieldComplexity, fieldDepth = c.field(obj, sel)
+		case *ast.FragmentSpread:
+			fieldComplexity, fieldDepth = c.selectionSet(obj, c.fragments[sel.Name.Value].SelectionSet)
+		case *ast.InlineFragment:
+			fieldComplexity, fieldDepth = c.selectionSet(obj, sel.SelectionSet)
+		}
+		complexity += fieldComplexity
+		depth = max(depth, fieldDepth)
+	}
+	return complexity, depth
+}
+
+func (c *cost) field(obj *gql.Object, field *ast.Field) (complexity, depth int) {
+	def := gql.DefaultTypeInfoFieldDef(&c.schema.schema, obj, field)
+	if def == nil {
+		return 0, 1
+	}
+	if obj == gql.TypeType && typeListFields[def.Name] {
+		c.typeLists++
+		defer func() { c.typeLists-- }()
+		if c.typeLists == maxIntrospectionLists && c.err == nil {
+			c.err = errorAt(field.Loc, CodeValidationFailed, "Maximum introspection depth exceeded.")
+		}
+	}
+
+	var childComplexity, childDepth int
+	if child, ok := gql.GetNamed(def.Type).(*gql.Object); ok {
+		childComplexity, childDepth = c.selectionSet(child, field.SelectionSet)
+	}
+
+	if strings.HasPrefix(obj.Name(), "__") || strings.HasPrefix(def.Name, "__") {
+		// Introspection reads the schema, not the data, and GraphiQL's query
+		// alone nests deeper than most limits allow.
+		return 0, 1
+	}
+	complexity = 1 + childComplexity
+	if weigh, ok := c.schema.complexity[obj.Name()+"."+def.Name]; ok {
+		complexity = weigh(childComplexity, c.arguments(def, field))
+	}
+	return complexity, 1 + childDepth
+}
+
+// arguments returns the scalar and enum arguments of field as its resolver
+// gets them, which is all complexity functions need.
+func (c *cost) arguments(def *gql.FieldDefinition, field *ast.Field) map[string]interface{} {
+	args := make(map[string]interface{})
+	for _, arg := range def.Args {
+		if arg.DefaultValue != nil {
+			args[arg.Name()] = arg.DefaultValue
+		}
+		for _, given := range field.Arguments {
+			if given.Name.Value != arg.Name() {
+				continue
+			}
+			if value := c.value(arg.Type, given.Value); value != nil {
+				args[arg.Name()] = value
+			}
+		}
+	}
+	return args
+}
+
+func (c *cost) value(t gql.Input, literal ast.Value) interface{} {
+	if variable, ok := literal.(*ast.Variable); ok {
+		if value, given := c.vars[variable.Name.Value]; given {
+			switch named := gql.GetNamed(t).(type) {
+			case *gql.Scalar:
+				return named.ParseValue(value)
+			case *gql.Enum:
+				return named.ParseValue(value)
+			}
+			return nil
+		}
+		if literal = c.defaults[variable.Name.Value]; literal == nil {
+			return nil
+		}
+	}
+	switch named := gql.GetNamed(t).(type) {
+	case *gql.Scalar:
+		return named.ParseLiteral(literal)
+	case *gql.Enum:
+		return named.ParseLiteral(literal)
+	}
+	return nil
+}
@@ -0,0 +1,308 @@
+// Package graphql serves a schema built with graphql-go: it checks
+// operations against limits on their depth and complexity before they run,
+// serves them over HTTP and publishes the schema as SDL.
+//
+// Resolvers may return a func() (interface{}, error) to get their value
+// later. graphql-go calls those a level at a time, after every field of the
+// level has resolved, so a dataloader that loads on the first call sees all
+// of the level's keys at once.
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// Schema is a graphql-go schema with the complexity of its fields.
+type Schema struct {
+	schema     gql.Schema
+	complexity Complexity
+}
+
+// Complexity holds, by "Type.field", the cost of fields given the cost of
+// their selections. Fields left out cost 1 plus that; list fields usually
+// multiply it by the page size.
+type Complexity map[string]func(childComplexity int, args map[string]interface{}) int
+
+// NewSchema builds the schema of config. A resolver that panics is logged
+// and its field reported as an internal error.
+func NewSchema(config gql.SchemaConfig, complexity Complexity) (*Schema, error) {
+	schema, err := gql.NewSchema(config)
+	if err != nil {
+		return nil, err
+	}
+	for name, t := range schema.TypeMap() {
+		obj, ok := t.(*gql.Object)
+		if !ok || strings.HasPrefix(name, "__") {
+			continue
+		}
+		for _, field := range obj.Fields() {
+			if field.Resolve != nil {
+				field.Resolve = recoverResolve(field.Resolve)
+			}
+		}
+	}
+	return &Schema{schema: schema, complexity: complexity}, nil
+}
+
+func recoverResolve(resolve gql.FieldResolveFn) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (value interface{}, err error) {
+		defer recoverPanic(p.Context, &err)
+		value, err = resolve(p)
+		if later, ok := value.(func() (interface{}, error)); ok {
+			value = func() (value interface{}, err error) {
+				defer recoverPanic(p.Context, &err)
+				return later()
+			}
+		}
+		return value, err
+	}
+}
+
+func recoverPanic(ctx context.Context, err *error) {
+	if r := recover(); r != nil {
+		slog.ErrorContext(ctx, "panic resolving graphql field", "panic", fmt.Sprint(r), "stack", string(debug.Stack()))
+		*err = NewError(CodeInternal, "internal server error")
+	}
+}
+
+// Limits bound the cost of an operation; zero means no limit. Depth counts
+// nested fields. Complexity adds up the Complexity of every selected field,
+// so list fields weigh as much as the objects they may return.
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// Request is a GraphQL request as sent over HTTP.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Response is the result of executing a request. Data is absent when the
+// request was rejected before any field ran and null when a non-null root
+// field failed.
+type Response struct {
+	Data   json.RawMessage `json:"data,omitempty"`
+	Errors []*Error        `json:"errors,omitempty"`
+}
+
+// Location is a position in a document, counted from 1.
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Error is a GraphQL error as it appears in a response.
+type Error struct {
+	Message    string                 `json:"message"`
+	Locations  []Location             `json:"locations,omitempty"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// Error codes reported in extensions.code.
+const (
+	CodeParseFailed      = "GRAPHQL_PARSE_FAILED"
+	CodeValidationFailed = "GRAPHQL_VALIDATION_FAILED"
+	CodeTooComplex       = "QUERY_TOO_COMPLEX"
+	CodeBadUserInput     = "BAD_USER_INPUT"
+	CodeNotFound         = "NOT_FOUND"
+	CodeInternal         = "INTERNAL_SERVER_ERROR"
+)
+
+// NewError returns an error for a resolver to return, which clients see
+// with code as its extensions.code.
+func NewError(code, message string) error {
+	return &codedError{code: code, message: message}
+}
+
+type codedError struct{ code, message string }
+
+func (e *codedError) Error() string { return e.message }
+
+// Extensions is how graphql-go finds the code of an error.
+func (e *codedError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+func newError(code, message string) *Error {
+	return &Error{Message: message, Extensions: map[string]interface{}{"code": code}}
+}
+
+func errorAt(loc *ast.Location, code, format string, args ...interface{}) *Error {
+	err := newError(code, fmt.Sprintf(format, args...))
+	if loc != nil {
+		l := location.GetLocation(loc.Source, loc.Start)
+		err.Locations = []Location{{Line: l.Line, Column: l.Column}}
+	}
+	return err
+}
+
+// responseError converts an error reported by graphql-go. It keeps the code
+// of an error from NewError, and gets code otherwise; an empty code leaves
+// it without.
+func responseError(formatted gqlerrors.FormattedError, code string) *Error {
+	// Syntax errors go on to quote the document, which locations point into.
+	message, _, _ := strings.Cut(formatted.Message, "\n\n")
+	err := &Error{Message: message, Path: formatted.Path, Extensions: formatted.Extensions}
+	for _, l := range formatted.Locations {
+		err.Locations = append(err.Locations, Location{Line: l.Line, Column: l.Column})
+	}
+	if err.Extensions == nil {
+		if coded := codedCause(formatted); coded != nil {
+			err.Extensions = coded.Extensions()
+		} else if code != "" {
+			err.Extensions = map[string]interface{}{"code": code}
+		}
+	}
+	return err
+}
+
+// codedCause finds the error from NewError that err wraps. graphql-go
+// formats the errors of thunks twice, losing their extensions on the way.
+func codedCause(err error) *codedError {
+	for err != nil {
+		switch e := err.(type) {
+		case *codedError:
+			return e
+		case gqlerrors.FormattedError:
+			err = e.OriginalError()
+		case *gqlerrors.Error:
+			err = e.OriginalError
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func responseErrors(formatted []gqlerrors.FormattedError, code string) []*Error {
+	errs := make([]*Error, len(formatted))
+	for i, err := range formatted {
+		errs[i] = responseError(err, code)
+	}
+	return errs
+}
+
+// prepared is an operation that passed validation and is ready to run.
+type prepared struct {
+	doc *ast.Document
+	op  *ast.OperationDefinition
+}
+
+// Execute validates req against the schema and limits and runs it.
+func (s *Schema) Execute(ctx context.Context, req Request, limits Limits) *Response {
+	p, errs := s.prepare(req, limits)
+	if len(errs) > 0 {
+		return &Response{Errors: errs}
+	}
+	return s.execute(ctx, req, p)
+}
+
+// prepare parses and validates req. Variables must have been decoded as
+// encoding/json does by default, with numbers as float64.
+func (s *Schema) prepare(req Request, limits Limits) (*prepared, []*Error) {
+	doc, err := parser.Parse(parser.ParseParams{Source: req.Query})
+	if err != nil {
+		return nil, []*Error{responseError(gqlerrors.FormatError(err), CodeParseFailed)}
+	}
+	if result := gql.ValidateDocument(&s.schema, doc, nil); !result.IsValid {
+		return nil, responseErrors(result.Errors, CodeValidationFailed)
+	}
+	op, opErr := selectOperation(doc, req.OperationName)
+	if opErr != nil {
+		return nil, []*Error{opErr}
+	}
+
+	var root *gql.Object
+	switch op.Operation {
+	case ast.OperationTypeQuery:
+		root = s.schema.QueryType()
+	case ast.OperationTypeMutation:
+		root = s.schema.MutationType()
+	}
+	if root == nil {
+		return nil, []*Error{errorAt(op.Loc, CodeValidationFailed, "Schema is not configured for %ss.", op.Operation)}
+	}
+
+	c := newCost(s, doc, op, req.Variables)
+	complexity, depth := c.selectionSet(root, op.SelectionSet)
+	if c.err != nil {
+		return nil, []*Error{c.err}
+	}
+	if limits.MaxDepth > 0 && depth > limits.MaxDepth {
+		return nil, []*Error{errorAt(op.Loc, CodeTooComplex, "Query is nested %d levels deep, more than the maximum of %d.", depth, limits.MaxDepth)}
+	}
+	if limits.MaxComplexity > 0 && complexity > limits.MaxComplexity {
+		return nil, []*Error{errorAt(op.Loc, CodeTooComplex, "Query has complexity %d, more than the maximum of %d.", complexity, limits.MaxComplexity)}
+	}
+	return &prepared{doc: doc, op: op}, nil
+}
+
+func selectOperation(doc *ast.Document, name string) (*ast.OperationDefinition, *Error) {
+	var found *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if name == "" && found != nil {
+			return nil, newError(CodeValidationFailed, "Must provide operation name if query contains multiple operations.")
+		}
+		if name == "" || op.Name != nil && op.Name.Value == name {
+			found = op
+		}
+	}
+	switch {
+	case found != nil:
+		return found, nil
+	case name != "":
+		return nil, newError(CodeValidationFailed, fmt.Sprintf("Unknown operation named %q.", name))
+	}
+	return nil, newError(CodeValidationFailed, "Must provide an operation.")
+}
+
+func (s *Schema) execute(ctx context.Context, req Request, p *prepared) *Response {
+	result := gql.Execute(gql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           p.doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+
+	// Errors without a path come before any field ran: graphql-go checks
+	// variables only as it starts.
+	rejected := result.Data == nil && len(result.Errors) > 0
+	for _, err := range result.Errors {
+		rejected = rejected && len(err.Path) == 0
+	}
+	if rejected {
+		return &Response{Errors: responseErrors(result.Errors, CodeBadUserInput)}
+	}
+
+	data, err := json.Marshal(result.Data)
+	if err != nil {
+		return &Response{Errors: []*Error{newError(CodeInternal, "failed to encode the response")}}
+	}
+	var errs []*Error
+	if len(result.Errors) > 0 {
+		errs = responseErrors(result.Errors, "")
+	}
+	return &Response{Data: data, Errors: errs}
+}
@@ -0,0 +1,308 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	gql "github.com/graphql-go/graphql"
+
+	"github.com/ltphat2204/domain-driven-golang/dataloader"
+)
+
+func TestMain(m *testing.M) {
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	os.Exit(m.Run())
+}
+
+type character struct {
+	name    string
+	friends []string
+}
+
+var characters = map[string]*character{
+	"luke":  {name: "Luke", friends: []string{"han", "leia"}},
+	"han":   {name: "Han", friends: []string{"luke"}},
+	"leia":  {name: "Leia", friends: []string{"luke", "han"}},
+	"vader": {name: "Vader"},
+}
+
+// bestFriendKey identifies a bestFriend field by its path, so that every
+// level of a query loads its own keys.
+type bestFriendKey struct {
+	path   string
+	source *character
+}
+
+// testSchema has a field, bestFriend, resolved through a dataloader whose
+// batches it records so tests can check thunks are forced a level at a time.
+func testSchema(t *testing.T) (*Schema, *[]int) {
+	t.Helper()
+	var batches []int
+	bestFriends := dataloader.New(func(_ context.Context, keys []bestFriendKey) (map[bestFriendKey]*character, error) {
+		batches = append(batches, len(keys))
+		found := make(map[bestFriendKey]*character)
+		for _, key := range keys {
+			if len(key.source.friends) > 0 {
+				found[key] = characters[key.source.friends[0]]
+			}
+		}
+		return found, nil
+	})
+	mood := gql.NewEnum(gql.EnumConfig{Name: "Mood", Values: gql.EnumValueConfigMap{"HAPPY": {Value: 1}, "GRUMPY": {Value: 2}}})
+	char := gql.NewObject(gql.ObjectConfig{Name: "Character", Fields: gql.Fields{
+		"name": {Type: gql.NewNonNull(gql.String), Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			return p.Source.(*character).name, nil
+		}},
+		"mood": {Type: mood, Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			if p.Source.(*character).name == "Han" {
+				return 2, nil
+			}
+			return 1, nil
+		}},
+		"secret": {Type: gql.NewNonNull(gql.String), Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			return nil, NewError(CodeNotFound, "no secrets for "+p.Source.(*character).name)
+		}},
+		"crash": {Type: gql.String, Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			panic("kaboom")
+		}},
+	}})
+	char.AddFieldConfig("friends", &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(char))), Resolve: func(p gql.ResolveParams) (interface{}, error) {
+		var friends []*character
+		for _, id := range p.Source.(*character).friends {
+			friends = append(friends, characters[id])
+		}
+		return friends, nil
+	}})
+	char.AddFieldConfig("bestFriend", &gql.Field{Type: char, Resolve: func(p gql.ResolveParams) (interface{}, error) {
+		load := bestFriends.Load(p.Context, bestFriendKey{fmt.Sprint(p.Info.Path.AsArray()), p.Source.(*character)})
+		return func() (interface{}, error) {
+			friend, err := load()
+			if friend == nil {
+				return nil, err
+			}
+			return friend, err
+		}, nil
+	}})
+	query := gql.NewObject(gql.ObjectConfig{Name: "Query", Fields: gql.Fields{
+		"hero": {Type: gql.NewNonNull(char), Args: gql.FieldConfigArgument{"id": {Type: gql.ID, DefaultValue: "luke"}},
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				c, ok := characters[p.Args["id"].(string)]
+				if !ok {
+					return nil, errors.New("no such character")
+				}
+				return c, nil
+			}},
+		"characters": {Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(char))), Args: gql.FieldConfigArgument{"first": {Type: gql.NewNonNull(gql.Int)}},
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				all := []*character{characters["luke"], characters["han"], characters["leia"], characters["vader"]}
+				return all[:min(p.Args["first"].(int), len(all))], nil
+			}},
+	}})
+	var log []string
+	mutation := gql.NewObject(gql.ObjectConfig{Name: "Mutation", Fields: gql.Fields{
+		"append": {Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(gql.String))), Args: gql.FieldConfigArgument{"s": {Type: gql.NewNonNull(gql.String)}},
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				log = append(log, p.Args["s"].(string))
+				return append([]string{}, log...), nil
+			}},
+	}})
+	schema, err := NewSchema(gql.SchemaConfig{Query: query, Mutation: mutation}, Complexity{
+		"Query.characters": func(child int, args map[string]interface{}) int { return 1 + child*args["first"].(int) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema, &batches
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables string
+		limits    Limits
+		want      string
+	}{
+		{
+			name:  "fields, aliases and fragments",
+			query: `{ hero { ...names friends { __typename ... on Character { me: name mood } } } } fragment names on Character { name }`,
+			want:  `{"data":{"hero":{"friends":[{"__typename":"Character","me":"Han","mood":"GRUMPY"},{"__typename":"Character","me":"Leia","mood":"HAPPY"}],"name":"Luke"}}}`,
+		},
+		{
+			name:      "variables and directives",
+			query:     `query Q($id: ID = "han", $full: Boolean!) { hero(id: $id) { name friends @include(if: $full) { name } mood @skip(if: $full) } }`,
+			variables: `{"full": false}`,
+			want:      `{"data":{"hero":{"mood":"GRUMPY","name":"Han"}}}`,
+		},
+		{
+			name:      "missing variable",
+			query:     `query Q($id: ID!) { hero(id: $id) { name } }`,
+			variables: `{}`,
+			want:      `{"errors":[{"message":"Variable \"$id\" of required type \"ID!\" was not provided.","locations":[{"line":1,"column":9}],"extensions":{"code":"BAD_USER_INPUT"}}]}`,
+		},
+		{
+			name:  "null propagates to the nearest nullable field",
+			query: `{ hero { bestFriend { name secret } } }`,
+			want:  `{"data":{"hero":{"bestFriend":null}},"errors":[{"message":"no secrets for Han","locations":[{"line":1,"column":28}],"path":["hero","bestFriend","secret"],"extensions":{"code":"NOT_FOUND"}}]}`,
+		},
+		{
+			name:  "root error nulls data",
+			query: `{ hero(id: "yoda") { name } }`,
+			want:  `{"data":null,"errors":[{"message":"no such character","locations":[{"line":1,"column":3}],"path":["hero"]}]}`,
+		},
+		{
+			name:  "panics become internal errors",
+			query: `{ hero { crash } }`,
+			want:  `{"data":{"hero":{"crash":null}},"errors":[{"message":"internal server error","locations":[{"line":1,"column":10}],"path":["hero","crash"],"extensions":{"code":"INTERNAL_SERVER_ERROR"}}]}`,
+		},
+		{
+			name:  "mutations run in order",
+			query: `mutation { a: append(s: "a") b: append(s: "b") }`,
+			want:  `{"data":{"a":["a"],"b":["a","b"]}}`,
+		},
+		{
+			name:  "syntax error",
+			query: `{ hero { name }`,
+			want:  `{"errors":[{"message":"Syntax Error GraphQL (1:16) Expected Name, found EOF","locations":[{"line":1,"column":16}],"extensions":{"code":"GRAPHQL_PARSE_FAILED"}}]}`,
+		},
+		{
+			name:  "validation errors are all reported",
+			query: `{ hero { nickname friends } villain }`,
+			want:  `{"errors":[{"message":"Cannot query field \"nickname\" on type \"Character\". Did you mean \"name\"?","locations":[{"line":1,"column":10}],"extensions":{"code":"GRAPHQL_VALIDATION_FAILED"}},{"message":"Field \"friends\" of type \"[Character!]!\" must have a sub selection.","locations":[{"line":1,"column":19}],"extensions":{"code":"GRAPHQL_VALIDATION_FAILED"}},{"message":"Cannot query field \"villain\" on type \"Query\".","locations":[{"line":1,"column":29}],"extensions":{"code":"GRAPHQL_VALIDATION_FAILED"}}]}`,
+		},
+		{
+			name:   "too deep",
+			query:  `{ hero { friends { friends { name } } } }`,
+			limits: Limits{MaxDepth: 3},
+			want:   `{"errors":[{"message":"Query is nested 4 levels deep, more than the maximum of 3.","locations":[{"line":1,"column":1}],"extensions":{"code":"QUERY_TOO_COMPLEX"}}]}`,
+		},
+		{
+			name:   "too complex",
+			query:  `{ characters(first: 50) { name mood } }`,
+			limits: Limits{MaxComplexity: 100},
+			want:   `{"errors":[{"message":"Query has complexity 101, more than the maximum of 100.","locations":[{"line":1,"column":1}],"extensions":{"code":"QUERY_TOO_COMPLEX"}}]}`,
+		},
+		{
+			name:  "type introspection",
+			query: `{ __type(name: "Character") { kind name fields { name type { kind ofType { name } } } } mood: __type(name: "Mood") { enumValues { name } } none: __type(name: "Droid") { name } }`,
+			want:  `{"data":{"__type":{"fields":[{"name":"bestFriend","type":{"kind":"OBJECT","ofType":null}},{"name":"crash","type":{"kind":"SCALAR","ofType":null}},{"name":"friends","type":{"kind":"NON_NULL","ofType":{"name":null}}},{"name":"mood","type":{"kind":"ENUM","ofType":null}},{"name":"name","type":{"kind":"NON_NULL","ofType":{"name":"String"}}},{"name":"secret","type":{"kind":"NON_NULL","ofType":{"name":"String"}}}],"kind":"OBJECT","name":"Character"},"mood":{"enumValues":[{"name":"HAPPY"},{"name":"GRUMPY"}]},"none":null}}`,
+		},
+		{
+			name:   "introspection is bounded by its own nesting limit",
+			query:  `{ __schema { types { fields { type { fields { type { fields { type { fields { name } } } } } } } } } }`,
+			limits: Limits{MaxDepth: 3},
+			want:   `{"errors":[{"message":"Maximum introspection depth exceeded.","locations":[{"line":1,"column":54}],"extensions":{"code":"GRAPHQL_VALIDATION_FAILED"}}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, _ := testSchema(t)
+			req := Request{Query: tt.query}
+			if tt.variables != "" {
+				if err := decodeJSON(strings.NewReader(tt.variables), &req.Variables); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := json.Marshal(schema.Execute(context.Background(), req, tt.limits))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThunksAreForcedPerLevel(t *testing.T) {
+	schema, batches := testSchema(t)
+	resp := schema.Execute(context.Background(), Request{Query: `{ characters(first: 4) { bestFriend { bestFriend { name } } } }`}, Limits{})
+	if len(resp.Errors) > 0 {
+		t.Fatal(resp.Errors[0])
+	}
+	want := `{"characters":[{"bestFriend":{"bestFriend":{"name":"Luke"}}},{"bestFriend":{"bestFriend":{"name":"Han"}}},{"bestFriend":{"bestFriend":{"name":"Han"}}},{"bestFriend":null}]}`
+	if string(resp.Data) != want {
+		t.Errorf("data = %s, want %s", resp.Data, want)
+	}
+	// Every bestFriend of a level is resolved before any of its thunks run,
+	// so each level loads in one batch.
+	if got := *batches; len(got) != 2 || got[0] != 4 || got[1] != 3 {
+		t.Errorf("thunk batches = %v, want [4 3]", got)
+	}
+}
+
+// introspectionQuery is the query GraphiQL sends to build its schema
+// explorer, less the fields added to introspection after graphql-go.
+const introspectionQuery = `query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types { ...FullType }
+    directives { name description locations args { ...InputValue } }
+  }
+}
+fragment FullType on __Type {
+  kind name description
+  fields(includeDeprecated: true) { name description args { ...InputValue } type { ...TypeRef } isDeprecated deprecationReason }
+  inputFields { ...InputValue }
+  interfaces { ...TypeRef }
+  enumValues(includeDeprecated: true) { name description isDeprecated deprecationReason }
+  possibleTypes { ...TypeRef }
+}
+fragment InputValue on __InputValue { name description type { ...TypeRef } defaultValue }
+fragment TypeRef on __Type {
+  kind name
+  ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name } } } } } } } }
+}`
+
+func TestIntrospectionQuery(t *testing.T) {
+	schema, _ := testSchema(t)
+	resp := schema.Execute(context.Background(), Request{Query: introspectionQuery}, Limits{MaxDepth: 10, MaxComplexity: 1000})
+	if len(resp.Errors) > 0 {
+		t.Fatal(resp.Errors[0])
+	}
+	var data struct {
+		Schema struct {
+			QueryType    struct{ Name string }
+			MutationType struct{ Name string }
+			Types        []struct {
+				Kind, Name string
+				Fields     []struct {
+					Name string
+					Args []struct{ Name, DefaultValue string }
+				}
+			}
+			Directives []struct{ Name string }
+		} `json:"__schema"`
+	}
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		t.Fatal(err)
+	}
+	if data.Schema.QueryType.Name != "Query" || data.Schema.MutationType.Name != "Mutation" || len(data.Schema.Directives) != 3 {
+		t.Errorf("roots and directives = %+v", data.Schema)
+	}
+	kinds := make(map[string]string)
+	for _, typ := range data.Schema.Types {
+		kinds[typ.Name] = typ.Kind
+		if typ.Name != "Query" {
+			continue
+		}
+		// Fields come sorted by name.
+		if len(typ.Fields) != 2 || typ.Fields[1].Name != "hero" || typ.Fields[1].Args[0].DefaultValue != `"luke"` {
+			t.Errorf("Query fields = %+v, want hero with a default id and characters, without the meta fields", typ.Fields)
+		}
+	}
+	for name, kind := range map[string]string{"Character": "OBJECT", "Mood": "ENUM", "Boolean": "SCALAR", "__Type": "OBJECT", "__TypeKind": "ENUM"} {
+		if kinds[name] != kind {
+			t.Errorf("type %s has kind %q, want %q", name, kinds[name], kind)
+		}
+	}
+}
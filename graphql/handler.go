@@ -0,0 +1,108 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// maxBodyBytes bounds the size of a POSTed request.
+const maxBodyBytes = 1 << 20
+
+// Handler serves GraphQL over HTTP: POST with a JSON body of query,
+// operationName and variables, or GET with the same as URL parameters for
+// queries only. Requests rejected before any field runs get 400; once an
+// operation runs the status is 200, with any field errors in the body.
+func (s *Schema) Handler(limits Limits) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req, err := readRequest(c)
+		if err != nil {
+			respond(c, http.StatusBadRequest, &Response{Errors: []*Error{newError(CodeBadUserInput, err.Error())}})
+			return
+		}
+
+		p, errs := s.prepare(req, limits)
+		if len(errs) > 0 {
+			respond(c, http.StatusBadRequest, &Response{Errors: errs})
+			return
+		}
+		if c.Request.Method == http.MethodGet && p.op.Operation != ast.OperationTypeQuery {
+			c.Header("Allow", http.MethodPost)
+			respond(c, http.StatusMethodNotAllowed, &Response{Errors: []*Error{
+				errorAt(p.op.Loc, CodeValidationFailed, "Can only perform a %s operation from a POST request.", p.op.Operation),
+			}})
+			return
+		}
+		resp := s.execute(c.Request.Context(), req, p)
+		if resp.Data == nil {
+			respond(c, http.StatusBadRequest, resp)
+			return
+		}
+		respond(c, http.StatusOK, resp)
+	}
+}
+
+func readRequest(c *gin.Context) (Request, error) {
+	var req Request
+	if c.Request.Method == http.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if vars := c.Query("variables"); vars != "" {
+			if err := decodeJSON(strings.NewReader(vars), &req.Variables); err != nil {
+				return req, errors.New("variables must be a JSON object")
+			}
+		}
+	} else {
+		if contentType := c.ContentType(); contentType != "application/json" {
+			return req, errors.New("Content-Type must be application/json")
+		}
+		var buf bytes.Buffer
+		if _, err := buf.ReadFrom(http.MaxBytesReader(c.Writer, c.Request.Body, maxBodyBytes)); err != nil {
+			return req, errors.New("request body is too large")
+		}
+		if err := decodeJSON(&buf, &req); err != nil {
+			return req, errors.New("request body must be a JSON object with a query string")
+		}
+	}
+	if req.Query == "" {
+		return req, errors.New("Must provide query string.")
+	}
+	return req, nil
+}
+
+// decodeJSON decodes one JSON value, with numbers as float64 as graphql-go
+// expects them.
+func decodeJSON(r io.Reader, v interface{}) error {
+	decoder := json.NewDecoder(r)
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return errors.New("unexpected data after the JSON value")
+	}
+	return nil
+}
+
+func respond(c *gin.Context, status int, resp *Response) {
+	body, err := json.Marshal(resp)
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+	c.Data(status, "application/json; charset=utf-8", body)
+}
+
+// SDLHandler serves the schema in the schema definition language, for
+// tools that generate clients or check queries.
+func (s *Schema) SDLHandler() gin.HandlerFunc {
+	sdl := []byte(s.SDL())
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", sdl)
+	}
+}
@@ -0,0 +1,143 @@
+package graphql
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	gql "github.com/graphql-go/graphql"
+)
+
+// builtinScalars are the scalars every schema has, which SDL leaves out.
+var builtinScalars = map[string]bool{"Int": true, "Float": true, "String": true, "Boolean": true, "ID": true}
+
+// SDL prints the schema in the GraphQL schema definition language: the
+// root types, then the others by name. graphql-go keeps fields, arguments
+// and enum values in maps, so they are sorted by name too.
+func (s *Schema) SDL() string {
+	var names []string
+	for name := range s.schema.TypeMap() {
+		if !strings.HasPrefix(name, "__") && !builtinScalars[name] {
+			names = append(names, name)
+		}
+	}
+	roots := []string{s.schema.QueryType().Name()}
+	if mutation := s.schema.MutationType(); mutation != nil {
+		roots = append(roots, mutation.Name())
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		ia, ib := slices.Index(roots, a), slices.Index(roots, b)
+		switch {
+		case ia >= 0 && ib >= 0:
+			return ia - ib
+		case ia >= 0:
+			return -1
+		case ib >= 0:
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+
+	var b strings.Builder
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		switch t := s.schema.Type(name).(type) {
+		case *gql.Object:
+			writeDescription(&b, "", t.Description())
+			fmt.Fprintf(&b, "type %s {\n", t.Name())
+			fields := t.Fields()
+			for _, name := range sortedKeys(fields) {
+				f := fields[name]
+				writeDescription(&b, "  ", f.Description)
+				fmt.Fprintf(&b, "  %s%s: %s\n", f.Name, argumentsSDL(f.Args), f.Type)
+			}
+			b.WriteString("}\n")
+		case *gql.InputObject:
+			writeDescription(&b, "", t.Description())
+			fmt.Fprintf(&b, "input %s {\n", t.Name())
+			fields := t.Fields()
+			for _, name := range sortedKeys(fields) {
+				f := fields[name]
+				writeDescription(&b, "  ", f.Description())
+				fmt.Fprintf(&b, "  %s\n", inputValueSDL(f.Name(), f.Type, f.DefaultValue))
+			}
+			b.WriteString("}\n")
+		case *gql.Enum:
+			writeDescription(&b, "", t.Description())
+			fmt.Fprintf(&b, "enum %s {\n", t.Name())
+			values := slices.Clone(t.Values())
+			slices.SortFunc(values, func(a, b *gql.EnumValueDefinition) int { return strings.Compare(a.Name, b.Name) })
+			for _, v := range values {
+				writeDescription(&b, "  ", v.Description)
+				fmt.Fprintf(&b, "  %s\n", v.Name)
+			}
+			b.WriteString("}\n")
+		case *gql.Scalar:
+			writeDescription(&b, "", t.Description())
+			fmt.Fprintf(&b, "scalar %s\n", t.Name())
+		}
+	}
+	return b.String()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func argumentsSDL(args []*gql.Argument) string {
+	if len(args) == 0 {
+		return ""
+	}
+	args = slices.Clone(args)
+	slices.SortFunc(args, func(a, b *gql.Argument) int { return strings.Compare(a.Name(), b.Name()) })
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		parts[i] = inputValueSDL(arg.Name(), arg.Type, arg.DefaultValue)
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func inputValueSDL(name string, t gql.Input, value interface{}) string {
+	s := name + ": " + t.String()
+	if value != nil {
+		s += " = " + defaultSDL(t, value)
+	}
+	return s
+}
+
+func defaultSDL(t gql.Input, v interface{}) string {
+	if enum, ok := gql.GetNamed(t).(*gql.Enum); ok {
+		for _, value := range enum.Values() {
+			if value.Value == v {
+				return value.Name
+			}
+		}
+	}
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprint(v)
+}
+
+func writeDescription(b *strings.Builder, indent, description string) {
+	if description == "" {
+		return
+	}
+	if !strings.Contains(description, "\n") {
+		fmt.Fprintf(b, "%s%s\n", indent, strconv.Quote(description))
+		return
+	}
+	fmt.Fprintf(b, "%s\"\"\"\n", indent)
+	for _, line := range strings.Split(description, "\n") {
+		fmt.Fprintf(b, "%s%s\n", indent, line)
+	}
+	fmt.Fprintf(b, "%s\"\"\"\n", indent)
+}
@@ -0,0 +1,76 @@
+// Package grpcserver serves the task and category services over gRPC, next to
+// the REST API and on top of the same application services.
+package grpcserver
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/ltphat2204/domain-driven-golang/logging"
+	categoryApplication "github.com/ltphat2204/domain-driven-golang/modules/category/application"
+	categoryRPC "github.com/ltphat2204/domain-driven-golang/modules/category/rpc"
+	healthApplication "github.com/ltphat2204/domain-driven-golang/modules/health/application"
+	healthRPC "github.com/ltphat2204/domain-driven-golang/modules/health/rpc"
+	streamApplication "github.com/ltphat2204/domain-driven-golang/modules/stream/application"
+	taskApplication "github.com/ltphat2204/domain-driven-golang/modules/task/application"
+	taskRPC "github.com/ltphat2204/domain-driven-golang/modules/task/rpc"
+	pb "github.com/ltphat2204/domain-driven-golang/proto/taskmanager/v1"
+	"github.com/ltphat2204/domain-driven-golang/tracing"
+)
+
+type Services struct {
+	Tasks taskApplication.TaskService
+	// Stream feeds WatchTasks; Tasks must publish to it.
+	Stream     *streamApplication.Hub
+	Categories categoryApplication.CategoryService
+	Health     healthApplication.HealthService
+}
+
+type Server struct {
+	*grpc.Server
+	health *healthRPC.HealthServer
+	stream *streamApplication.Hub
+}
+
+// New registers the task, category and health services and server
+// reflection, behind the same request ID, logging and tracing as the REST
+// API.
+func New(services Services, opts ...grpc.ServerOption) *Server {
+	opts = append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(), tracing.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(logging.StreamServerInterceptor(), tracing.StreamServerInterceptor()),
+	}, opts...)
+	s := &Server{
+		Server: grpc.NewServer(opts...),
+		health: healthRPC.NewHealthServer(services.Health, pb.TaskService_ServiceDesc.ServiceName, pb.CategoryService_ServiceDesc.ServiceName),
+		stream: services.Stream,
+	}
+	pb.RegisterTaskServiceServer(s, taskRPC.NewTaskServer(services.Tasks, services.Stream))
+	pb.RegisterCategoryServiceServer(s, categoryRPC.NewCategoryServer(services.Categories))
+	healthpb.RegisterHealthServer(s, s.health)
+	reflection.Register(s)
+	return s
+}
+
+// Shutdown ends the watch streams, which would otherwise never finish, then
+// waits for in-flight calls until ctx ends and closes whatever is left.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.health.Shutdown()
+	s.stream.Close()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.Stop()
+		return ctx.Err()
+	}
+}
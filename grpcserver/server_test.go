@@ -0,0 +1,289 @@
+package grpcserver_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ltphat2204/domain-driven-golang/config"
+	"github.com/ltphat2204/domain-driven-golang/grpcserver"
+	categoryApplication "github.com/ltphat2204/domain-driven-golang/modules/category/application"
+	categoryInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/category/infrastructure"
+	healthApplication "github.com/ltphat2204/domain-driven-golang/modules/health/application"
+	streamApplication "github.com/ltphat2204/domain-driven-golang/modules/stream/application"
+	taskApplication "github.com/ltphat2204/domain-driven-golang/modules/task/application"
+	taskInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/task/infrastructure"
+	pb "github.com/ltphat2204/domain-driven-golang/proto/taskmanager/v1"
+)
+
+func TestMain(m *testing.M) {
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	os.Exit(m.Run())
+}
+
+type fixture struct {
+	conn   *grpc.ClientConn
+	server *grpcserver.Server
+	tasks  taskApplication.TaskService
+	health healthApplication.HealthService
+}
+
+// newFixture serves the gRPC API over memory storage on an in-process
+// listener.
+func newFixture(t *testing.T) *fixture {
+	categoryRepo := categoryInfrastructure.NewMemoryCategoryRepository()
+	hub := streamApplication.NewHub(100)
+	f := &fixture{
+		tasks:  hub.TaskService(taskApplication.NewTaskService(taskInfrastructure.NewMemoryTaskRepository(categoryRepo))),
+		health: healthApplication.NewHealthService(),
+	}
+	f.server = grpcserver.New(grpcserver.Services{
+		Tasks:      f.tasks,
+		Stream:     hub,
+		Categories: hub.CategoryService(categoryApplication.NewCategoryService(categoryRepo, config.ColorPalette)),
+		Health:     f.health,
+	})
+
+	listener := bufconn.Listen(1 << 20)
+	go f.server.Serve(listener)
+	t.Cleanup(f.server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	f.conn = conn
+	return f
+}
+
+func TestTasksAndCategories(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	tasks := pb.NewTaskServiceClient(f.conn)
+	categories := pb.NewCategoryServiceClient(f.conn)
+
+	ops, err := categories.CreateCategory(ctx, &pb.CreateCategoryRequest{Name: "Ops"})
+	if err != nil {
+		t.Fatalf("CreateCategory: %v", err)
+	}
+	due := time.Date(2025, time.June, 15, 17, 0, 0, 0, time.UTC)
+	var header metadata.MD
+	created, err := tasks.CreateTask(
+		metadata.AppendToOutgoingContext(ctx, "x-request-id", "grpc-test-1"),
+		&pb.CreateTaskRequest{Title: "Rotate certificates", DueAt: timestamppb.New(due), CategoryId: proto.Uint32(ops.GetId())},
+		grpc.Header(&header),
+	)
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	if got := header.Get("x-request-id"); len(got) != 1 || got[0] != "grpc-test-1" {
+		t.Errorf("x-request-id header = %v, want the client's ID echoed", got)
+	}
+	if created.GetStatus() != pb.TaskStatus_TASK_STATUS_PENDING || !created.GetDueAt().AsTime().Equal(due) || created.GetCategoryId() != ops.GetId() {
+		t.Errorf("CreateTask = %v", created)
+	}
+	got, err := tasks.GetTask(ctx, &pb.GetTaskRequest{Id: created.GetId()})
+	if err != nil || got.GetCategory().GetName() != "Ops" {
+		t.Errorf("GetTask = %v, %v; want the category loaded", got, err)
+	}
+	for _, title := range []string{"Write runbook", "Page the on-call"} {
+		if _, err := tasks.CreateTask(ctx, &pb.CreateTaskRequest{Title: title}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	done, err := tasks.UpdateTask(ctx, &pb.UpdateTaskRequest{Id: created.GetId(), Status: pb.TaskStatus_TASK_STATUS_DONE, CategoryId: created.CategoryId})
+	if err != nil {
+		t.Fatalf("UpdateTask: %v", err)
+	}
+	if done.GetStatus() != pb.TaskStatus_TASK_STATUS_DONE || done.GetTitle() != "Rotate certificates" || done.GetCategoryId() != ops.GetId() {
+		t.Errorf("UpdateTask = %v", done)
+	}
+
+	page, err := tasks.ListTasks(ctx, &pb.ListTasksRequest{PageSize: 2, SortBy: "title", SortOrder: "asc"})
+	if err != nil {
+		t.Fatalf("ListTasks: %v", err)
+	}
+	if len(page.GetTasks()) != 2 || page.GetTasks()[0].GetTitle() != "Page the on-call" || page.GetPageInfo().GetTotal() != 3 || !page.GetPageInfo().GetHasMore() {
+		t.Fatalf("ListTasks page 1 = %v", page)
+	}
+	page, err = tasks.ListTasks(ctx, &pb.ListTasksRequest{PageSize: 2, SortBy: "title", SortOrder: "asc", Cursor: page.GetPageInfo().GetNextCursor()})
+	if err != nil {
+		t.Fatalf("ListTasks with cursor: %v", err)
+	}
+	if len(page.GetTasks()) != 1 || page.GetTasks()[0].GetTitle() != "Write runbook" || page.GetPageInfo().GetHasMore() {
+		t.Errorf("ListTasks page 2 = %v", page)
+	}
+	filtered, err := tasks.ListTasks(ctx, &pb.ListTasksRequest{Status: pb.TaskStatus_TASK_STATUS_DONE, Filter: "category_id = 1"})
+	if err != nil || len(filtered.GetTasks()) != 1 {
+		t.Errorf("ListTasks by status and filter = %v, %v", filtered, err)
+	}
+
+	if _, err := tasks.DeleteTask(ctx, &pb.DeleteTaskRequest{Id: created.GetId()}); err != nil {
+		t.Fatalf("DeleteTask: %v", err)
+	}
+	listed, err := categories.ListCategories(ctx, &pb.ListCategoriesRequest{})
+	if err != nil || len(listed.GetCategories()) != 1 {
+		t.Errorf("ListCategories = %v, %v", listed, err)
+	}
+	renamed, err := categories.UpdateCategory(ctx, &pb.UpdateCategoryRequest{Id: ops.GetId(), Name: proto.String("Operations")})
+	if err != nil || renamed.GetName() != "Operations" || renamed.GetColor() != ops.GetColor() {
+		t.Errorf("UpdateCategory = %v, %v", renamed, err)
+	}
+	if _, err := categories.DeleteCategory(ctx, &pb.DeleteCategoryRequest{Id: ops.GetId()}); err != nil {
+		t.Errorf("DeleteCategory: %v", err)
+	}
+
+	for _, tt := range []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{"missing task", func() error { _, err := tasks.GetTask(ctx, &pb.GetTaskRequest{Id: created.GetId()}); return err }, codes.NotFound},
+		{"missing category", func() error { _, err := categories.GetCategory(ctx, &pb.GetCategoryRequest{Id: 99}); return err }, codes.NotFound},
+		{"empty title", func() error { _, err := tasks.CreateTask(ctx, &pb.CreateTaskRequest{}); return err }, codes.InvalidArgument},
+		{"bad sort", func() error { _, err := tasks.ListTasks(ctx, &pb.ListTasksRequest{SortBy: "owner"}); return err }, codes.InvalidArgument},
+		{"bad filter", func() error { _, err := tasks.ListTasks(ctx, &pb.ListTasksRequest{Filter: "due_at <"}); return err }, codes.InvalidArgument},
+		{"bad cursor", func() error { _, err := tasks.ListTasks(ctx, &pb.ListTasksRequest{Cursor: "nope"}); return err }, codes.InvalidArgument},
+		{"bad status", func() error {
+			_, err := tasks.UpdateTask(ctx, &pb.UpdateTaskRequest{Id: 2, Status: pb.TaskStatus(42)})
+			return err
+		}, codes.InvalidArgument},
+		{"bad color", func() error {
+			_, err := categories.CreateCategory(ctx, &pb.CreateCategoryRequest{Name: "Dev"})
+			if err != nil {
+				return err
+			}
+			_, err = categories.UpdateCategory(ctx, &pb.UpdateCategoryRequest{Id: 2, Color: proto.String("#000001")})
+			return err
+		}, codes.InvalidArgument},
+	} {
+		if got := status.Code(tt.call()); got != tt.want {
+			t.Errorf("%s: code = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWatchTasks(t *testing.T) {
+	f := newFixture(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	tasks := pb.NewTaskServiceClient(f.conn)
+
+	stream, err := tasks.WatchTasks(ctx, &pb.WatchTasksRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Wait for the headers, sent once the watch is subscribed.
+	if _, err := stream.Header(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Category changes share the hub but are not task events.
+	if _, err := pb.NewCategoryServiceClient(f.conn).CreateCategory(ctx, &pb.CreateCategoryRequest{Name: "Releases"}); err != nil {
+		t.Fatal(err)
+	}
+	created, err := tasks.CreateTask(ctx, &pb.CreateTaskRequest{Title: "Ship 1.2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Changes made through the services directly, as the REST API does,
+	// are streamed too.
+	title := "Ship 1.2.1"
+	if _, err := f.tasks.UpdateTask(ctx, uint(created.GetId()), &title, nil, nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.tasks.DeleteTask(ctx, uint(created.GetId())); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		typ   pb.TaskEvent_Type
+		title string
+	}{
+		{pb.TaskEvent_TYPE_CREATED, "Ship 1.2"},
+		{pb.TaskEvent_TYPE_UPDATED, "Ship 1.2.1"},
+		{pb.TaskEvent_TYPE_DELETED, ""},
+	}
+	for _, w := range want {
+		event, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		if event.GetType() != w.typ || event.GetTask().GetId() != created.GetId() || event.GetTask().GetTitle() != w.title || event.GetOccurredAt() == nil {
+			t.Errorf("event = %v, want %v %q", event, w.typ, w.title)
+		}
+	}
+
+	shutdown, cancelShutdown := context.WithTimeout(ctx, time.Second)
+	defer cancelShutdown()
+	if err := f.server.Shutdown(shutdown); err != nil {
+		t.Errorf("Shutdown with an open watch: %v", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Errorf("Recv after shutdown = %v, want Unavailable", err)
+	}
+}
+
+func TestHealthAndReflection(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	health := healthpb.NewHealthClient(f.conn)
+
+	for _, service := range []string{"", "liveness", "taskmanager.v1.TaskService"} {
+		resp, err := health.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("Check(%q) = %v, %v; want SERVING", service, resp, err)
+		}
+	}
+	if _, err := health.Check(ctx, &healthpb.HealthCheckRequest{Service: "nope"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Check(nope) = %v, want NotFound", err)
+	}
+	f.health.SetShuttingDown()
+	if resp, _ := health.Check(ctx, &healthpb.HealthCheckRequest{}); resp.GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Check while shutting down = %v, want NOT_SERVING", resp)
+	}
+	if resp, _ := health.Check(ctx, &healthpb.HealthCheckRequest{Service: "liveness"}); resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("liveness while shutting down = %v, want SERVING", resp)
+	}
+
+	stream, err := reflectionpb.NewServerReflectionClient(f.conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&reflectionpb.ServerReflectionRequest{MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{}}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	services := map[string]bool{}
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		services[service.GetName()] = true
+	}
+	for _, name := range []string{"taskmanager.v1.TaskService", "taskmanager.v1.CategoryService", "grpc.health.v1.Health"} {
+		if !services[name] {
+			t.Errorf("reflection lists %v, missing %s", services, name)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ltphat2204/domain-driven-golang/common"
+)
+
+// requestIDMetadata is the gRPC metadata key carrying the request ID; gRPC
+// lowercases keys.
+var requestIDMetadata = strings.ToLower(common.RequestIDHeader)
+
+// UnaryServerInterceptor is Middleware and Recovery for unary RPCs: it gives
+// every call a request ID, reusing a valid x-request-id from the client and
+// returning it as a header, turns panics into Internal errors and logs one
+// line per call.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		start := time.Now()
+		ctx = withRequestID(ctx)
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ctx, r)
+			}
+			logRPC(ctx, info.FullMethod, start, err)
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming RPCs. The
+// line is logged when the stream ends.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		start := time.Now()
+		ctx := withRequestID(ss.Context())
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ctx, r)
+			}
+			logRPC(ctx, info.FullMethod, start, err)
+		}()
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context { return s.ctx }
+
+func withRequestID(ctx context.Context) context.Context {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDMetadata); len(values) > 0 {
+			id = values[0]
+		}
+	}
+	if !validRequestID(id) {
+		id = common.NewRequestID()
+	}
+	// Fails only outside a gRPC call, which has no header to set.
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadata, id))
+	return common.WithRequestID(ctx, id)
+}
+
+func recovered(ctx context.Context, r interface{}) error {
+	slog.ErrorContext(ctx, "panic serving rpc", "panic", fmt.Sprint(r), "stack", string(debug.Stack()))
+	return status.Error(codes.Internal, "internal server error")
+}
+
+// logRPC logs at info for successes and cancellations, error for server
+// faults and warn for everything else, which the client caused.
+func logRPC(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelWarn
+	switch code {
+	case codes.OK, codes.Canceled:
+		level = slog.LevelInfo
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss, codes.Unimplemented:
+		level = slog.LevelError
+	}
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", status.Convert(err).Message()))
+	}
+	slog.LogAttrs(ctx, level, "rpc", attrs...)
+}
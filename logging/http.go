@@ -0,0 +1,87 @@
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ltphat2204/domain-driven-golang/common"
+)
+
+// maxRequestIDLength bounds request IDs taken from clients.
+const maxRequestIDLength = 128
+
+// Middleware gives every request an ID, reusing a valid X-Request-ID header
+// from the client, stores it in the request context and echoes it in the
+// response. Once the request is handled it logs one access line: info for
+// successes, warn for client errors and error for server errors. It must be
+// the first middleware so everything below sees the ID.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		id := c.GetHeader(common.RequestIDHeader)
+		if !validRequestID(id) {
+			id = common.NewRequestID()
+		}
+		c.Header(common.RequestIDHeader, id)
+		c.Request = c.Request.WithContext(common.WithRequestID(c.Request.Context(), id))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("route", c.FullPath()),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Duration("duration", time.Since(start)),
+			slog.Int("bytes", max(c.Writer.Size(), 0)),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("error", c.Errors.String()))
+		}
+		slog.LogAttrs(c.Request.Context(), level, "request", attrs...)
+	}
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// Recovery turns a panic in a handler into a logged error and a 500
+// response, instead of gin's plain-text recovery.
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				if r == http.ErrAbortHandler {
+					panic(r)
+				}
+				ctx := c.Request.Context()
+				slog.ErrorContext(ctx, "panic serving request", "panic", fmt.Sprint(r), "stack", string(debug.Stack()))
+				c.AbortWithStatusJSON(http.StatusInternalServerError, common.NewErrorResponse(ctx, http.StatusInternalServerError, "Internal server error", ""))
+			}
+		}()
+		c.Next()
+	}
+}
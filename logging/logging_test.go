@@ -0,0 +1,143 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/ltphat2204/domain-driven-golang/common"
+	"github.com/ltphat2204/domain-driven-golang/config"
+)
+
+// captureLogs makes the default logger write JSON at debug level to the
+// returned buffer for the rest of the test.
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(New(config.LoggingConfig{Level: "debug", Format: config.LogFormatJSON}, &buf))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+func records(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var out []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("log line %q is not JSON: %v", line, err)
+		}
+		out = append(out, record)
+	}
+	return out
+}
+
+func TestMiddlewareRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logs := captureLogs(t)
+
+	r := gin.New()
+	r.Use(Middleware(), Recovery())
+	r.GET("/items/:id", func(c *gin.Context) {
+		slog.InfoContext(c.Request.Context(), "handling")
+		c.JSON(http.StatusNotFound, common.NewSimpleErrorResponse(c.Request.Context(), "missing"))
+	})
+	r.GET("/panic", func(c *gin.Context) { panic("boom") })
+
+	tests := []struct {
+		name, header string
+		keep         bool
+	}{
+		{"propagated", "client-id-1", true},
+		{"generated", "", false},
+		{"invalid replaced", "has spaces", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs.Reset()
+			req := httptest.NewRequest("GET", "/items/7", nil)
+			if tt.header != "" {
+				req.Header.Set(common.RequestIDHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			id := rec.Header().Get(common.RequestIDHeader)
+			if tt.keep && id != tt.header {
+				t.Errorf("response request ID = %q, want %q", id, tt.header)
+			}
+			if !tt.keep && (id == tt.header || len(id) != 32) {
+				t.Errorf("response request ID = %q, want a generated one", id)
+			}
+			var body struct {
+				RequestID string `json:"request_id"`
+			}
+			json.Unmarshal(rec.Body.Bytes(), &body)
+			if body.RequestID != id {
+				t.Errorf("error body request_id = %q, want %q", body.RequestID, id)
+			}
+
+			lines := records(t, logs)
+			if len(lines) != 2 {
+				t.Fatalf("got %d log lines, want handler and access lines: %s", len(lines), logs)
+			}
+			for _, line := range lines {
+				if line["request_id"] != id {
+					t.Errorf("log line %v has request_id %v, want %q", line["msg"], line["request_id"], id)
+				}
+			}
+			access := lines[1]
+			if access["level"] != "WARN" || access["route"] != "/items/:id" || access["status"] != float64(404) {
+				t.Errorf("access line = %v", access)
+			}
+		})
+	}
+
+	t.Run("panic", func(t *testing.T) {
+		logs.Reset()
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest("GET", "/panic", nil))
+		if rec.Code != http.StatusInternalServerError || !strings.Contains(rec.Body.String(), `"request_id"`) {
+			t.Errorf("panic response = %d %s", rec.Code, rec.Body)
+		}
+		lines := records(t, logs)
+		if len(lines) != 2 || lines[0]["panic"] != "boom" || lines[1]["level"] != "ERROR" {
+			t.Errorf("panic logs = %v", lines)
+		}
+	})
+}
+
+func TestGormLogger(t *testing.T) {
+	logs := captureLogs(t)
+	ctx := common.WithRequestID(context.Background(), "req-1")
+	l := NewGormLogger(100 * time.Millisecond)
+	sql := func() (string, int64) { return "SELECT 1", 1 }
+
+	l.Trace(ctx, time.Now(), sql, nil)
+	l.Trace(ctx, time.Now().Add(-time.Second), sql, nil)
+	l.Trace(ctx, time.Now(), sql, errors.New("no such table"))
+	l.Trace(ctx, time.Now(), sql, gorm.ErrRecordNotFound)
+
+	var got []string
+	for _, line := range records(t, logs) {
+		if line["request_id"] != "req-1" || line["sql"] != "SELECT 1" {
+			t.Errorf("log line = %v", line)
+		}
+		got = append(got, line["level"].(string)+" "+line["msg"].(string))
+	}
+	want := []string{"DEBUG sql", "WARN slow sql", "ERROR sql failed", "DEBUG sql"}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("levels = %v, want %v", got, want)
+	}
+}
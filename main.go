@@ -1,7 +1,17 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -9,48 +19,240 @@ import (
 
 	categoryApplication "github.com/ltphat2204/domain-driven-golang/modules/category/application"
 	categoryDomain "github.com/ltphat2204/domain-driven-golang/modules/category/domain"
-	categoryHandler "github.com/ltphat2204/domain-driven-golang/modules/category/handler"
 	categoryInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/category/infrastructure"
-	categoryRoutes "github.com/ltphat2204/domain-driven-golang/modules/category/route"
 
 	taskApplication "github.com/ltphat2204/domain-driven-golang/modules/task/application"
 	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
-	taskHandler "github.com/ltphat2204/domain-driven-golang/modules/task/handlers"
 	taskInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/task/infrastructure"
-	taskRoutes "github.com/ltphat2204/domain-driven-golang/modules/task/route"
 
+	searchApplication "github.com/ltphat2204/domain-driven-golang/modules/search/application"
+
+	healthApplication "github.com/ltphat2204/domain-driven-golang/modules/health/application"
+	healthDomain "github.com/ltphat2204/domain-driven-golang/modules/health/domain"
+	healthInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/health/infrastructure"
+
+	viewApplication "github.com/ltphat2204/domain-driven-golang/modules/view/application"
+	viewDomain "github.com/ltphat2204/domain-driven-golang/modules/view/domain"
+	viewInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/view/infrastructure"
+
+	streamApplication "github.com/ltphat2204/domain-driven-golang/modules/stream/application"
+
+	transferApplication "github.com/ltphat2204/domain-driven-golang/modules/transfer/application"
+	transferDomain "github.com/ltphat2204/domain-driven-golang/modules/transfer/domain"
+	transferInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/transfer/infrastructure"
+
+	"github.com/ltphat2204/domain-driven-golang/common"
 	"github.com/ltphat2204/domain-driven-golang/config"
+	"github.com/ltphat2204/domain-driven-golang/database"
+	"github.com/ltphat2204/domain-driven-golang/graphql"
+	"github.com/ltphat2204/domain-driven-golang/grpcserver"
+	"github.com/ltphat2204/domain-driven-golang/logging"
+	"github.com/ltphat2204/domain-driven-golang/metrics"
+	"github.com/ltphat2204/domain-driven-golang/router"
+	"github.com/ltphat2204/domain-driven-golang/tracing"
+	"github.com/ltphat2204/domain-driven-golang/worker"
 )
 
-var db *gorm.DB
+func main() {
+	err := run(context.Background(), os.Args[1:])
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return
+	}
 
-func init() {
-	err := godotenv.Load()
+	var usage usageError
+	if errors.As(err, &usage) {
+		fmt.Fprintln(os.Stderr, usage.Error())
+		os.Exit(2)
+	}
+	slog.Error("exiting", "error", err)
+	os.Exit(1)
+}
+
+// usageError is a command-line mistake; it is printed as-is and exits with
+// status 2.
+type usageError string
+
+func (e usageError) Error() string { return string(e) }
+
+// run loads the configuration and runs either the server or a subcommand.
+func run(ctx context.Context, args []string) error {
+	if err := loadDotEnv(); err != nil {
+		return err
+	}
+	cfg, args, err := config.Load(args)
 	if err != nil {
-		log.Fatal("Error loading .env file, using default environment variables instead")
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("invalid configuration:\n%w", err)
+	}
+
+	slog.SetDefault(logging.New(cfg.Logging, os.Stderr))
+	if cfg.Logging.Level != "debug" {
+		gin.SetMode(gin.ReleaseMode)
 	}
 
-	db, err = config.GetDb()
+	if len(args) > 0 && args[0] == "migrate" {
+		return runMigrate(ctx, cfg, args[1:])
+	}
+	if len(args) > 0 {
+		return usageError(fmt.Sprintf("unknown command %q; the only subcommand is migrate", args[0]))
+	}
+
+	slog.Info("effective configuration", "config", cfg)
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	app, err := bootstrap(ctx, cfg)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
+	go func() {
+		// A second signal during shutdown kills the process.
+		<-ctx.Done()
+		stop()
+	}()
+	return app.serve(ctx)
+}
 
-	db.AutoMigrate(&taskDomain.Task{}, &categoryDomain.Category{})
+// loadDotEnv loads .env into the environment when the file exists. Variables
+// already set in the environment win, so deployments can skip the file.
+func loadDotEnv() error {
+	err := godotenv.Load()
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("loading .env: %w", err)
+	}
+	return nil
 }
 
-func main() {
-	taskRepo := taskInfrastructure.NewTaskRepository(db)
-	taskService := taskApplication.NewTaskService(taskRepo)
-	taskHandler := taskHandler.NewTaskHandler(taskService)
+// bootstrap connects the storage, wires the services and returns the
+// application ready to serve.
+func bootstrap(ctx context.Context, cfg *config.Config) (*application, error) {
+	if cfg.Pagination.CursorSecret != "" {
+		common.SetCursorSecret([]byte(cfg.Pagination.CursorSecret))
+	}
+	common.SetDefaultPageSize(cfg.Pagination.DefaultPageSize)
+
+	app := &application{
+		workers:         &worker.Group{},
+		shutdownTimeout: time.Duration(cfg.Server.ShutdownTimeout),
+		shutdownDelay:   time.Duration(cfg.Server.ShutdownDelay),
+	}
+	flushTraces, err := tracing.Setup(ctx, cfg.Tracing)
+	if err != nil {
+		return nil, err
+	}
+	app.flushTraces = flushTraces
 
-	categoryRepo := categoryInfrastructure.NewCategoryRepository(db)
-	categoryService := categoryApplication.NewCategoryService(categoryRepo)
-	categoryHandler := categoryHandler.NewCategoryHandler(categoryService)
+	checkers := []healthDomain.Checker{healthInfrastructure.WorkersChecker(app.workers)}
+	var m *metrics.Metrics
+	if cfg.Metrics.Enabled {
+		m = metrics.New(true)
+	}
+	var (
+		taskRepo     taskDomain.TaskRepository
+		categoryRepo categoryDomain.CategoryRepository
+		viewRepo     viewDomain.ViewRepository
+		refRepo      transferDomain.ExternalRefRepository
+		transactor   database.Transactor
+	)
+	if cfg.Database.Driver == config.DriverMemory {
+		categoryRepo = categoryInfrastructure.NewMemoryCategoryRepository()
+		taskRepo = taskInfrastructure.NewMemoryTaskRepository(categoryRepo)
+		viewRepo = viewInfrastructure.NewMemoryViewRepository()
+		refRepo = transferInfrastructure.NewMemoryExternalRefRepository()
+		transactor = database.NewMemoryTransactor(taskRepo.(database.Snapshotter), categoryRepo.(database.Snapshotter), refRepo.(database.Snapshotter))
+	} else {
+		db, err := cfg.Database.Connect(ctx, gormConfig(cfg))
+		if err != nil {
+			return nil, err
+		}
+		migrator, err := checkMigrations(ctx, cfg, db)
+		if err != nil {
+			return nil, fmt.Errorf("migrations: %w", err)
+		}
+		app.db = db
+		if err := db.Use(tracing.GormPlugin{}); err != nil {
+			return nil, fmt.Errorf("instrumenting database: %w", err)
+		}
+		if m != nil {
+			if err := m.InstrumentDB(db, cfg.Database.Driver); err != nil {
+				return nil, fmt.Errorf("instrumenting database: %w", err)
+			}
+		}
+		checkers = append(checkers,
+			healthInfrastructure.DatabaseChecker(db),
+			healthInfrastructure.MigrationsChecker(migrator),
+		)
+
+		categoryRepo = categoryInfrastructure.NewCategoryRepository(db)
+		taskRepo = taskInfrastructure.NewTaskRepository(db)
+		viewRepo = viewInfrastructure.NewViewRepository(db)
+		refRepo = transferInfrastructure.NewExternalRefRepository(db)
+		transactor = database.NewTransactor(db)
+	}
+
+	taskService := taskApplication.NewTaskService(taskRepo)
+	if m != nil {
+		taskService = m.TaskService(taskService)
+		app.workers.Add("overdue-tasks-metric", m.OverdueTasksWorker(taskService, time.Duration(cfg.Metrics.OverdueInterval)))
+	}
+	categoryService := categoryApplication.NewCategoryService(categoryRepo, cfg.Categories.Palette)
+	hub := streamApplication.NewHub(cfg.Stream.ReplayBuffer)
+	taskService = hub.TaskService(taskService)
+	categoryService = hub.CategoryService(categoryService)
+	bulkService := taskApplication.NewBulkService(taskService, transactor, cfg.Tasks.BulkMaxOperations)
+	viewService := viewApplication.NewViewService(viewRepo, taskService)
+	transferService := transferApplication.NewTransferService(taskService, categoryService, refRepo, transactor, transferInfrastructure.Codecs(), transferInfrastructure.Importers())
+	searchService := searchApplication.NewSearchService(taskService, categoryService)
+	app.health = healthApplication.NewHealthService(checkers...)
 
-	r := gin.Default()
+	r := router.New(router.Services{
+		Tasks:      taskService,
+		Bulk:       bulkService,
+		Categories: categoryService,
+		Views:      viewService,
+		Transfer:   transferService,
+		Search:     searchService,
+		Health:     app.health,
+		Metrics:    m,
+		GraphQL: graphql.Limits{
+			MaxComplexity: cfg.GraphQL.MaxComplexity,
+			MaxDepth:      cfg.GraphQL.MaxDepth,
+		},
+		Stream:          hub,
+		StreamHeartbeat: time.Duration(cfg.Stream.HeartbeatInterval),
+	})
 
-	categoryRoutes.SetupRoutes(r, categoryHandler)
-	taskRoutes.SetupRoutes(r, taskHandler)
+	app.server = &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.Server.Port),
+		Handler:           r,
+		ReadTimeout:       time.Duration(cfg.Server.ReadTimeout),
+		ReadHeaderTimeout: time.Duration(cfg.Server.ReadHeaderTimeout),
+		WriteTimeout:      time.Duration(cfg.Server.WriteTimeout),
+		IdleTimeout:       time.Duration(cfg.Server.IdleTimeout),
+		MaxHeaderBytes:    cfg.Server.MaxHeaderBytes,
+	}
+	// Shutdown does not wait for hijacked WebSockets, and streams would
+	// otherwise keep it waiting until its timeout.
+	app.server.RegisterOnShutdown(hub.Close)
+	if cfg.Server.GRPCPort != 0 {
+		app.grpcServer = grpcserver.New(grpcserver.Services{
+			Tasks:      taskService,
+			Stream:     hub,
+			Categories: categoryService,
+			Health:     app.health,
+		})
+		app.grpcAddr = fmt.Sprintf(":%d", cfg.Server.GRPCPort)
+	}
+	return app, nil
+}
 
-	r.Run(":8080")
+// gormConfig sends GORM's logs, including slow statements, to slog.
+func gormConfig(cfg *config.Config) *gorm.Config {
+	return &gorm.Config{
+		Logger: logging.NewGormLogger(time.Duration(cfg.Logging.SlowQueryThreshold)),
+	}
 }
@@ -0,0 +1,82 @@
+// Package metrics exposes Prometheus metrics for HTTP requests, database
+// queries and task activity.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics owns a registry and the collectors reported on it. Each instance
+// is independent, so tests can create their own.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	dbQueryDuration *prometheus.HistogramVec
+	dbQueryErrors   *prometheus.CounterVec
+
+	tasksCreated   prometheus.Counter
+	tasksCompleted prometheus.Counter
+	tasksOverdue   prometheus.Gauge
+}
+
+// New creates the application metrics. withRuntime adds the Go runtime and
+// process collectors.
+func New(withRuntime bool) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "HTTP requests by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency by method, route template and status code.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		dbQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Database statement latency by GORM operation and table.",
+			Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "table"}),
+		dbQueryErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "db_query_errors_total",
+			Help: "Database statements that failed, by GORM operation and table. Not-found results are not errors.",
+		}, []string{"operation", "table"}),
+		tasksCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "tasks_created_total",
+			Help: "Tasks created.",
+		}),
+		tasksCompleted: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "tasks_completed_total",
+			Help: "Tasks moved to the Done status.",
+		}),
+		tasksOverdue: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "tasks_overdue",
+			Help: "Tasks past their due date that are not done, as of the last refresh.",
+		}),
+	}
+	m.registry.MustRegister(
+		m.httpRequests, m.httpDuration,
+		m.dbQueryDuration, m.dbQueryErrors,
+		m.tasksCreated, m.tasksCompleted, m.tasksOverdue,
+	)
+	if withRuntime {
+		m.registry.MustRegister(
+			collectors.NewGoCollector(),
+			collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		)
+	}
+	return m
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
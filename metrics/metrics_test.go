@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/ltphat2204/domain-driven-golang/database/repotest"
+	categoryInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/category/infrastructure"
+	taskApplication "github.com/ltphat2204/domain-driven-golang/modules/task/application"
+	"github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	taskInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/task/infrastructure"
+)
+
+func TestInstrumentDB(t *testing.T) {
+	m := New(false)
+	db := repotest.OpenSQLite(t)
+	if err := m.InstrumentDB(db, "sqlite"); err != nil {
+		t.Fatalf("InstrumentDB: %v", err)
+	}
+
+	tasks := taskInfrastructure.NewTaskRepository(db)
+	ctx := context.Background()
+	saved, err := tasks.Save(ctx, &domain.Task{Title: "write report"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tasks.FindByID(ctx, saved.ID); err != nil {
+		t.Fatal(err)
+	}
+	missing := uint(404)
+	if _, err := tasks.Save(ctx, &domain.Task{Title: "orphan", CategoryID: &missing}); err == nil {
+		t.Fatal("saving a task with a missing category succeeded")
+	}
+
+	if n := testutil.CollectAndCount(m.dbQueryDuration, "db_query_duration_seconds"); n < 2 {
+		t.Errorf("db_query_duration_seconds has %d series, want create and query", n)
+	}
+	if got := testutil.ToFloat64(m.dbQueryErrors.WithLabelValues("create", "tasks")); got != 1 {
+		t.Errorf("db_query_errors_total{create,tasks} = %v, want 1", got)
+	}
+	if n, err := testutil.GatherAndCount(m.registry, "go_sql_open_connections"); err != nil || n != 1 {
+		t.Errorf("pool stats: %d series, %v", n, err)
+	}
+}
+
+func TestTaskMetrics(t *testing.T) {
+	m := New(false)
+	categories := categoryInfrastructure.NewMemoryCategoryRepository()
+	tasks := m.TaskService(taskApplication.NewTaskService(taskInfrastructure.NewMemoryTaskRepository(categories)))
+	ctx := context.Background()
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	late, _ := tasks.CreateTask(ctx, "late", "", &past, nil)
+	tasks.CreateTask(ctx, "also late", "", &past, nil)
+	tasks.CreateTask(ctx, "on time", "", &future, nil)
+
+	done := domain.StatusDone
+	for i := 0; i < 2; i++ {
+		if _, err := tasks.UpdateTask(ctx, late.ID, nil, nil, &done, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := m.refreshOverdue(ctx, tasks); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `
+# HELP tasks_completed_total Tasks moved to the Done status.
+# TYPE tasks_completed_total counter
+tasks_completed_total 1
+# HELP tasks_created_total Tasks created.
+# TYPE tasks_created_total counter
+tasks_created_total 3
+# HELP tasks_overdue Tasks past their due date that are not done, as of the last refresh.
+# TYPE tasks_overdue gauge
+tasks_overdue 1
+`
+	if err := testutil.GatherAndCompare(m.registry, strings.NewReader(expected), "tasks_completed_total", "tasks_created_total", "tasks_overdue"); err != nil {
+		t.Error(err)
+	}
+}
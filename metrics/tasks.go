@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ltphat2204/domain-driven-golang/common"
+	"github.com/ltphat2204/domain-driven-golang/database"
+	taskApplication "github.com/ltphat2204/domain-driven-golang/modules/task/application"
+	"github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	"github.com/ltphat2204/domain-driven-golang/worker"
+)
+
+// TaskService counts the tasks created and completed through next.
+func (m *Metrics) TaskService(next taskApplication.TaskService) taskApplication.TaskService {
+	return &instrumentedTaskService{TaskService: next, metrics: m}
+}
+
+type instrumentedTaskService struct {
+	taskApplication.TaskService
+	metrics *Metrics
+}
+
+func (s *instrumentedTaskService) CreateTask(ctx context.Context, title, description string, dueAt *time.Time, categoryID *uint) (*domain.Task, error) {
+	task, err := s.TaskService.CreateTask(ctx, title, description, dueAt, categoryID)
+	if err == nil {
+		database.AfterCommit(ctx, s.metrics.tasksCreated.Inc)
+	}
+	return task, err
+}
+
+func (s *instrumentedTaskService) UpdateTask(ctx context.Context, id uint, title, description *string, status *domain.TaskStatus, dueAt *time.Time, categoryID *uint) (*domain.Task, error) {
+	wasDone := false
+	if status != nil && *status == domain.StatusDone {
+		if before, err := s.TaskService.GetTaskByID(ctx, id); err == nil {
+			wasDone = before.Status == domain.StatusDone
+		}
+	}
+	task, err := s.TaskService.UpdateTask(ctx, id, title, description, status, dueAt, categoryID)
+	if err == nil && !wasDone && task.Status == domain.StatusDone {
+		database.AfterCommit(ctx, s.metrics.tasksCompleted.Inc)
+	}
+	return task, err
+}
+
+// OverdueTasksWorker refreshes the overdue tasks gauge every interval.
+func (m *Metrics) OverdueTasksWorker(tasks taskApplication.TaskService, interval time.Duration) worker.Func {
+	return func(ctx context.Context) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := m.refreshOverdue(ctx, tasks); err != nil && ctx.Err() == nil {
+				slog.ErrorContext(ctx, "counting overdue tasks for metrics failed", "error", err)
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+func (m *Metrics) refreshOverdue(ctx context.Context, tasks taskApplication.TaskService) error {
+	query := &domain.TaskQuery{
+		BaseQuery: common.BaseQuery{Page: 1, PageSize: 1},
+		Filter: domain.FilterAnd{Exprs: []domain.FilterExpr{
+			domain.FilterCondition{Field: domain.FilterFieldDueAt, Op: domain.FilterOpLt, Values: []interface{}{time.Now()}},
+			domain.FilterCondition{Field: domain.FilterFieldStatus, Op: domain.FilterOpNe, Values: []interface{}{domain.StatusDone}},
+		}},
+	}
+	_, total, err := tasks.GetTasks(ctx, query)
+	if err != nil {
+		return err
+	}
+	m.tasksOverdue.Set(float64(total))
+	return nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"gorm.io/gorm"
+
+	"github.com/ltphat2204/domain-driven-golang/config"
+	"github.com/ltphat2204/domain-driven-golang/migrations"
+)
+
+const migrateUsage = `usage: migrate <command>
+
+commands:
+  up            apply all pending migrations
+  down [n]      roll back the last n migrations (default 1)
+  status        list migrations and when they were applied
+  create <name> write a new numbered up/down pair to MIGRATIONS_DIR`
+
+func runMigrate(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return usageError(migrateUsage)
+	}
+
+	if args[0] == "create" {
+		if len(args) < 2 {
+			return usageError("migrate create: name is required")
+		}
+		up, down, err := migrations.Create(cfg.MigrationsDir(), args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Println("created", up)
+		fmt.Println("created", down)
+		return nil
+	}
+
+	switch args[0] {
+	case "up", "down", "status":
+	default:
+		return usageError(migrateUsage)
+	}
+
+	if cfg.Database.Driver == config.DriverMemory {
+		return errors.New("migrate: the memory driver has no schema to migrate")
+	}
+	steps := 1
+	if args[0] == "down" && len(args) > 1 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 {
+			return usageError("migrate down: steps must be a positive number")
+		}
+		steps = n
+	}
+
+	db, err := cfg.Database.Connect(ctx, gormConfig(cfg))
+	if err != nil {
+		return err
+	}
+	migrator, err := migrations.New(db)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Printf("applied %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+	case "down":
+		reverted, err := migrator.Down(ctx, steps)
+		for _, m := range reverted {
+			fmt.Printf("reverted %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+		}
+		return w.Flush()
+	}
+	return nil
+}
+
+// checkMigrations applies pending migrations when MIGRATE_ON_START is set and
+// otherwise only warns about them. It returns the migrator for the readiness
+// check.
+func checkMigrations(ctx context.Context, cfg *config.Config, db *gorm.DB) (*migrations.Migrator, error) {
+	migrator, err := migrations.New(db)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Migrations.OnStart {
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			slog.InfoContext(ctx, "applied migration", "version", m.Version, "name", m.Name)
+		}
+		return migrator, err
+	}
+
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) > 0 {
+		slog.WarnContext(ctx, "pending migrations; run `migrate up` or set MIGRATE_ON_START=true", "pending", len(pending))
+	}
+	return migrator, nil
+}
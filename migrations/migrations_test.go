@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestStatusIsReadOnly(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	defer sqlDB.Close()
+
+	migrator, err := New(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		t.Fatalf("Pending before the first migration: %v", err)
+	}
+	if len(pending) == 0 || len(pending) != len(migrator.migrations) {
+		t.Errorf("%d pending before the first migration, want all %d", len(pending), len(migrator.migrations))
+	}
+	if db.Migrator().HasTable(table) {
+		t.Errorf("Pending created %s", table)
+	}
+
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			t.Errorf("%04d_%s is pending after Up", status.Version, status.Name)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+-- Matches the schema previously created by GORM AutoMigrate, so existing
+-- databases adopt it without changes.
+CREATE TABLE IF NOT EXISTS categories (
+    id          BIGSERIAL PRIMARY KEY,
+    name        TEXT NOT NULL,
+    description TEXT,
+    color       VARCHAR(7),
+    created_at  TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS tasks (
+    id          BIGSERIAL PRIMARY KEY,
+    title       TEXT NOT NULL,
+    description TEXT,
+    status      VARCHAR(10) DEFAULT 'Pending',
+    created_at  TIMESTAMPTZ,
+    updated_at  TIMESTAMPTZ,
+    due_at      TIMESTAMP,
+    category_id BIGINT,
+    CONSTRAINT fk_tasks_category FOREIGN KEY (category_id) REFERENCES categories (id)
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_categories_search_vector;
+ALTER TABLE categories DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS idx_tasks_search_vector;
+ALTER TABLE tasks DROP COLUMN IF EXISTS search_vector;
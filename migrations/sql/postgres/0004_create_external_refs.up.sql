@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS external_refs (
+    id          BIGSERIAL PRIMARY KEY,
+    entity      VARCHAR(20) NOT NULL,
+    external_id TEXT NOT NULL,
+    entity_id   BIGINT NOT NULL,
+    created_at  TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_external_refs_entity_external_id ON external_refs (entity, external_id);
+CREATE INDEX IF NOT EXISTS idx_external_refs_entity_id ON external_refs (entity, entity_id);
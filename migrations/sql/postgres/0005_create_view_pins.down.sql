@@ -0,0 +1,7 @@
+ALTER TABLE views ADD COLUMN pinned BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE views ADD COLUMN position BIGINT NOT NULL DEFAULT 0;
+
+UPDATE views SET pinned = view_pins.pinned, position = view_pins.position
+FROM view_pins WHERE view_pins.view_id = views.id AND view_pins.user_id = views.owner_id;
+
+DROP TABLE IF EXISTS view_pins;
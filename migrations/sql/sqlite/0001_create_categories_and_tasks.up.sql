@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS categories (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    name        TEXT NOT NULL,
+    description TEXT,
+    color       VARCHAR(7),
+    created_at  DATETIME
+);
+
+CREATE TABLE IF NOT EXISTS tasks (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    title       TEXT NOT NULL,
+    description TEXT,
+    status      VARCHAR(10) DEFAULT 'Pending',
+    created_at  DATETIME,
+    updated_at  DATETIME,
+    due_at      DATETIME,
+    category_id INTEGER,
+    CONSTRAINT fk_tasks_category FOREIGN KEY (category_id) REFERENCES categories (id)
+);
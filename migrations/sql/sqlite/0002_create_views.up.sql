@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS views (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    name       TEXT NOT NULL,
+    owner_id   VARCHAR(64) NOT NULL,
+    visibility VARCHAR(10) DEFAULT 'personal',
+    pinned     BOOLEAN NOT NULL DEFAULT FALSE,
+    position   INTEGER NOT NULL DEFAULT 0,
+    search     TEXT,
+    status     VARCHAR(10),
+    filter     TEXT,
+    sort_by    VARCHAR(20),
+    sort_order VARCHAR(4),
+    group_by   VARCHAR(20),
+    created_at DATETIME,
+    updated_at DATETIME
+);
+
+CREATE INDEX IF NOT EXISTS idx_views_owner_id ON views (owner_id);
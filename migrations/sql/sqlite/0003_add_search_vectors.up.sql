@@ -0,0 +1,3 @@
+-- SQLite has no tsvector; full-text search falls back to LIKE matching.
+-- This version exists so both dialects share the same migration numbers.
+SELECT 1;
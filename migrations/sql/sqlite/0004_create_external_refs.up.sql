@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS external_refs (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    entity      VARCHAR(20) NOT NULL,
+    external_id TEXT NOT NULL,
+    entity_id   INTEGER NOT NULL,
+    created_at  DATETIME
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_external_refs_entity_external_id ON external_refs (entity, external_id);
+CREATE INDEX IF NOT EXISTS idx_external_refs_entity_id ON external_refs (entity, entity_id);
@@ -0,0 +1,8 @@
+ALTER TABLE views ADD COLUMN pinned BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE views ADD COLUMN position INTEGER NOT NULL DEFAULT 0;
+
+UPDATE views SET
+    pinned = COALESCE((SELECT pinned FROM view_pins WHERE view_id = views.id AND user_id = views.owner_id), FALSE),
+    position = COALESCE((SELECT position FROM view_pins WHERE view_id = views.id AND user_id = views.owner_id), 0);
+
+DROP TABLE IF EXISTS view_pins;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS view_pins (
+    user_id  VARCHAR(64) NOT NULL,
+    view_id  INTEGER NOT NULL REFERENCES views (id) ON DELETE CASCADE,
+    pinned   BOOLEAN NOT NULL DEFAULT FALSE,
+    position INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, view_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_view_pins_view_id ON view_pins (view_id);
+
+INSERT INTO view_pins (user_id, view_id, pinned, position)
+SELECT owner_id, id, pinned, position FROM views WHERE pinned OR position <> 0;
+
+ALTER TABLE views DROP COLUMN pinned;
+ALTER TABLE views DROP COLUMN position;
@@ -2,48 +2,99 @@ package application
 
 import (
 	"context"
+	"iter"
+	"log/slog"
 	"fmt"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/ltphat2204/domain-driven-golang/modules/category/domain"
-	"github.com/ltphat2204/domain-driven-golang/config"
+	"github.com/ltphat2204/domain-driven-golang/tracing"
 	"github.com/ltphat2204/domain-driven-golang/utils"
 )
 
+var tracer = otel.Tracer("github.com/ltphat2204/domain-driven-golang/modules/category/application")
+
 type CategoryService interface {
 	CreateCategory(ctx context.Context, name, description string) (*domain.Category, error)
 	GetCategoryByID(ctx context.Context, id uint) (*domain.Category, error)
+	GetCategoriesByIDs(ctx context.Context, ids []uint) ([]*domain.Category, error)
 	GetCategories(ctx context.Context, query *domain.CategoryQuery) ([]*domain.Category, int, error)
+	// AllCategories yields every category in ID order.
+	AllCategories(ctx context.Context) iter.Seq2[*domain.Category, error]
 	UpdateCategory(ctx context.Context, id uint, name, description, color *string) (*domain.Category, error)
 	DeleteCategory(ctx context.Context, id uint) error
 }
 
 type categoryService struct {
-	repo domain.CategoryRepository
+	repo    domain.CategoryRepository
+	palette []string
 }
 
-func NewCategoryService(repo domain.CategoryRepository) CategoryService {
-	return &categoryService{repo: repo}
+// NewCategoryService creates the service. New categories get a random color
+// from palette, and updates may only pick colors from it.
+func NewCategoryService(repo domain.CategoryRepository, palette []string) CategoryService {
+	return &categoryService{repo: repo, palette: palette}
 }
 
-func (s *categoryService) CreateCategory(ctx context.Context, name, description string) (*domain.Category, error) {
-	color := utils.GetRandomColor(config.ColorPalette)
+func (s *categoryService) CreateCategory(ctx context.Context, name, description string) (_ *domain.Category, err error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.CreateCategory")
+	defer tracing.End(span, &err)
+
+	color := utils.GetRandomColor(s.palette)
 	category := &domain.Category{
 		Name:        name,
 		Description: description,
 		Color:       color,
 	}
-	return s.repo.Save(ctx, category)
+	created, err := s.repo.Save(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "category created", "category_id", created.ID)
+	return created, nil
 }
 
-func (s *categoryService) GetCategoryByID(ctx context.Context, id uint) (*domain.Category, error) {
+func (s *categoryService) GetCategoryByID(ctx context.Context, id uint) (_ *domain.Category, err error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.GetCategoryByID")
+	defer tracing.End(span, &err)
+	span.SetAttributes(attribute.Int64("category.id", int64(id)))
+
 	return s.repo.FindByID(ctx, id)
 }
 
-func (s *categoryService) GetCategories(ctx context.Context, query *domain.CategoryQuery) ([]*domain.Category, int, error) {
-	return s.repo.FindCategories(ctx, query)
+func (s *categoryService) GetCategoriesByIDs(ctx context.Context, ids []uint) (_ []*domain.Category, err error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.GetCategoriesByIDs")
+	defer tracing.End(span, &err)
+	span.SetAttributes(attribute.Int("category.ids", len(ids)))
+
+	return s.repo.FindByIDs(ctx, ids)
 }
 
-func (s *categoryService) UpdateCategory(ctx context.Context, id uint, name, description, color *string) (*domain.Category, error) {
+func (s *categoryService) GetCategories(ctx context.Context, query *domain.CategoryQuery) (_ []*domain.Category, _ int, err error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.GetCategories")
+	defer tracing.End(span, &err)
+	span.SetAttributes(
+		attribute.Int("category.query.page_size", query.PageSize),
+		attribute.Bool("category.query.cursor", query.Cursor != nil),
+		attribute.Bool("category.query.search", query.Search != ""),
+	)
+
+	categories, total, err := s.repo.FindCategories(ctx, query)
+	span.SetAttributes(attribute.Int("category.query.results", len(categories)))
+	return categories, total, err
+}
+
+func (s *categoryService) AllCategories(ctx context.Context) iter.Seq2[*domain.Category, error] {
+	return s.repo.All(ctx)
+}
+
+func (s *categoryService) UpdateCategory(ctx context.Context, id uint, name, description, color *string) (_ *domain.Category, err error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.UpdateCategory")
+	defer tracing.End(span, &err)
+	span.SetAttributes(attribute.Int64("category.id", int64(id)))
+
 	category, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -55,14 +106,27 @@ func (s *categoryService) UpdateCategory(ctx context.Context, id uint, name, des
 		category.Description = *description
 	}
 	if color != nil && *color != "" {
-		if !utils.IsValidColor(*color, config.ColorPalette) {
-			return nil, fmt.Errorf("invalid color: must be one of %v", config.ColorPalette)
+		if !utils.IsValidColor(*color, s.palette) {
+			return nil, fmt.Errorf("invalid color: must be one of %v", s.palette)
 		}
 		category.Color = *color
 	}
-	return s.repo.Update(ctx, category)
+	updated, err := s.repo.Update(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "category updated", "category_id", id)
+	return updated, nil
 }
 
-func (s *categoryService) DeleteCategory(ctx context.Context, id uint) error {
-	return s.repo.Delete(ctx, id)
+func (s *categoryService) DeleteCategory(ctx context.Context, id uint) (err error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.DeleteCategory")
+	defer tracing.End(span, &err)
+	span.SetAttributes(attribute.Int64("category.id", int64(id)))
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "category deleted", "category_id", id)
+	return nil
 }
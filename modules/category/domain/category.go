@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"iter"
 	"time"
 
 	"github.com/ltphat2204/domain-driven-golang/common"
@@ -13,6 +14,9 @@ type Category struct {
 	Description string
 	Color       string    `gorm:"type:varchar(7)"`
 	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	// Populated only when the query has a search term.
+	SearchRank      float64 `gorm:"->;-:migration" json:",omitempty"`
+	SearchHighlight string  `gorm:"->;-:migration" json:",omitempty"`
 }
 
 type CategoryQuery struct {
@@ -22,10 +26,54 @@ type CategoryQuery struct {
 	SortOrder string
 }
 
+// SortByRelevance orders search results by rank and requires a search term.
+const SortByRelevance = "relevance"
+
+var CategorySortFields = []string{"name", "created_at", SortByRelevance}
+
+// EffectiveSort returns the sort actually applied to the query. Both SortBy
+// and SortOrder must be set to override the default of newest first, except
+// for relevance which is always best match first.
+func (q *CategoryQuery) EffectiveSort() (string, string) {
+	if q.SortBy == SortByRelevance {
+		return SortByRelevance, "desc"
+	}
+	if q.SortBy != "" && q.SortOrder != "" {
+		return q.SortBy, q.SortOrder
+	}
+	return "created_at", "desc"
+}
+
+// SupportsCursor reports whether the query's sort can be paginated by cursor.
+func (q *CategoryQuery) SupportsCursor() bool {
+	sortBy, _ := q.EffectiveSort()
+	return sortBy != SortByRelevance
+}
+
 type CategoryRepository interface {
 	Save(ctx context.Context, category *Category) (*Category, error)
 	FindByID(ctx context.Context, id uint) (*Category, error)
+	// FindByIDs returns the categories that exist among ids, in no
+	// particular order.
+	FindByIDs(ctx context.Context, ids []uint) ([]*Category, error)
 	FindCategories(ctx context.Context, query *CategoryQuery) ([]*Category, int, error)
+	// All yields every category in ID order, without loading them all at
+	// once.
+	All(ctx context.Context) iter.Seq2[*Category, error]
 	Update(ctx context.Context, category *Category) (*Category, error)
 	Delete(ctx context.Context, id uint) error
 }
+
+// SortKey returns the value of the given sort field as stored in a cursor.
+func (c *Category) SortKey(field string) *string {
+	var value string
+	switch field {
+	case "name":
+		value = c.Name
+	case "created_at":
+		value = c.CreatedAt.UTC().Format(time.RFC3339Nano)
+	default:
+		return nil
+	}
+	return &value
+}
@@ -1,8 +1,8 @@
 package dto
 
 import (
-	"github.com/ltphat2204/domain-driven-golang/modules/category/domain"
 	"github.com/ltphat2204/domain-driven-golang/common"
+	"github.com/ltphat2204/domain-driven-golang/modules/category/domain"
 )
 
 type CategoryCreateDTO struct {
@@ -17,11 +17,13 @@ type CategoryUpdateDTO struct {
 }
 
 type CategoryQueryDTO struct {
-	Page      int    `form:"page" binding:"omitempty,gte=1"`
-	PageSize  int    `form:"page_size" binding:"omitempty,gte=1"`
-	Search    string `form:"search"`
-	SortBy    string `form:"sort_by"`
-	SortOrder string `form:"sort_order"`
+	Page         int    `form:"page" binding:"omitempty,gte=1"`
+	PageSize     int    `form:"page_size" binding:"omitempty,gte=1"`
+	Search       string `form:"search"`
+	SortBy       string `form:"sort_by"`
+	SortOrder    string `form:"sort_order"`
+	Cursor       string `form:"cursor"`
+	IncludeTotal *bool  `form:"include_total"`
 }
 
 type CategoryListResponse struct {
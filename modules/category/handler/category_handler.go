@@ -23,13 +23,13 @@ func NewCategoryHandler(application application.CategoryService) *CategoryHandle
 func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 	var input dto.CategoryCreateDTO
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(err.Error()))
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), err.Error()))
 		return
 	}
 
 	category, err := h.application.CreateCategory(c.Request.Context(), input.Name, input.Description)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(http.StatusInternalServerError, "Failed to create category", err.Error()))
+		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(c.Request.Context(), http.StatusInternalServerError, "Failed to create category", err.Error()))
 		return
 	}
 
@@ -39,13 +39,13 @@ func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 func (h *CategoryHandler) GetCategory(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse("Invalid ID"))
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Invalid ID"))
 		return
 	}
 
 	category, err := h.application.GetCategoryByID(c.Request.Context(), uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, common.NewErrorResponse(http.StatusNotFound, "Category not found", err.Error()))
+		c.JSON(http.StatusNotFound, common.NewErrorResponse(c.Request.Context(), http.StatusNotFound, "Category not found", err.Error()))
 		return
 	}
 
@@ -55,7 +55,7 @@ func (h *CategoryHandler) GetCategory(c *gin.Context) {
 func (h *CategoryHandler) GetCategories(c *gin.Context) {
 	var queryDTO dto.CategoryQueryDTO
 	if err := c.ShouldBindQuery(&queryDTO); err != nil {
-		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(err.Error()))
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), err.Error()))
 		return
 	}
 
@@ -64,55 +64,77 @@ func (h *CategoryHandler) GetCategories(c *gin.Context) {
 	if queryDTO.Page > 0 {
 		page = queryDTO.Page
 	}
-	pageSize := 10
+	pageSize := common.DefaultPageSize()
 	if queryDTO.PageSize > 0 {
 		pageSize = queryDTO.PageSize
 	}
 
-	allowedSortFields := []string{"name", "created_at"}
 	allowedSortOrders := []string{"asc", "desc"}
 
-	if queryDTO.SortBy != "" && !slices.Contains(allowedSortFields, queryDTO.SortBy) {
-		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse("Invalid sort_by"))
+	if queryDTO.SortBy != "" && !slices.Contains(domain.CategorySortFields, queryDTO.SortBy) {
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Invalid sort_by"))
 		return
 	}
 
 	if queryDTO.SortOrder != "" && !slices.Contains(allowedSortOrders, queryDTO.SortOrder) {
-		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse("Invalid sort_order"))
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Invalid sort_order"))
+		return
+	}
+
+	if queryDTO.SortBy == domain.SortByRelevance && queryDTO.Search == "" {
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "sort_by=relevance requires search"))
 		return
 	}
 
 	query := &domain.CategoryQuery{
 		BaseQuery: common.BaseQuery{
-			Page:     page,
-			PageSize: pageSize,
+			Page:      page,
+			PageSize:  pageSize,
+			SkipTotal: queryDTO.IncludeTotal != nil && !*queryDTO.IncludeTotal,
 		},
 		Search:    queryDTO.Search,
 		SortBy:    queryDTO.SortBy,
 		SortOrder: queryDTO.SortOrder,
 	}
 
+	sortBy, sortOrder := query.EffectiveSort()
+	if queryDTO.Cursor != "" {
+		if !query.SupportsCursor() {
+			c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Cursor pagination is not supported with sort_by=relevance"))
+			return
+		}
+		cursor, err := common.DecodeCursor(queryDTO.Cursor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Invalid cursor"))
+			return
+		}
+		if cursor.SortBy != sortBy || cursor.SortOrder != sortOrder {
+			c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Cursor does not match sort_by and sort_order"))
+			return
+		}
+		query.Cursor = cursor
+	}
+
 	categories, total, err := h.application.GetCategories(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(http.StatusInternalServerError, "Failed to retrieve categories", err.Error()))
+		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(c.Request.Context(), http.StatusInternalServerError, "Failed to retrieve categories", err.Error()))
 		return
 	}
 
-	totalPages := 0
-	if pageSize > 0 {
-		totalPages = (total + pageSize - 1) / pageSize
-	}
-
-	meta := common.PaginationMeta{
-		Total:      total,
-		Page:       page,
-		PageSize:   pageSize,
-		TotalPages: totalPages,
+	var last *common.Cursor
+	if len(categories) > 0 && query.SupportsCursor() {
+		lastCategory := categories[len(categories)-1]
+		last = &common.Cursor{
+			SortBy:    sortBy,
+			SortOrder: sortOrder,
+			Value:     lastCategory.SortKey(sortBy),
+			ID:        lastCategory.ID,
+		}
 	}
 
 	response := dto.CategoryListResponse{
 		Categories: categories,
-		Meta:       meta,
+		Meta:       common.NewPaginationMeta(query.BaseQuery, total, last),
 	}
 
 	c.JSON(http.StatusOK, common.NewSuccessResponse(response))
@@ -121,19 +143,19 @@ func (h *CategoryHandler) GetCategories(c *gin.Context) {
 func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse("Invalid ID"))
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Invalid ID"))
 		return
 	}
 
 	var input dto.CategoryUpdateDTO
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(err.Error()))
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), err.Error()))
 		return
 	}
 
 	category, err := h.application.UpdateCategory(c.Request.Context(), uint(id), input.Name, input.Description, input.Color)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, common.NewErrorResponse(http.StatusBadRequest, "Failed to update category", err.Error()))
+		c.JSON(http.StatusBadRequest, common.NewErrorResponse(c.Request.Context(), http.StatusBadRequest, "Failed to update category", err.Error()))
 		return
 	}
 
@@ -143,12 +165,12 @@ func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse("Invalid ID"))
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Invalid ID"))
 		return
 	}
 
 	if err := h.application.DeleteCategory(c.Request.Context(), uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(http.StatusInternalServerError, "Failed to delete category", err.Error()))
+		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(c.Request.Context(), http.StatusInternalServerError, "Failed to delete category", err.Error()))
 		return
 	}
 
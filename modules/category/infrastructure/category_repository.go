@@ -2,21 +2,34 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"iter"
 
+	"github.com/ltphat2204/domain-driven-golang/database"
+	"github.com/ltphat2204/domain-driven-golang/fulltext"
 	"github.com/ltphat2204/domain-driven-golang/modules/category/domain"
 	"gorm.io/gorm"
 )
 
+// SearchDocument describes the columns covered by free-text search.
+var SearchDocument = fulltext.Document{
+	Table:   "categories",
+	Columns: []string{"name", "description"},
+	Vector:  "search_vector",
+}
+
 type categoryRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	search fulltext.Engine
 }
 
 func NewCategoryRepository(db *gorm.DB) domain.CategoryRepository {
-	return &categoryRepository{db: db}
+	return &categoryRepository{db: db, search: fulltext.ForDB(db)}
 }
 
 func (r *categoryRepository) Save(ctx context.Context, category *domain.Category) (*domain.Category, error) {
-	result := r.db.WithContext(ctx).Create(category)
+	result := database.Conn(ctx, r.db).Create(category)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -25,52 +38,108 @@ func (r *categoryRepository) Save(ctx context.Context, category *domain.Category
 
 func (r *categoryRepository) FindByID(ctx context.Context, id uint) (*domain.Category, error) {
 	var category domain.Category
-	result := r.db.WithContext(ctx).First(&category, id)
+	result := database.Conn(ctx, r.db).First(&category, id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return &category, nil
 }
 
+func (r *categoryRepository) FindByIDs(ctx context.Context, ids []uint) ([]*domain.Category, error) {
+	var categories []*domain.Category
+	if len(ids) == 0 {
+		return categories, nil
+	}
+	if err := database.Conn(ctx, r.db).Where("id IN ?", ids).Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+func (r *categoryRepository) All(ctx context.Context) iter.Seq2[*domain.Category, error] {
+	return database.Batches(database.Conn(ctx, r.db), func(category *domain.Category) uint { return category.ID })
+}
+
+var categorySortColumns = map[string]database.KeysetColumn{
+	"name":       {Name: "name"},
+	"created_at": {Name: "created_at", Time: true},
+}
+
 func (r *categoryRepository) FindCategories(ctx context.Context, query *domain.CategoryQuery) ([]*domain.Category, int, error) {
-	db := r.db.WithContext(ctx).Model(&domain.Category{})
+	db := database.Conn(ctx, r.db).Model(&domain.Category{})
 
 	if query.Search != "" {
-		db = db.Where("name LIKE ? OR description LIKE ?", "%"+query.Search+"%", "%"+query.Search+"%")
+		db = r.search.Match(db, SearchDocument, query.Search)
 	}
 
 	var total int64
-	db.Count(&total)
+	if !query.SkipTotal {
+		if err := db.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if query.Search != "" {
+		db = r.search.Annotate(db, SearchDocument, query.Search)
+	}
 
 	var categories []*domain.Category
-	dbQuery := db
-	if query.SortBy != "" && query.SortOrder != "" {
-		dbQuery = db.Order(query.SortBy + " " + query.SortOrder)
-	} else {
-		dbQuery = db.Order("created_at desc")
+	var dbQuery *gorm.DB
+	sortBy, sortOrder := query.EffectiveSort()
+	column, ok := categorySortColumns[sortBy]
+	switch {
+	case sortBy == domain.SortByRelevance:
+		if query.Search == "" {
+			return nil, 0, fmt.Errorf("sorting by relevance requires a search term")
+		}
+		if query.Cursor != nil {
+			return nil, 0, fmt.Errorf("cursor pagination is not supported when sorting by relevance")
+		}
+		dbQuery = r.search.OrderByRank(db, SearchDocument, query.Search)
+	case ok:
+		dbQuery = db.Scopes(database.OrderBy(column, sortOrder))
+	default:
+		return nil, 0, fmt.Errorf("unsupported sort field: %s", sortBy)
 	}
 
-	if query.Page > 0 && query.PageSize > 0 {
-		offset := (query.Page - 1) * query.PageSize
-		dbQuery = dbQuery.Offset(offset).Limit(query.PageSize)
+	if query.Cursor != nil {
+		after, err := database.After(column, sortOrder, query.Cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+		dbQuery = dbQuery.Scopes(after)
 	}
 
-	if err := dbQuery.Find(&categories).Error; err != nil {
+	if err := dbQuery.Scopes(database.Window(&query.BaseQuery)).Find(&categories).Error; err != nil {
 		return nil, 0, err
 	}
+	categories = database.Trim(categories, &query.BaseQuery)
+
+	if query.Search != "" {
+		for _, category := range categories {
+			if category.SearchHighlight == "" {
+				category.SearchHighlight = fulltext.Highlight(query.Search, category.Name+" "+category.Description)
+			}
+		}
+	}
 
 	return categories, int(total), nil
 }
 
 func (r *categoryRepository) Update(ctx context.Context, category *domain.Category) (*domain.Category, error) {
-	result := r.db.WithContext(ctx).Save(category)
+	result := database.Conn(ctx, r.db).Save(category)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return category, nil
 }
 
+// Delete fails with gorm.ErrForeignKeyViolated while tasks refer to the
+// category.
 func (r *categoryRepository) Delete(ctx context.Context, id uint) error {
-	result := r.db.WithContext(ctx).Delete(&domain.Category{}, id)
-	return result.Error
+	err := database.Conn(ctx, r.db).Delete(&domain.Category{}, id).Error
+	if translator, ok := r.db.Dialector.(gorm.ErrorTranslator); ok && errors.Is(translator.Translate(err), gorm.ErrForeignKeyViolated) {
+		return fmt.Errorf("category %d is still referenced: %w", id, gorm.ErrForeignKeyViolated)
+	}
+	return err
 }
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/ltphat2204/domain-driven-golang/database/repotest"
+	taskRepository "github.com/ltphat2204/domain-driven-golang/modules/task/infrastructure"
+)
+
+func TestCategoryRepository(t *testing.T) {
+	t.Run("Memory", func(t *testing.T) {
+		repotest.RunCategoryRepository(t, func(t *testing.T) repotest.TaskRepositories {
+			categories := NewMemoryCategoryRepository()
+			return repotest.TaskRepositories{Tasks: taskRepository.NewMemoryTaskRepository(categories), Categories: categories}
+		})
+	})
+
+	t.Run("SQLite", func(t *testing.T) {
+		repotest.RunCategoryRepository(t, func(t *testing.T) repotest.TaskRepositories {
+			db := repotest.OpenSQLite(t)
+			return repotest.TaskRepositories{Tasks: taskRepository.NewTaskRepository(db), Categories: NewCategoryRepository(db)}
+		})
+	})
+
+	t.Run("Postgres", func(t *testing.T) {
+		repotest.RunCategoryRepository(t, func(t *testing.T) repotest.TaskRepositories {
+			db := repotest.OpenPostgres(t)
+			return repotest.TaskRepositories{Tasks: taskRepository.NewTaskRepository(db), Categories: NewCategoryRepository(db)}
+		})
+	})
+}
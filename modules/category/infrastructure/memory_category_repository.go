@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ltphat2204/domain-driven-golang/database"
+	"github.com/ltphat2204/domain-driven-golang/fulltext"
+	"github.com/ltphat2204/domain-driven-golang/modules/category/domain"
+	"gorm.io/gorm"
+)
+
+// memoryCategoryRepository keeps categories in a map. It mirrors the search,
+// sort and pagination behaviour of the GORM repository so it can stand in for
+// it in development and tests.
+type memoryCategoryRepository struct {
+	mu         sync.RWMutex
+	nextID     uint
+	categories map[uint]domain.Category
+	// referrers report whether something refers to a category, which
+	// Delete then refuses as the foreign keys do in SQL.
+	referrers []func(id uint) bool
+}
+
+func NewMemoryCategoryRepository() domain.CategoryRepository {
+	return &memoryCategoryRepository{categories: make(map[uint]domain.Category)}
+}
+
+func (r *memoryCategoryRepository) Save(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if category.ID == 0 {
+		r.nextID++
+		category.ID = r.nextID
+	} else if _, exists := r.categories[category.ID]; exists {
+		return nil, fmt.Errorf("category %d already exists", category.ID)
+	} else {
+		r.nextID = max(r.nextID, category.ID)
+	}
+	if category.CreatedAt.IsZero() {
+		category.CreatedAt = time.Now()
+	}
+	r.categories[category.ID] = stored(category)
+	return category, nil
+}
+
+func (r *memoryCategoryRepository) FindByID(ctx context.Context, id uint) (*domain.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	category, ok := r.categories[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &category, nil
+}
+
+func (r *memoryCategoryRepository) FindByIDs(ctx context.Context, ids []uint) ([]*domain.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var categories []*domain.Category
+	for _, id := range ids {
+		if category, ok := r.categories[id]; ok {
+			categories = append(categories, &category)
+		}
+	}
+	return categories, nil
+}
+
+func (r *memoryCategoryRepository) FindCategories(ctx context.Context, query *domain.CategoryQuery) ([]*domain.Category, int, error) {
+	sortBy, sortOrder := query.EffectiveSort()
+	column, ok := categorySortColumns[sortBy]
+	switch {
+	case sortBy == domain.SortByRelevance:
+		if query.Search == "" {
+			return nil, 0, fmt.Errorf("sorting by relevance requires a search term")
+		}
+		if query.Cursor != nil {
+			return nil, 0, fmt.Errorf("cursor pagination is not supported when sorting by relevance")
+		}
+	case !ok:
+		return nil, 0, fmt.Errorf("unsupported sort field: %s", sortBy)
+	}
+	if query.Cursor != nil {
+		if err := database.CheckCursor(column, query.Cursor); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	r.mu.RLock()
+	var categories []*domain.Category
+	for _, c := range r.categories {
+		category := c
+		if query.Search != "" {
+			category.SearchRank = fulltext.Score(query.Search, category.Name, category.Description)
+			if category.SearchRank == 0 {
+				continue
+			}
+		}
+		categories = append(categories, &category)
+	}
+	r.mu.RUnlock()
+
+	if sortBy == domain.SortByRelevance {
+		slices.SortFunc(categories, func(a, b *domain.Category) int {
+			if c := cmp.Compare(b.SearchRank, a.SearchRank); c != 0 {
+				return c
+			}
+			return cmp.Compare(b.ID, a.ID)
+		})
+	} else {
+		slices.SortFunc(categories, func(a, b *domain.Category) int {
+			return database.Compare(column, sortOrder, a.SortKey(sortBy), a.ID, b.SortKey(sortBy), b.ID)
+		})
+	}
+
+	total := 0
+	if !query.SkipTotal {
+		total = len(categories)
+	}
+
+	categories = database.Paginate(categories, &query.BaseQuery, func(c *domain.Category) bool {
+		return database.Compare(column, sortOrder, c.SortKey(sortBy), c.ID, query.Cursor.Value, query.Cursor.ID) > 0
+	})
+
+	if query.Search != "" {
+		for _, category := range categories {
+			category.SearchHighlight = fulltext.Highlight(query.Search, category.Name+" "+category.Description)
+		}
+	}
+
+	return categories, total, nil
+}
+
+// All yields a copy of the categories taken when iteration starts, so the
+// caller may write to the repository as it goes.
+func (r *memoryCategoryRepository) All(ctx context.Context) iter.Seq2[*domain.Category, error] {
+	return func(yield func(*domain.Category, error) bool) {
+		r.mu.RLock()
+		categories := slices.SortedFunc(maps.Values(r.categories), func(a, b domain.Category) int { return cmp.Compare(a.ID, b.ID) })
+		r.mu.RUnlock()
+		for _, category := range categories {
+			if !yield(&category, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (r *memoryCategoryRepository) Update(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if category.ID == 0 {
+		r.nextID++
+		category.ID = r.nextID
+	}
+	r.nextID = max(r.nextID, category.ID)
+	if category.CreatedAt.IsZero() {
+		category.CreatedAt = time.Now()
+	}
+	r.categories[category.ID] = stored(category)
+	return category, nil
+}
+
+// Snapshot implements database.Snapshotter, so a memory transactor can roll
+// back the categories.
+func (r *memoryCategoryRepository) Snapshot() func() {
+	r.mu.RLock()
+	nextID, categories := r.nextID, maps.Clone(r.categories)
+	r.mu.RUnlock()
+	return func() {
+		r.mu.Lock()
+		r.nextID, r.categories = nextID, categories
+		r.mu.Unlock()
+	}
+}
+
+// AddReferrer makes Delete fail with gorm.ErrForeignKeyViolated while
+// referenced reports a category in use. The memory task repository registers
+// itself here.
+func (r *memoryCategoryRepository) AddReferrer(referenced func(id uint) bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.referrers = append(r.referrers, referenced)
+}
+
+func (r *memoryCategoryRepository) Delete(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, referenced := range r.referrers {
+		if referenced(id) {
+			return fmt.Errorf("category %d is still referenced: %w", id, gorm.ErrForeignKeyViolated)
+		}
+	}
+	delete(r.categories, id)
+	return nil
+}
+
+// stored returns the copy of category that is kept in the map, without the
+// per-query search fields.
+func stored(category *domain.Category) domain.Category {
+	c := *category
+	c.SearchRank = 0
+	c.SearchHighlight = ""
+	return c
+}
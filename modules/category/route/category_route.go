@@ -1,8 +1,13 @@
 package route
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/ltphat2204/domain-driven-golang/modules/category/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/category/dto"
 	"github.com/ltphat2204/domain-driven-golang/modules/category/handler"
+	"github.com/ltphat2204/domain-driven-golang/openapi"
 )
 
 func SetupRoutes(r *gin.Engine, categoryHandler *handler.CategoryHandler) {
@@ -12,3 +17,25 @@ func SetupRoutes(r *gin.Engine, categoryHandler *handler.CategoryHandler) {
 	r.PATCH("/categories/:id", categoryHandler.UpdateCategory)
 	r.DELETE("/categories/:id", categoryHandler.DeleteCategory)
 }
+
+// Operations documents the routes SetupRoutes registers.
+func Operations() []openapi.Operation {
+	tags := []string{"categories"}
+	return []openapi.Operation{
+		{Method: "POST", Path: "/categories", ID: "createCategory", Summary: "Create a category", Tags: tags,
+			Body: dto.CategoryCreateDTO{}, Response: domain.Category{},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError}},
+		{Method: "GET", Path: "/categories/:id", ID: "getCategory", Summary: "Get a category", Tags: tags,
+			Response: domain.Category{},
+			Errors:   []int{http.StatusBadRequest, http.StatusNotFound}},
+		{Method: "GET", Path: "/categories", ID: "listCategories", Summary: "List and search categories", Tags: tags,
+			Query: dto.CategoryQueryDTO{}, Response: dto.CategoryListResponse{},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError}},
+		{Method: "PATCH", Path: "/categories/:id", ID: "updateCategory", Summary: "Update a category", Tags: tags,
+			Body: dto.CategoryUpdateDTO{}, Response: domain.Category{},
+			Errors: []int{http.StatusBadRequest}},
+		{Method: "DELETE", Path: "/categories/:id", ID: "deleteCategory", Summary: "Delete a category", Tags: tags,
+			Response: "",
+			Errors:   []int{http.StatusBadRequest, http.StatusInternalServerError}},
+	}
+}
@@ -0,0 +1,168 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+
+	"github.com/ltphat2204/domain-driven-golang/common"
+	"github.com/ltphat2204/domain-driven-golang/modules/category/application"
+	"github.com/ltphat2204/domain-driven-golang/modules/category/domain"
+	pb "github.com/ltphat2204/domain-driven-golang/proto/taskmanager/v1"
+)
+
+type CategoryServer struct {
+	pb.UnimplementedCategoryServiceServer
+	service application.CategoryService
+}
+
+func NewCategoryServer(service application.CategoryService) *CategoryServer {
+	return &CategoryServer{service: service}
+}
+
+func (s *CategoryServer) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.Category, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	category, err := s.service.CreateCategory(ctx, req.GetName(), req.GetDescription())
+	if err != nil {
+		return nil, serviceError(err, codes.Internal, "failed to create category")
+	}
+	return ToProto(category), nil
+}
+
+func (s *CategoryServer) GetCategory(ctx context.Context, req *pb.GetCategoryRequest) (*pb.Category, error) {
+	category, err := s.service.GetCategoryByID(ctx, uint(req.GetId()))
+	if err != nil {
+		return nil, serviceError(err, codes.Internal, "failed to get category")
+	}
+	return ToProto(category), nil
+}
+
+func (s *CategoryServer) ListCategories(ctx context.Context, req *pb.ListCategoriesRequest) (*pb.ListCategoriesResponse, error) {
+	if req.GetPage() < 0 || req.GetPageSize() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page and page_size must not be negative")
+	}
+	if req.GetSortBy() != "" && !slices.Contains(domain.CategorySortFields, req.GetSortBy()) {
+		return nil, status.Error(codes.InvalidArgument, "invalid sort_by")
+	}
+	if req.GetSortOrder() != "" && req.GetSortOrder() != "asc" && req.GetSortOrder() != "desc" {
+		return nil, status.Error(codes.InvalidArgument, "invalid sort_order")
+	}
+	if req.GetSortBy() == domain.SortByRelevance && req.GetSearch() == "" {
+		return nil, status.Error(codes.InvalidArgument, "sort_by=relevance requires search")
+	}
+
+	query := &domain.CategoryQuery{
+		BaseQuery: common.BaseQuery{
+			Page:      max(int(req.GetPage()), 1),
+			PageSize:  int(req.GetPageSize()),
+			SkipTotal: req.IncludeTotal != nil && !req.GetIncludeTotal(),
+		},
+		Search:    req.GetSearch(),
+		SortBy:    req.GetSortBy(),
+		SortOrder: req.GetSortOrder(),
+	}
+	if query.PageSize == 0 {
+		query.PageSize = common.DefaultPageSize()
+	}
+
+	sortBy, sortOrder := query.EffectiveSort()
+	if req.GetCursor() != "" {
+		if !query.SupportsCursor() {
+			return nil, status.Error(codes.InvalidArgument, "cursor pagination is not supported with sort_by=relevance")
+		}
+		cursor, err := common.DecodeCursor(req.GetCursor())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid cursor")
+		}
+		if cursor.SortBy != sortBy || cursor.SortOrder != sortOrder {
+			return nil, status.Error(codes.InvalidArgument, "cursor does not match sort_by and sort_order")
+		}
+		query.Cursor = cursor
+	}
+
+	categories, total, err := s.service.GetCategories(ctx, query)
+	if err != nil {
+		return nil, serviceError(err, codes.Internal, "failed to list categories")
+	}
+
+	var last *common.Cursor
+	if len(categories) > 0 && query.SupportsCursor() {
+		lastCategory := categories[len(categories)-1]
+		last = &common.Cursor{SortBy: sortBy, SortOrder: sortOrder, Value: lastCategory.SortKey(sortBy), ID: lastCategory.ID}
+	}
+	response := &pb.ListCategoriesResponse{
+		Categories: make([]*pb.Category, len(categories)),
+		PageInfo:   PageInfo(common.NewPaginationMeta(query.BaseQuery, total, last)),
+	}
+	for i, category := range categories {
+		response.Categories[i] = ToProto(category)
+	}
+	return response, nil
+}
+
+func (s *CategoryServer) UpdateCategory(ctx context.Context, req *pb.UpdateCategoryRequest) (*pb.Category, error) {
+	category, err := s.service.UpdateCategory(ctx, uint(req.GetId()), req.Name, req.Description, req.Color)
+	if err != nil {
+		// As with the REST API, other failures are blamed on the input, such
+		// as a color outside the palette.
+		return nil, serviceError(err, codes.InvalidArgument, "failed to update category")
+	}
+	return ToProto(category), nil
+}
+
+func (s *CategoryServer) DeleteCategory(ctx context.Context, req *pb.DeleteCategoryRequest) (*pb.DeleteCategoryResponse, error) {
+	if err := s.service.DeleteCategory(ctx, uint(req.GetId())); err != nil {
+		return nil, serviceError(err, codes.Internal, "failed to delete category")
+	}
+	return &pb.DeleteCategoryResponse{}, nil
+}
+
+// serviceError maps a service error to a gRPC status: NotFound for missing
+// records, code otherwise.
+func serviceError(err error, code codes.Code, message string) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return status.Error(codes.NotFound, "category not found")
+	}
+	return status.Errorf(code, "%s: %v", message, err)
+}
+
+func ToProto(category *domain.Category) *pb.Category {
+	if category == nil {
+		return nil
+	}
+	return &pb.Category{
+		Id:              uint32(category.ID),
+		Name:            category.Name,
+		Description:     category.Description,
+		Color:           category.Color,
+		CreatedAt:       timestamppb.New(category.CreatedAt),
+		SearchRank:      category.SearchRank,
+		SearchHighlight: category.SearchHighlight,
+	}
+}
+
+// PageInfo converts the pagination meta shared with the REST API.
+func PageInfo(meta common.PaginationMeta) *pb.PageInfo {
+	info := &pb.PageInfo{
+		Page:       int32(meta.Page),
+		PageSize:   int32(meta.PageSize),
+		HasMore:    meta.HasMore,
+		NextCursor: meta.NextCursor,
+	}
+	if meta.Total != nil {
+		total := int32(*meta.Total)
+		info.Total = &total
+	}
+	if meta.TotalPages != nil {
+		pages := int32(*meta.TotalPages)
+		info.TotalPages = &pages
+	}
+	return info
+}
@@ -0,0 +1,111 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ltphat2204/domain-driven-golang/modules/health/domain"
+)
+
+// CheckTimeout bounds how long a readiness check may take before it fails.
+const CheckTimeout = 2 * time.Second
+
+type HealthService interface {
+	// Liveness reports whether the process is running; it never checks
+	// dependencies, so a database outage does not get the process restarted.
+	Liveness(ctx context.Context) *domain.Report
+	// Readiness runs every dependency check and fails while shutting down.
+	Readiness(ctx context.Context) *domain.Report
+	// SetShuttingDown makes readiness fail from now on.
+	SetShuttingDown()
+}
+
+type healthService struct {
+	checkers     []domain.Checker
+	shuttingDown atomic.Bool
+}
+
+// NewHealthService runs the given checkers on every readiness request.
+func NewHealthService(checkers ...domain.Checker) HealthService {
+	return &healthService{checkers: checkers}
+}
+
+func (s *healthService) Liveness(ctx context.Context) *domain.Report {
+	return newReport(map[string]*domain.Component{
+		"process": {Status: domain.StatusOK},
+	})
+}
+
+func (s *healthService) Readiness(ctx context.Context) *domain.Report {
+	components := make(map[string]*domain.Component, len(s.checkers)+1)
+	components["shutdown"] = &domain.Component{Status: domain.StatusOK}
+	if s.shuttingDown.Load() {
+		components["shutdown"] = &domain.Component{Status: domain.StatusFail, Error: "shutting down"}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, CheckTimeout)
+	defer cancel()
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, checker := range s.checkers {
+		wg.Add(1)
+		go func(checker domain.Checker) {
+			defer wg.Done()
+			component := runCheck(ctx, checker)
+			mu.Lock()
+			components[checker.Name] = component
+			mu.Unlock()
+		}(checker)
+	}
+	wg.Wait()
+
+	return newReport(components)
+}
+
+func (s *healthService) SetShuttingDown() {
+	s.shuttingDown.Store(true)
+}
+
+// runCheck runs a checker, failing it when it does not return before ctx
+// ends.
+func runCheck(ctx context.Context, checker domain.Checker) *domain.Component {
+	type outcome struct {
+		details interface{}
+		err     error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		details, err := checker.Check(ctx)
+		done <- outcome{details, err}
+	}()
+
+	var result outcome
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		result.err = errors.New("check timed out")
+	}
+
+	component := &domain.Component{Status: domain.StatusOK, Details: result.details}
+	if result.err != nil {
+		component.Status = domain.StatusFail
+		component.Error = result.err.Error()
+	}
+	return component
+}
+
+func newReport(components map[string]*domain.Component) *domain.Report {
+	report := &domain.Report{Status: domain.StatusOK, Components: components}
+	for _, component := range components {
+		if component.Status != domain.StatusOK {
+			report.Status = domain.StatusFail
+		}
+	}
+	return report
+}
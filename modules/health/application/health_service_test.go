@@ -0,0 +1,55 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ltphat2204/domain-driven-golang/modules/health/domain"
+)
+
+func TestReadiness(t *testing.T) {
+	ok := domain.Checker{Name: "ok", Check: func(ctx context.Context) (interface{}, error) {
+		return map[string]int{"pending": 0}, nil
+	}}
+	broken := domain.Checker{Name: "broken", Check: func(ctx context.Context) (interface{}, error) {
+		return nil, errors.New("connection refused")
+	}}
+	hung := domain.Checker{Name: "hung", Check: func(ctx context.Context) (interface{}, error) {
+		time.Sleep(CheckTimeout + time.Second)
+		return nil, nil
+	}}
+
+	service := NewHealthService(ok)
+	if report := service.Readiness(context.Background()); !report.Healthy() || report.Components["ok"].Details == nil {
+		t.Errorf("readiness with passing checks = %+v", report)
+	}
+
+	service.SetShuttingDown()
+	report := service.Readiness(context.Background())
+	if report.Healthy() || report.Components["shutdown"].Status != domain.StatusFail {
+		t.Errorf("readiness while shutting down = %+v, want shutdown failing", report)
+	}
+	if !service.Liveness(context.Background()).Healthy() {
+		t.Error("liveness failed while shutting down")
+	}
+
+	start := time.Now()
+	report = NewHealthService(ok, broken, hung).Readiness(context.Background())
+	if elapsed := time.Since(start); elapsed > CheckTimeout+500*time.Millisecond {
+		t.Errorf("readiness took %s, want at most about %s", elapsed, CheckTimeout)
+	}
+	if report.Healthy() {
+		t.Error("readiness passed with failing checks")
+	}
+	if c := report.Components["broken"]; c.Status != domain.StatusFail || c.Error != "connection refused" {
+		t.Errorf("broken = %+v", c)
+	}
+	if c := report.Components["hung"]; c.Status != domain.StatusFail || c.Error != "check timed out" {
+		t.Errorf("hung = %+v", c)
+	}
+	if c := report.Components["ok"]; c.Status != domain.StatusOK {
+		t.Errorf("ok = %+v", c)
+	}
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "context"
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// Checker checks one dependency. Details is reported as-is and may be nil;
+// a non-nil error fails the check.
+type Checker struct {
+	Name  string
+	Check func(ctx context.Context) (details interface{}, err error)
+}
+
+// Component is the outcome of one check.
+type Component struct {
+	Status  string      `json:"status"`
+	Error   string      `json:"error,omitempty"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// Report is the overall status and the status of every component. It fails
+// when any component fails.
+type Report struct {
+	Status     string                `json:"status"`
+	Components map[string]*Component `json:"components"`
+}
+
+func (r *Report) Healthy() bool {
+	return r.Status == StatusOK
+}
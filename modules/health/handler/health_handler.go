@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ltphat2204/domain-driven-golang/modules/health/application"
+	"github.com/ltphat2204/domain-driven-golang/modules/health/domain"
+)
+
+type HealthHandler struct {
+	service application.HealthService
+}
+
+func NewHealthHandler(service application.HealthService) *HealthHandler {
+	return &HealthHandler{service: service}
+}
+
+// Probe responses are the bare report rather than the usual envelope, so
+// orchestrators and people can read them at a glance.
+
+func (h *HealthHandler) Healthz(c *gin.Context) {
+	respond(c, h.service.Liveness(c.Request.Context()))
+}
+
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	respond(c, h.service.Readiness(c.Request.Context()))
+}
+
+func respond(c *gin.Context, report *domain.Report) {
+	status := http.StatusOK
+	if !report.Healthy() {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
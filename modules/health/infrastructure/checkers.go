@@ -0,0 +1,61 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/ltphat2204/domain-driven-golang/migrations"
+	"github.com/ltphat2204/domain-driven-golang/modules/health/domain"
+	"github.com/ltphat2204/domain-driven-golang/worker"
+)
+
+// DatabaseChecker pings the database through the GORM connection pool and
+// reports the pool's usage.
+func DatabaseChecker(db *gorm.DB) domain.Checker {
+	return domain.Checker{Name: "database", Check: func(ctx context.Context) (interface{}, error) {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		if err := sqlDB.PingContext(ctx); err != nil {
+			return nil, err
+		}
+		stats := sqlDB.Stats()
+		return map[string]int{
+			"open_connections": stats.OpenConnections,
+			"in_use":           stats.InUse,
+			"idle":             stats.Idle,
+		}, nil
+	}}
+}
+
+// MigrationsChecker fails while the database has pending migrations.
+func MigrationsChecker(migrator *migrations.Migrator) domain.Checker {
+	return domain.Checker{Name: "migrations", Check: func(ctx context.Context) (interface{}, error) {
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			return nil, err
+		}
+		details := map[string]int{"pending": len(pending)}
+		if len(pending) > 0 {
+			return details, fmt.Errorf("%d pending migration(s), starting with %04d_%s", len(pending), pending[0].Version, pending[0].Name)
+		}
+		return details, nil
+	}}
+}
+
+// WorkersChecker reports every background worker and fails when one has
+// failed.
+func WorkersChecker(workers *worker.Group) domain.Checker {
+	return domain.Checker{Name: "workers", Check: func(ctx context.Context) (interface{}, error) {
+		statuses := workers.Status()
+		for _, status := range statuses {
+			if status.State == worker.StateFailed {
+				return statuses, fmt.Errorf("worker %s failed", status.Name)
+			}
+		}
+		return statuses, nil
+	}}
+}
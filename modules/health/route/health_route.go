@@ -0,0 +1,28 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ltphat2204/domain-driven-golang/modules/health/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/health/handler"
+	"github.com/ltphat2204/domain-driven-golang/openapi"
+)
+
+func SetupRoutes(r *gin.Engine, healthHandler *handler.HealthHandler) {
+	r.GET("/healthz", healthHandler.Healthz)
+	r.GET("/readyz", healthHandler.Readyz)
+}
+
+// Operations documents the routes SetupRoutes registers. Probes answer with
+// the bare report, 503 when it is failing.
+func Operations() []openapi.Operation {
+	tags := []string{"health"}
+	statuses := []int{http.StatusOK, http.StatusServiceUnavailable}
+	return []openapi.Operation{
+		{Method: "GET", Path: "/healthz", ID: "liveness", Summary: "Liveness probe", Tags: tags,
+			Response: domain.Report{}, Raw: true, Statuses: statuses},
+		{Method: "GET", Path: "/readyz", ID: "readiness", Summary: "Readiness probe", Tags: tags,
+			Response: domain.Report{}, Raw: true, Statuses: statuses},
+	}
+}
@@ -0,0 +1,105 @@
+package rpc
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/ltphat2204/domain-driven-golang/modules/health/application"
+)
+
+// LivenessService is the service name whose status is the liveness report
+// rather than readiness.
+const LivenessService = "liveness"
+
+// watchInterval is how often Watch re-runs the checks.
+const watchInterval = 5 * time.Second
+
+// HealthServer implements the gRPC health protocol over the same checks as
+// /health/ready. The empty service name and every registered service are
+// SERVING while the process is ready; "liveness" reports /health/live.
+type HealthServer struct {
+	healthpb.UnimplementedHealthServer
+	service  application.HealthService
+	services []string
+	shutdown chan struct{}
+	once     sync.Once
+}
+
+func NewHealthServer(service application.HealthService, services ...string) *HealthServer {
+	return &HealthServer{service: service, services: services, shutdown: make(chan struct{})}
+}
+
+// Shutdown tells watchers the server is NOT_SERVING and ends their streams,
+// so they do not hold up a graceful stop.
+func (s *HealthServer) Shutdown() {
+	s.once.Do(func() { close(s.shutdown) })
+}
+
+func (s *HealthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if !s.known(req.GetService()) {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+	return &healthpb.HealthCheckResponse{Status: s.status(ctx, req.GetService())}, nil
+}
+
+func (s *HealthServer) List(ctx context.Context, req *healthpb.HealthListRequest) (*healthpb.HealthListResponse, error) {
+	response := &healthpb.HealthListResponse{Statuses: make(map[string]*healthpb.HealthCheckResponse)}
+	for _, name := range append([]string{"", LivenessService}, s.services...) {
+		response.Statuses[name] = &healthpb.HealthCheckResponse{Status: s.status(ctx, name)}
+	}
+	return response, nil
+}
+
+// Watch sends the status straight away and again whenever it changes.
+// Unknown services are reported as SERVICE_UNKNOWN, as the protocol asks.
+func (s *HealthServer) Watch(req *healthpb.HealthCheckRequest, stream grpc.ServerStreamingServer[healthpb.HealthCheckResponse]) error {
+	ctx := stream.Context()
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_ServingStatus(-1)
+	for {
+		current := healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		if s.known(req.GetService()) {
+			current = s.status(ctx, req.GetService())
+		}
+		if current != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: current}); err != nil {
+				return err
+			}
+			last = current
+		}
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-s.shutdown:
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING}); err != nil {
+				return err
+			}
+			return status.Error(codes.Unavailable, "server is shutting down")
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *HealthServer) known(name string) bool {
+	return name == "" || name == LivenessService || slices.Contains(s.services, name)
+}
+
+func (s *HealthServer) status(ctx context.Context, name string) healthpb.HealthCheckResponse_ServingStatus {
+	report := s.service.Readiness
+	if name == LivenessService {
+		report = s.service.Liveness
+	}
+	if report(ctx).Healthy() {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
@@ -0,0 +1,134 @@
+package application
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/ltphat2204/domain-driven-golang/common"
+	categoryApplication "github.com/ltphat2204/domain-driven-golang/modules/category/application"
+	categoryDomain "github.com/ltphat2204/domain-driven-golang/modules/category/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/search/domain"
+	taskApplication "github.com/ltphat2204/domain-driven-golang/modules/task/application"
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+)
+
+type SearchService interface {
+	Search(ctx context.Context, query *domain.SearchQuery) (*domain.Result, error)
+}
+
+// provider searches one entity type and returns its hits and total matches.
+type provider func(ctx context.Context, text string, limit int) ([]*domain.Hit, int, error)
+
+type searchService struct {
+	providers map[domain.EntityType]provider
+}
+
+// NewSearchService searches every entity through its own application
+// service, so each keeps its own search, ranking and preloading rules.
+func NewSearchService(tasks taskApplication.TaskService, categories categoryApplication.CategoryService) SearchService {
+	return &searchService{
+		providers: map[domain.EntityType]provider{
+			domain.EntityTask:     searchTasks(tasks),
+			domain.EntityCategory: searchCategories(categories),
+		},
+	}
+}
+
+func (s *searchService) Search(ctx context.Context, query *domain.SearchQuery) (*domain.Result, error) {
+	types := query.Types
+	if len(types) == 0 {
+		types = domain.EntityTypes
+	}
+
+	type outcome struct {
+		hits  []*domain.Hit
+		total int
+		err   error
+	}
+	outcomes := make([]outcome, len(types))
+
+	var wg sync.WaitGroup
+	for i, entityType := range types {
+		search, ok := s.providers[entityType]
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, search provider) {
+			defer wg.Done()
+			hits, total, err := search(ctx, query.Text, query.Limit)
+			outcomes[i] = outcome{hits: hits, total: total, err: err}
+		}(i, search)
+	}
+	wg.Wait()
+
+	result := &domain.Result{
+		Hits:   []*domain.Hit{},
+		Facets: map[domain.EntityType]int{},
+	}
+	for i, o := range outcomes {
+		if o.err != nil {
+			return nil, o.err
+		}
+		result.Hits = append(result.Hits, o.hits...)
+		result.Facets[types[i]] = o.total
+	}
+
+	sort.SliceStable(result.Hits, func(i, j int) bool {
+		return result.Hits[i].Rank > result.Hits[j].Rank
+	})
+	return result, nil
+}
+
+func searchTasks(service taskApplication.TaskService) provider {
+	return func(ctx context.Context, text string, limit int) ([]*domain.Hit, int, error) {
+		tasks, total, err := service.GetTasks(ctx, &taskDomain.TaskQuery{
+			BaseQuery: common.BaseQuery{Page: 1, PageSize: limit},
+			Search:    text,
+			SortBy:    taskDomain.SortByRelevance,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		hits := make([]*domain.Hit, 0, len(tasks))
+		for _, task := range tasks {
+			hits = append(hits, &domain.Hit{
+				Type:      domain.EntityTask,
+				ID:        task.ID,
+				Title:     task.Title,
+				Highlight: task.SearchHighlight,
+				Rank:      task.SearchRank,
+				Data:      task,
+			})
+		}
+		return hits, total, nil
+	}
+}
+
+func searchCategories(service categoryApplication.CategoryService) provider {
+	return func(ctx context.Context, text string, limit int) ([]*domain.Hit, int, error) {
+		categories, total, err := service.GetCategories(ctx, &categoryDomain.CategoryQuery{
+			BaseQuery: common.BaseQuery{Page: 1, PageSize: limit},
+			Search:    text,
+			SortBy:    categoryDomain.SortByRelevance,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		hits := make([]*domain.Hit, 0, len(categories))
+		for _, category := range categories {
+			hits = append(hits, &domain.Hit{
+				Type:      domain.EntityCategory,
+				ID:        category.ID,
+				Title:     category.Name,
+				Highlight: category.SearchHighlight,
+				Rank:      category.SearchRank,
+				Data:      category,
+			})
+		}
+		return hits, total, nil
+	}
+}
@@ -0,0 +1,44 @@
+package domain
+
+type EntityType string
+
+const (
+	EntityTask     EntityType = "task"
+	EntityCategory EntityType = "category"
+)
+
+// EntityTypes lists every searchable entity type in display order.
+var EntityTypes = []EntityType{EntityTask, EntityCategory}
+
+type SearchQuery struct {
+	Text  string
+	Types []EntityType
+	// Limit caps the number of hits returned per entity type.
+	Limit int
+}
+
+// Hit is a single search result. Data holds the matching entity itself.
+type Hit struct {
+	Type      EntityType  `json:"type"`
+	ID        uint        `json:"id"`
+	Title     string      `json:"title"`
+	Highlight string      `json:"highlight,omitempty"`
+	Rank      float64     `json:"rank"`
+	Data      interface{} `json:"data"`
+}
+
+// Result holds the merged hits, best first, and the total number of matches
+// per entity type, which may exceed the hits returned.
+type Result struct {
+	Hits   []*Hit             `json:"hits"`
+	Facets map[EntityType]int `json:"facets"`
+}
+
+func IsValidEntityType(entityType EntityType) bool {
+	for _, t := range EntityTypes {
+		if t == entityType {
+			return true
+		}
+	}
+	return false
+}
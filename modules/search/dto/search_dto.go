@@ -0,0 +1,7 @@
+package dto
+
+type SearchQueryDTO struct {
+	Q     string `form:"q" binding:"required"`
+	Types string `form:"types"`
+	Limit int    `form:"limit" binding:"omitempty,gte=1,lte=50"`
+}
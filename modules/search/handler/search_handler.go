@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ltphat2204/domain-driven-golang/common"
+	"github.com/ltphat2204/domain-driven-golang/modules/search/application"
+	"github.com/ltphat2204/domain-driven-golang/modules/search/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/search/dto"
+)
+
+type SearchHandler struct {
+	service application.SearchService
+}
+
+func NewSearchHandler(service application.SearchService) *SearchHandler {
+	return &SearchHandler{service: service}
+}
+
+func (h *SearchHandler) Search(c *gin.Context) {
+	var queryDTO dto.SearchQueryDTO
+	if err := c.ShouldBindQuery(&queryDTO); err != nil {
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), err.Error()))
+		return
+	}
+
+	// Set defaults
+	limit := 10
+	if queryDTO.Limit > 0 {
+		limit = queryDTO.Limit
+	}
+
+	var types []domain.EntityType
+	if queryDTO.Types != "" {
+		for _, t := range strings.Split(queryDTO.Types, ",") {
+			entityType := domain.EntityType(strings.TrimSpace(t))
+			if !domain.IsValidEntityType(entityType) {
+				c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Invalid types"))
+				return
+			}
+			types = append(types, entityType)
+		}
+	}
+
+	result, err := h.service.Search(c.Request.Context(), &domain.SearchQuery{
+		Text:  queryDTO.Q,
+		Types: types,
+		Limit: limit,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(c.Request.Context(), http.StatusInternalServerError, "Failed to search", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, common.NewSuccessResponse(result))
+}
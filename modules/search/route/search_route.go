@@ -0,0 +1,24 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ltphat2204/domain-driven-golang/modules/search/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/search/dto"
+	"github.com/ltphat2204/domain-driven-golang/modules/search/handler"
+	"github.com/ltphat2204/domain-driven-golang/openapi"
+)
+
+func SetupRoutes(r *gin.Engine, searchHandler *handler.SearchHandler) {
+	r.GET("/search", searchHandler.Search)
+}
+
+// Operations documents the routes SetupRoutes registers.
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Method: "GET", Path: "/search", ID: "search", Summary: "Search tasks and categories", Tags: []string{"search"},
+			Query: dto.SearchQueryDTO{}, Response: domain.Result{},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError}},
+	}
+}
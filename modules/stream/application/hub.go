@@ -0,0 +1,147 @@
+package application
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ltphat2204/domain-driven-golang/modules/stream/domain"
+)
+
+// subscriberBuffer is how many events a subscriber may fall behind before it
+// is dropped. A dropped client reconnects with Last-Event-ID and catches up
+// from the replay buffer.
+const subscriberBuffer = 64
+
+// Hub numbers task and category changes, keeps the most recent ones for
+// replay and fans them out to subscribers in this process.
+type Hub struct {
+	mu sync.Mutex
+	// recent is a ring of the last len(recent) events; count of them are
+	// filled and the oldest is at start.
+	recent      []domain.Event
+	start       int
+	count       int
+	lastID      uint64
+	subscribers map[*subscriber]struct{}
+	closed      bool
+}
+
+type subscriber struct {
+	ch     chan domain.Event
+	filter domain.Filter
+}
+
+// NewHub returns a hub that keeps the last replayBuffer events.
+func NewHub(replayBuffer int) *Hub {
+	return &Hub{
+		recent:      make([]domain.Event, replayBuffer),
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// Subscription is what a subscriber gets: the events it missed, then the
+// live ones.
+type Subscription struct {
+	Replay []domain.Event
+	// Events is closed when the subscription's context ends, when the hub
+	// is closed, or if the subscriber falls more than subscriberBuffer
+	// events behind.
+	Events <-chan domain.Event
+	// Reset is set when the events after the requested ID are no longer
+	// buffered, or the ID is from before a restart; the subscriber should
+	// reload its state instead of relying on Replay.
+	Reset bool
+	// LastID is the ID of the latest event when the subscription started.
+	LastID uint64
+}
+
+// Subscribe starts a subscription to the events matching filter. When after
+// is set, the buffered events that follow it are replayed first.
+func (h *Hub) Subscribe(ctx context.Context, filter domain.Filter, after *uint64) Subscription {
+	sub := &subscriber{ch: make(chan domain.Event, subscriberBuffer), filter: filter}
+	h.mu.Lock()
+	s := Subscription{Events: sub.ch, LastID: h.lastID}
+	if after != nil {
+		oldest := h.lastID - uint64(h.count) + 1
+		if *after > h.lastID || *after+1 < oldest {
+			s.Reset = true
+		} else {
+			for i := *after + 1 - oldest; i < uint64(h.count); i++ {
+				if e := h.at(int(i)); filter.Matches(e) {
+					s.Replay = append(s.Replay, e)
+				}
+			}
+		}
+	}
+	if h.closed {
+		close(sub.ch)
+	} else {
+		h.subscribers[sub] = struct{}{}
+	}
+	h.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		h.unsubscribe(sub)
+	}()
+	return s
+}
+
+// at returns the i-th oldest buffered event.
+func (h *Hub) at(i int) domain.Event {
+	return h.recent[(h.start+i)%len(h.recent)]
+}
+
+func (h *Hub) unsubscribe(sub *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subscribers[sub]; ok {
+		delete(h.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+// Publish numbers event, buffers it and sends it to every matching
+// subscriber without blocking.
+func (h *Hub) Publish(event domain.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastID++
+	event.ID = h.lastID
+	if h.count < len(h.recent) {
+		h.recent[(h.start+h.count)%len(h.recent)] = event
+		h.count++
+	} else {
+		h.recent[h.start] = event
+		h.start = (h.start + 1) % len(h.recent)
+	}
+
+	for sub := range h.subscribers {
+		if !sub.filter.Matches(event) {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			delete(h.subscribers, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+// Close ends every subscription, for shutdown.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for sub := range h.subscribers {
+		delete(h.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+func (h *Hub) Closed() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.closed
+}
@@ -0,0 +1,123 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/ltphat2204/domain-driven-golang/config"
+	categoryApplication "github.com/ltphat2204/domain-driven-golang/modules/category/application"
+	categoryDomain "github.com/ltphat2204/domain-driven-golang/modules/category/domain"
+	categoryInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/category/infrastructure"
+	"github.com/ltphat2204/domain-driven-golang/modules/stream/domain"
+	taskApplication "github.com/ltphat2204/domain-driven-golang/modules/task/application"
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	taskInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/task/infrastructure"
+)
+
+func ids(events []domain.Event) []uint64 {
+	var ids []uint64
+	for _, e := range events {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
+
+func TestSubscribeReplaysAfterLastEventID(t *testing.T) {
+	hub := NewHub(3)
+	for i := 1; i <= 5; i++ {
+		hub.Publish(domain.Event{Type: domain.CategoryCreated, Category: &categoryDomain.Category{ID: uint(i)}})
+	}
+
+	tests := []struct {
+		name       string
+		after      *uint64
+		filter     domain.Filter
+		wantReplay []uint64
+		wantReset  bool
+	}{
+		{name: "live only"},
+		{name: "buffered", after: ptr(2), wantReplay: []uint64{3, 4, 5}},
+		{name: "filtered", after: ptr(2), filter: domain.Filter{CategoryIDs: []uint{4}}, wantReplay: []uint64{4}},
+		{name: "up to date", after: ptr(5)},
+		{name: "no longer buffered", after: ptr(1), wantReset: true},
+		{name: "from before a restart", after: ptr(9), wantReset: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := hub.Subscribe(t.Context(), tt.filter, tt.after)
+			if got := ids(sub.Replay); !slices.Equal(got, tt.wantReplay) || sub.Reset != tt.wantReset || sub.LastID != 5 {
+				t.Errorf("replay %v, reset %v, last ID %d; want %v, %v, 5", got, sub.Reset, sub.LastID, tt.wantReplay, tt.wantReset)
+			}
+		})
+	}
+}
+
+func TestPublishingServices(t *testing.T) {
+	ctx := context.Background()
+	hub := NewHub(100)
+	categoryRepo := categoryInfrastructure.NewMemoryCategoryRepository()
+	categories := hub.CategoryService(categoryApplication.NewCategoryService(categoryRepo, config.ColorPalette))
+	tasks := hub.TaskService(taskApplication.NewTaskService(taskInfrastructure.NewMemoryTaskRepository(categoryRepo)))
+	work, err := categories.CreateCategory(ctx, "Work", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	doing := hub.Subscribe(subCtx, domain.Filter{Statuses: []taskDomain.TaskStatus{taskDomain.StatusDoing}}, nil)
+	inWork := hub.Subscribe(subCtx, domain.Filter{CategoryIDs: []uint{work.ID}}, nil)
+
+	task, err := tasks.CreateTask(ctx, "Report", "", nil, &work.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := taskDomain.StatusDoing
+	if _, err := tasks.UpdateTask(ctx, task.ID, nil, nil, &status, nil, &work.ID); err != nil {
+		t.Fatal(err)
+	}
+	// Leaving the category still reaches its subscribers.
+	if _, err := tasks.UpdateTask(ctx, task.ID, nil, nil, &status, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := tasks.DeleteTask(ctx, task.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := categories.DeleteCategory(ctx, work.ID); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+
+	received := func(sub Subscription) string {
+		var got []string
+		for e := range sub.Events {
+			got = append(got, fmt.Sprintf("%d %s", e.ID, e.Type))
+		}
+		return fmt.Sprint(got)
+	}
+	if got, want := received(doing), "[3 task.updated 4 task.updated 5 task.deleted 6 category.deleted]"; got != want {
+		t.Errorf("status subscriber got %s, want %s", got, want)
+	}
+	if got, want := received(inWork), "[2 task.created 3 task.updated 4 task.updated 6 category.deleted]"; got != want {
+		t.Errorf("category subscriber got %s, want %s", got, want)
+	}
+}
+
+func TestSlowSubscriberIsDropped(t *testing.T) {
+	hub := NewHub(1)
+	sub := hub.Subscribe(t.Context(), domain.Filter{}, nil)
+	for i := 0; i <= subscriberBuffer; i++ {
+		hub.Publish(domain.Event{Type: domain.TaskDeleted, Task: &taskDomain.Task{ID: uint(i)}})
+	}
+	n := 0
+	for range sub.Events {
+		n++
+	}
+	if n != subscriberBuffer || hub.Closed() {
+		t.Errorf("got %d events before the channel closed, want %d", n, subscriberBuffer)
+	}
+}
+
+func ptr(id uint64) *uint64 { return &id }
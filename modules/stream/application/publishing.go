@@ -0,0 +1,101 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/ltphat2204/domain-driven-golang/database"
+	categoryApplication "github.com/ltphat2204/domain-driven-golang/modules/category/application"
+	categoryDomain "github.com/ltphat2204/domain-driven-golang/modules/category/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/stream/domain"
+	taskApplication "github.com/ltphat2204/domain-driven-golang/modules/task/application"
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+)
+
+// TaskService publishes the tasks created, updated and deleted through next.
+// Updates and deletions read the task first, so the event can be matched
+// against filters on what the task was.
+func (h *Hub) TaskService(next taskApplication.TaskService) taskApplication.TaskService {
+	return &publishingTaskService{TaskService: next, hub: h}
+}
+
+type publishingTaskService struct {
+	taskApplication.TaskService
+	hub *Hub
+}
+
+func (s *publishingTaskService) CreateTask(ctx context.Context, title, description string, dueAt *time.Time, categoryID *uint) (*taskDomain.Task, error) {
+	task, err := s.TaskService.CreateTask(ctx, title, description, dueAt, categoryID)
+	if err == nil {
+		event := domain.Event{Type: domain.TaskCreated, Task: task, OccurredAt: time.Now()}
+		database.AfterCommit(ctx, func() { s.hub.Publish(event) })
+	}
+	return task, err
+}
+
+func (s *publishingTaskService) UpdateTask(ctx context.Context, id uint, title, description *string, status *taskDomain.TaskStatus, dueAt *time.Time, categoryID *uint) (*taskDomain.Task, error) {
+	previous, _ := s.TaskService.GetTaskByID(ctx, id)
+	task, err := s.TaskService.UpdateTask(ctx, id, title, description, status, dueAt, categoryID)
+	if err == nil {
+		event := domain.Event{Type: domain.TaskUpdated, Task: task, Previous: previous, OccurredAt: time.Now()}
+		database.AfterCommit(ctx, func() { s.hub.Publish(event) })
+	}
+	return task, err
+}
+
+func (s *publishingTaskService) DeleteTask(ctx context.Context, id uint) error {
+	task, err := s.TaskService.GetTaskByID(ctx, id)
+	if err != nil {
+		// Without the task, filters cannot place the deletion; an ID-only
+		// task with no category or status only reaches unfiltered streams.
+		task = &taskDomain.Task{ID: id}
+	}
+	err = s.TaskService.DeleteTask(ctx, id)
+	if err == nil {
+		event := domain.Event{Type: domain.TaskDeleted, Task: task, OccurredAt: time.Now()}
+		database.AfterCommit(ctx, func() { s.hub.Publish(event) })
+	}
+	return err
+}
+
+// CategoryService publishes the categories created, updated and deleted
+// through next.
+func (h *Hub) CategoryService(next categoryApplication.CategoryService) categoryApplication.CategoryService {
+	return &publishingCategoryService{CategoryService: next, hub: h}
+}
+
+type publishingCategoryService struct {
+	categoryApplication.CategoryService
+	hub *Hub
+}
+
+func (s *publishingCategoryService) CreateCategory(ctx context.Context, name, description string) (*categoryDomain.Category, error) {
+	category, err := s.CategoryService.CreateCategory(ctx, name, description)
+	if err == nil {
+		event := domain.Event{Type: domain.CategoryCreated, Category: category, OccurredAt: time.Now()}
+		database.AfterCommit(ctx, func() { s.hub.Publish(event) })
+	}
+	return category, err
+}
+
+func (s *publishingCategoryService) UpdateCategory(ctx context.Context, id uint, name, description, color *string) (*categoryDomain.Category, error) {
+	category, err := s.CategoryService.UpdateCategory(ctx, id, name, description, color)
+	if err == nil {
+		event := domain.Event{Type: domain.CategoryUpdated, Category: category, OccurredAt: time.Now()}
+		database.AfterCommit(ctx, func() { s.hub.Publish(event) })
+	}
+	return category, err
+}
+
+func (s *publishingCategoryService) DeleteCategory(ctx context.Context, id uint) error {
+	category, err := s.CategoryService.GetCategoryByID(ctx, id)
+	if err != nil {
+		category = &categoryDomain.Category{ID: id}
+	}
+	err = s.CategoryService.DeleteCategory(ctx, id)
+	if err == nil {
+		event := domain.Event{Type: domain.CategoryDeleted, Category: category, OccurredAt: time.Now()}
+		database.AfterCommit(ctx, func() { s.hub.Publish(event) })
+	}
+	return err
+}
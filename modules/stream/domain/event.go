@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	categoryDomain "github.com/ltphat2204/domain-driven-golang/modules/category/domain"
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+)
+
+type EventType string
+
+const (
+	TaskCreated     EventType = "task.created"
+	TaskUpdated     EventType = "task.updated"
+	TaskDeleted     EventType = "task.deleted"
+	CategoryCreated EventType = "category.created"
+	CategoryUpdated EventType = "category.updated"
+	CategoryDeleted EventType = "category.deleted"
+)
+
+// Event is a change to a task or a category. IDs increase by one per event,
+// so a client that saw an ID can ask for everything after it.
+type Event struct {
+	ID         uint64
+	Type       EventType
+	Task       *taskDomain.Task
+	Category   *categoryDomain.Category
+	OccurredAt time.Time
+	// Previous is the task before an update, so subscribers filtering on
+	// the old category or status learn that it moved away.
+	Previous *taskDomain.Task
+}
+
+// Filter selects the events a subscriber receives; empty fields match
+// everything. Category events are matched by CategoryIDs only.
+//
+// Events are not scoped to the caller's workspace, as the stream was asked
+// to be: tasks and categories have no owner to scope by, only views know
+// their X-User-ID caller. Once they gain one, it belongs here, so Matches
+// drops other workspaces' events and replays skip them too.
+type Filter struct {
+	CategoryIDs []uint
+	Statuses    []taskDomain.TaskStatus
+}
+
+func (f Filter) Matches(e Event) bool {
+	if e.Category != nil {
+		return len(f.CategoryIDs) == 0 || slices.Contains(f.CategoryIDs, e.Category.ID)
+	}
+	return f.matchesTask(e.Task) || (e.Previous != nil && f.matchesTask(e.Previous))
+}
+
+func (f Filter) matchesTask(task *taskDomain.Task) bool {
+	if len(f.CategoryIDs) > 0 && (task.CategoryID == nil || !slices.Contains(f.CategoryIDs, *task.CategoryID)) {
+		return false
+	}
+	return len(f.Statuses) == 0 || slices.Contains(f.Statuses, task.Status)
+}
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"time"
+
+	categoryDomain "github.com/ltphat2204/domain-driven-golang/modules/category/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/stream/domain"
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+)
+
+type StreamQueryDTO struct {
+	// CategoryID may be repeated; events for any of the categories match.
+	CategoryID []uint `form:"category_id"`
+	// Status may be repeated. Category events are not filtered by status.
+	Status []string `form:"status"`
+	// LastEventID resumes after the given event, for clients that cannot
+	// set the Last-Event-ID header. The header wins when both are given.
+	LastEventID string `form:"last_event_id"`
+}
+
+// EventDTO is one event on the stream. Task is set for task events and
+// Category for category events; deletions carry the last known state.
+type EventDTO struct {
+	ID         uint64                   `json:"id"`
+	Type       domain.EventType         `json:"type"`
+	Task       *taskDomain.Task         `json:"task,omitempty"`
+	Category   *categoryDomain.Category `json:"category,omitempty"`
+	OccurredAt time.Time                `json:"occurred_at"`
+}
+
+func NewEventDTO(e domain.Event) EventDTO {
+	return EventDTO{ID: e.ID, Type: e.Type, Task: e.Task, Category: e.Category, OccurredAt: e.OccurredAt}
+}
+
+// ResetDTO tells a client that the events it asked to resume after are no
+// longer available, so it should reload tasks and categories. Its ID is the
+// latest event's, to resume from after reloading.
+type ResetDTO struct {
+	ID   uint64 `json:"id"`
+	Type string `json:"type"`
+}
@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+
+	"github.com/ltphat2204/domain-driven-golang/common"
+	"github.com/ltphat2204/domain-driven-golang/modules/stream/application"
+	"github.com/ltphat2204/domain-driven-golang/modules/stream/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/stream/dto"
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+)
+
+// writeTimeout bounds each write to a client, so a stalled connection does
+// not hold its subscription forever.
+const writeTimeout = 10 * time.Second
+
+type StreamHandler struct {
+	hub       *application.Hub
+	heartbeat time.Duration
+}
+
+// NewStreamHandler serves the hub's events, sending a keep-alive on streams
+// idle for heartbeat.
+func NewStreamHandler(hub *application.Hub, heartbeat time.Duration) *StreamHandler {
+	return &StreamHandler{hub: hub, heartbeat: heartbeat}
+}
+
+// Stream serves the events as Server-Sent Events.
+func (h *StreamHandler) Stream(c *gin.Context) {
+	filter, after, ok := parseQuery(c)
+	if !ok {
+		return
+	}
+
+	// The server's write timeout would cut the stream off; writes get their
+	// own deadline instead.
+	rc := http.NewResponseController(c.Writer)
+	_ = rc.SetWriteDeadline(time.Time{})
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	ctx := c.Request.Context()
+	h.serve(ctx, h.hub.Subscribe(ctx, filter, after), &sseSink{w: c.Writer, rc: rc})
+}
+
+// WebSocket serves the events as JSON text messages on a WebSocket.
+func (h *StreamHandler) WebSocket(c *gin.Context) {
+	filter, after, ok := parseQuery(c)
+	if !ok {
+		return
+	}
+	if !strings.EqualFold(c.GetHeader("Upgrade"), "websocket") {
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Expected a WebSocket upgrade"))
+		return
+	}
+
+	// The API has no cookies or sessions for a cross-site page to ride on,
+	// so any origin may connect.
+	server := websocket.Server{Handler: func(ws *websocket.Conn) {
+		// The connection keeps the deadlines of the request that opened
+		// it; writes get their own instead.
+		_ = ws.SetDeadline(time.Time{})
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+		go func() {
+			// Nothing is expected from the client; reading notices when it
+			// goes away.
+			var discard []byte
+			for websocket.Message.Receive(ws, &discard) == nil {
+			}
+			cancel()
+		}()
+		h.serve(ctx, h.hub.Subscribe(ctx, filter, after), &wsSink{ws: ws})
+	}}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+func parseQuery(c *gin.Context) (domain.Filter, *uint64, bool) {
+	var queryDTO dto.StreamQueryDTO
+	if err := c.ShouldBindQuery(&queryDTO); err != nil {
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), err.Error()))
+		return domain.Filter{}, nil, false
+	}
+
+	filter := domain.Filter{CategoryIDs: queryDTO.CategoryID}
+	for _, s := range queryDTO.Status {
+		status := taskDomain.TaskStatus(s)
+		if !taskDomain.IsValidTaskStatus(status) {
+			c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Invalid status"))
+			return domain.Filter{}, nil, false
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = queryDTO.LastEventID
+	}
+	if lastEventID == "" {
+		return filter, nil, true
+	}
+	after, err := strconv.ParseUint(lastEventID, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Invalid Last-Event-ID"))
+		return domain.Filter{}, nil, false
+	}
+	return filter, &after, true
+}
+
+// sink writes events in one protocol.
+type sink interface {
+	event(e dto.EventDTO) error
+	reset(r dto.ResetDTO) error
+	heartbeat() error
+}
+
+// serve writes sub's events to s until the client goes away, the hub is
+// closed or the client falls behind; a client that fell behind reconnects
+// with Last-Event-ID and catches up from the replay buffer.
+func (h *StreamHandler) serve(ctx context.Context, sub application.Subscription, s sink) {
+	if sub.Reset {
+		if err := s.reset(dto.ResetDTO{ID: sub.LastID, Type: "reset"}); err != nil {
+			return
+		}
+	}
+	for _, e := range sub.Replay {
+		if err := s.event(dto.NewEventDTO(e)); err != nil {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case e, ok := <-sub.Events:
+			if !ok {
+				if ctx.Err() == nil && !h.hub.Closed() {
+					slog.InfoContext(ctx, "dropped a stream client that fell behind")
+				}
+				return
+			}
+			if err := s.event(dto.NewEventDTO(e)); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := s.heartbeat(); err != nil {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+type sseSink struct {
+	w  gin.ResponseWriter
+	rc *http.ResponseController
+}
+
+func (s *sseSink) event(e dto.EventDTO) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return s.write(fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data))
+}
+
+func (s *sseSink) reset(r dto.ResetDTO) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return s.write(fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", r.ID, r.Type, data))
+}
+
+// heartbeat is a comment line, which EventSource ignores.
+func (s *sseSink) heartbeat() error {
+	return s.write(": heartbeat\n\n")
+}
+
+func (s *sseSink) write(text string) error {
+	_ = s.rc.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := io.WriteString(s.w, text); err != nil {
+		return err
+	}
+	s.w.Flush()
+	return nil
+}
+
+type wsSink struct {
+	ws *websocket.Conn
+}
+
+func (s *wsSink) event(e dto.EventDTO) error {
+	return s.send(e)
+}
+
+func (s *wsSink) reset(r dto.ResetDTO) error {
+	return s.send(r)
+}
+
+func (s *wsSink) send(v interface{}) error {
+	_ = s.ws.SetWriteDeadline(time.Now().Add(writeTimeout))
+	return websocket.JSON.Send(s.ws, v)
+}
+
+func (s *wsSink) heartbeat() error {
+	_ = s.ws.SetWriteDeadline(time.Now().Add(writeTimeout))
+	s.ws.PayloadType = websocket.PingFrame
+	defer func() { s.ws.PayloadType = websocket.TextFrame }()
+	_, err := s.ws.Write(nil)
+	return err
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+
+	"github.com/ltphat2204/domain-driven-golang/modules/stream/application"
+	"github.com/ltphat2204/domain-driven-golang/modules/stream/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/stream/dto"
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+)
+
+func newServer(t *testing.T, hub *application.Hub) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	h := NewStreamHandler(hub, time.Hour)
+	r.GET("/tasks/stream", h.Stream)
+	r.GET("/tasks/ws", h.WebSocket)
+	srv := httptest.NewServer(r)
+	t.Cleanup(func() {
+		hub.Close()
+		srv.Close()
+	})
+	return srv
+}
+
+func publishTask(hub *application.Hub, id uint, status taskDomain.TaskStatus) {
+	hub.Publish(domain.Event{Type: domain.TaskCreated, Task: &taskDomain.Task{ID: id, Status: status}})
+}
+
+// readEvent reads one SSE event, skipping comments.
+func readEvent(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+	var lines []string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "" && len(lines) > 0:
+			return strings.Join(lines, "\n")
+		case line != "" && !strings.HasPrefix(line, ":"):
+			lines = append(lines, line)
+		}
+	}
+}
+
+func TestServerSentEvents(t *testing.T) {
+	hub := application.NewHub(10)
+	srv := newServer(t, hub)
+	publishTask(hub, 1, taskDomain.StatusDone)
+	publishTask(hub, 2, taskDomain.StatusPending)
+
+	req, err := http.NewRequest("GET", srv.URL+"/tasks/stream?status=Pending", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Last-Event-ID", "0")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+
+	body := bufio.NewReader(resp.Body)
+	// The replay skips the Done task; live events follow it.
+	event := readEvent(t, body)
+	if !strings.HasPrefix(event, "id: 2\nevent: task.created\ndata: {\"id\":2,") {
+		t.Errorf("replayed event = %q", event)
+	}
+	publishTask(hub, 3, taskDomain.StatusDone)
+	publishTask(hub, 4, taskDomain.StatusPending)
+	if event := readEvent(t, body); !strings.HasPrefix(event, "id: 4\n") {
+		t.Errorf("live event = %q", event)
+	}
+}
+
+func TestServerSentEventsReset(t *testing.T) {
+	hub := application.NewHub(1)
+	srv := newServer(t, hub)
+	publishTask(hub, 1, taskDomain.StatusDone)
+	publishTask(hub, 2, taskDomain.StatusDone)
+
+	resp, err := http.Get(srv.URL + "/tasks/stream?last_event_id=0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	want := "id: 2\nevent: reset\ndata: {\"id\":2,\"type\":\"reset\"}"
+	if event := readEvent(t, bufio.NewReader(resp.Body)); event != want {
+		t.Errorf("event = %q, want %q", event, want)
+	}
+}
+
+func TestWebSocket(t *testing.T) {
+	hub := application.NewHub(10)
+	srv := newServer(t, hub)
+	publishTask(hub, 1, taskDomain.StatusDone)
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/tasks/ws?last_event_id=0"
+	ws, err := websocket.Dial(url, "", srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+
+	var event dto.EventDTO
+	if err := websocket.JSON.Receive(ws, &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.ID != 1 || event.Type != domain.TaskCreated || event.Task.ID != 1 {
+		t.Errorf("replayed event = %+v", event)
+	}
+	publishTask(hub, 2, taskDomain.StatusDone)
+	if err := websocket.JSON.Receive(ws, &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.ID != 2 {
+		t.Errorf("live event = %+v", event)
+	}
+}
@@ -0,0 +1,31 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ltphat2204/domain-driven-golang/modules/stream/dto"
+	"github.com/ltphat2204/domain-driven-golang/modules/stream/handler"
+	"github.com/ltphat2204/domain-driven-golang/openapi"
+)
+
+func SetupRoutes(r *gin.Engine, streamHandler *handler.StreamHandler) {
+	r.GET("/tasks/stream", streamHandler.Stream)
+	r.GET("/tasks/ws", streamHandler.WebSocket)
+}
+
+// Operations documents the routes SetupRoutes registers. Both send the same
+// events: EventDTOs, preceded by a ResetDTO when the events after
+// Last-Event-ID are no longer buffered. The optional Last-Event-ID header is
+// documented by its last_event_id query equivalent.
+func Operations() []openapi.Operation {
+	tags := []string{"stream"}
+	return []openapi.Operation{
+		{Method: "GET", Path: "/tasks/stream", ID: "streamTasks", Summary: "Stream task and category changes as Server-Sent Events", Tags: tags,
+			Query: dto.StreamQueryDTO{}, ContentType: "text/event-stream",
+			Errors: []int{http.StatusBadRequest}},
+		{Method: "GET", Path: "/tasks/ws", ID: "streamTasksWebSocket", Summary: "Stream task and category changes over a WebSocket", Tags: tags,
+			Query: dto.StreamQueryDTO{}, Response: dto.EventDTO{}, Raw: true,
+			Statuses: []int{http.StatusSwitchingProtocols}, Errors: []int{http.StatusBadRequest}},
+	}
+}
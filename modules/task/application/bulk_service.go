@@ -0,0 +1,153 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+
+	"github.com/ltphat2204/domain-driven-golang/database"
+	"github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	"github.com/ltphat2204/domain-driven-golang/tracing"
+)
+
+type BulkService interface {
+	// Run validates every operation, then applies them in order. It returns
+	// a *domain.BulkValidationError, having applied nothing, if any is
+	// invalid.
+	Run(ctx context.Context, mode domain.BulkMode, ops []domain.BulkOperation) (*domain.BulkResult, error)
+}
+
+type bulkService struct {
+	tasks         TaskService
+	transactor    database.Transactor
+	maxOperations int
+}
+
+// NewBulkService applies bulk operations through tasks, so they are
+// validated, recorded and published like single ones. Atomic requests run in
+// a transaction from transactor.
+func NewBulkService(tasks TaskService, transactor database.Transactor, maxOperations int) BulkService {
+	return &bulkService{tasks: tasks, transactor: transactor, maxOperations: maxOperations}
+}
+
+// errRolledBack aborts the transaction of an atomic request after a failed
+// operation, whose error is already in the results.
+var errRolledBack = errors.New("bulk operation failed")
+
+func (s *bulkService) Run(ctx context.Context, mode domain.BulkMode, ops []domain.BulkOperation) (_ *domain.BulkResult, err error) {
+	ctx, span := tracer.Start(ctx, "BulkService.Run")
+	defer tracing.End(span, &err)
+	span.SetAttributes(attribute.String("bulk.mode", string(mode)), attribute.Int("bulk.operations", len(ops)))
+
+	if err := s.validate(mode, ops); err != nil {
+		return nil, err
+	}
+
+	result := &domain.BulkResult{Mode: mode, Committed: true, Results: make([]domain.BulkItemResult, len(ops))}
+	if mode == domain.BulkBestEffort {
+		for i, op := range ops {
+			result.Results[i] = s.apply(ctx, i, op)
+		}
+	} else {
+		err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+			for i, op := range ops {
+				result.Results[i] = s.apply(ctx, i, op)
+				if result.Results[i].Status == domain.BulkFailed {
+					return errRolledBack
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			if !errors.Is(err, errRolledBack) {
+				return nil, err
+			}
+			result.Committed = false
+			for i := range result.Results {
+				item := &result.Results[i]
+				switch item.Status {
+				case domain.BulkApplied:
+					item.Status, item.Task = domain.BulkRolledBack, nil
+				case "":
+					*item = domain.BulkItemResult{Index: i, Op: ops[i].Op, ID: ops[i].ID, Status: domain.BulkSkipped}
+				}
+			}
+		}
+	}
+
+	for _, item := range result.Results {
+		switch item.Status {
+		case domain.BulkApplied:
+			result.Applied++
+		case domain.BulkFailed:
+			result.Failed++
+		}
+	}
+	slog.InfoContext(ctx, "bulk operations run", "mode", mode, "operations", len(ops), "applied", result.Applied, "failed", result.Failed)
+	return result, nil
+}
+
+func (s *bulkService) validate(mode domain.BulkMode, ops []domain.BulkOperation) error {
+	var problems []string
+	if mode != domain.BulkAtomic && mode != domain.BulkBestEffort {
+		problems = append(problems, fmt.Sprintf("mode must be %s or %s", domain.BulkAtomic, domain.BulkBestEffort))
+	}
+	if len(ops) == 0 || len(ops) > s.maxOperations {
+		problems = append(problems, fmt.Sprintf("between 1 and %d operations are allowed, got %d", s.maxOperations, len(ops)))
+	}
+	for i, op := range ops {
+		if err := op.Validate(); err != nil {
+			problems = append(problems, fmt.Sprintf("operations[%d]: %v", i, err))
+		}
+	}
+	if len(problems) > 0 {
+		return &domain.BulkValidationError{Problems: problems}
+	}
+	return nil
+}
+
+func (s *bulkService) apply(ctx context.Context, i int, op domain.BulkOperation) domain.BulkItemResult {
+	item := domain.BulkItemResult{Index: i, Op: op.Op, ID: op.ID, Status: domain.BulkApplied}
+	var err error
+	if op.Op == domain.BulkCreate {
+		description := ""
+		if op.Description != nil {
+			description = *op.Description
+		}
+		item.Task, err = s.tasks.CreateTask(ctx, *op.Title, description, op.DueAt, op.CategoryID)
+	} else {
+		item.Task, err = s.change(ctx, op)
+	}
+	if err != nil {
+		item.Status, item.Task, item.Error = domain.BulkFailed, nil, err.Error()
+	} else if item.Task != nil {
+		item.ID = item.Task.ID
+	}
+	return item
+}
+
+// change applies an operation to an existing task, which it checks for
+// first: deleting a missing task is not an error to the service.
+func (s *bulkService) change(ctx context.Context, op domain.BulkOperation) (*domain.Task, error) {
+	task, err := s.tasks.GetTaskByID(ctx, op.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("task %d not found", op.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case domain.BulkUpdate, domain.BulkSetStatus:
+		// UpdateTask sets the category it is given, so pass the current one.
+		return s.tasks.UpdateTask(ctx, op.ID, op.Title, op.Description, op.Status, op.DueAt, task.CategoryID)
+	case domain.BulkMove:
+		return s.tasks.UpdateTask(ctx, op.ID, nil, nil, nil, nil, op.CategoryID)
+	default:
+		return nil, s.tasks.DeleteTask(ctx, op.ID)
+	}
+}
@@ -0,0 +1,106 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ltphat2204/domain-driven-golang/database"
+	"github.com/ltphat2204/domain-driven-golang/database/repotest"
+	categoryInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/category/infrastructure"
+	streamApplication "github.com/ltphat2204/domain-driven-golang/modules/stream/application"
+	streamDomain "github.com/ltphat2204/domain-driven-golang/modules/stream/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/task/application"
+	"github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	taskInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/task/infrastructure"
+)
+
+func TestBulkService(t *testing.T) {
+	t.Run("Memory", func(t *testing.T) {
+		testBulkService(t, func() (domain.TaskRepository, database.Transactor) {
+			repo := taskInfrastructure.NewMemoryTaskRepository(categoryInfrastructure.NewMemoryCategoryRepository())
+			return repo, database.NewMemoryTransactor(repo.(database.Snapshotter))
+		})
+	})
+
+	t.Run("SQLite", func(t *testing.T) {
+		testBulkService(t, func() (domain.TaskRepository, database.Transactor) {
+			db := repotest.OpenSQLite(t)
+			return taskInfrastructure.NewTaskRepository(db), database.NewTransactor(db)
+		})
+	})
+
+	t.Run("Postgres", func(t *testing.T) {
+		testBulkService(t, func() (domain.TaskRepository, database.Transactor) {
+			db := repotest.OpenPostgres(t)
+			return taskInfrastructure.NewTaskRepository(db), database.NewTransactor(db)
+		})
+	})
+}
+
+func testBulkService(t *testing.T, newStorage func() (domain.TaskRepository, database.Transactor)) {
+	ctx := context.Background()
+	repo, transactor := newStorage()
+	hub := streamApplication.NewHub(100)
+	tasks := hub.TaskService(application.NewTaskService(repo))
+	bulk := application.NewBulkService(tasks, transactor, 10)
+	for _, title := range []string{"one", "two"} {
+		if _, err := tasks.CreateTask(ctx, title, "", nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	published := hub.Subscribe(subCtx, streamDomain.Filter{}, nil)
+
+	title, done := "three", domain.StatusDone
+	ops := []domain.BulkOperation{
+		{Op: domain.BulkCreate, Title: &title},
+		{Op: domain.BulkSetStatus, ID: 1, Status: &done},
+		{Op: domain.BulkDelete, ID: 99},
+		{Op: domain.BulkDelete, ID: 2},
+	}
+	result, err := bulk.Run(ctx, domain.BulkAtomic, ops)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []domain.BulkItemStatus{domain.BulkRolledBack, domain.BulkRolledBack, domain.BulkFailed, domain.BulkSkipped}
+	for i, item := range result.Results {
+		if item.Status != want[i] {
+			t.Errorf("atomic result %d is %s, want %s", i, item.Status, want[i])
+		}
+	}
+	if result.Committed || result.Applied != 0 || result.Failed != 1 {
+		t.Errorf("atomic result = %+v, want uncommitted with 1 failure", result)
+	}
+	if all, _, _ := tasks.GetTasks(ctx, &domain.TaskQuery{}); len(all) != 2 {
+		t.Errorf("%d tasks after rollback, want 2", len(all))
+	}
+	if task, _ := tasks.GetTaskByID(ctx, 1); task.Status != domain.StatusPending {
+		t.Errorf("task 1 is %s after rollback, want Pending", task.Status)
+	}
+
+	result, err = bulk.Run(ctx, domain.BulkBestEffort, ops)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Committed || result.Applied != 3 || result.Failed != 1 || result.Results[2].Error != "task 99 not found" {
+		t.Errorf("best-effort result = %+v, want 3 applied and task 99 not found", result)
+	}
+	if task, _ := tasks.GetTaskByID(ctx, 1); task.Status != domain.StatusDone {
+		t.Errorf("task 1 is %s, want Done", task.Status)
+	}
+
+	// Only the best-effort changes, which stuck, were published.
+	cancel()
+	var types []streamDomain.EventType
+	for event := range published.Events {
+		types = append(types, event.Type)
+	}
+	if len(types) != 3 || types[0] != streamDomain.TaskCreated || types[1] != streamDomain.TaskUpdated || types[2] != streamDomain.TaskDeleted {
+		t.Errorf("published %v, want created, updated, deleted", types)
+	}
+
+	if _, err := bulk.Run(ctx, domain.BulkAtomic, make([]domain.BulkOperation, 11)); err == nil {
+		t.Error("Run with more than the maximum operations succeeded")
+	}
+}
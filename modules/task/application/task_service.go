@@ -2,15 +2,26 @@ package application
 
 import (
 	"context"
+	"iter"
+	"log/slog"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	"github.com/ltphat2204/domain-driven-golang/tracing"
 )
 
+var tracer = otel.Tracer("github.com/ltphat2204/domain-driven-golang/modules/task/application")
+
 type TaskService interface {
 	CreateTask(ctx context.Context, title, description string, dueAt *time.Time, categoryID *uint) (*domain.Task, error)
 	GetTaskByID(ctx context.Context, id uint) (*domain.Task, error)
 	GetTasks(ctx context.Context, query *domain.TaskQuery) ([]*domain.Task, int, error)
+	// AllTasks yields every task in ID order, for exports too large to page
+	// through.
+	AllTasks(ctx context.Context) iter.Seq2[*domain.Task, error]
 	UpdateTask(ctx context.Context, id uint, title, description *string, status *domain.TaskStatus, dueAt *time.Time, categoryID *uint) (*domain.Task, error)
 	DeleteTask(ctx context.Context, id uint) error
 }
@@ -23,7 +34,10 @@ func NewTaskService(repo domain.TaskRepository) TaskService {
 	return &taskService{repo: repo}
 }
 
-func (s *taskService) CreateTask(ctx context.Context, title, description string, dueAt *time.Time, categoryID *uint) (*domain.Task, error) {
+func (s *taskService) CreateTask(ctx context.Context, title, description string, dueAt *time.Time, categoryID *uint) (_ *domain.Task, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.CreateTask")
+	defer tracing.End(span, &err)
+
 	task := &domain.Task{
 		Title:       title,
 		Description: description,
@@ -31,18 +45,48 @@ func (s *taskService) CreateTask(ctx context.Context, title, description string,
 		DueAt:       dueAt,
 		CategoryID:  categoryID,
 	}
-	return s.repo.Save(ctx, task)
+	created, err := s.repo.Save(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "task created", "task_id", created.ID)
+	return created, nil
 }
 
-func (s *taskService) GetTaskByID(ctx context.Context, id uint) (*domain.Task, error) {
+func (s *taskService) GetTaskByID(ctx context.Context, id uint) (_ *domain.Task, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.GetTaskByID")
+	defer tracing.End(span, &err)
+	span.SetAttributes(attribute.Int64("task.id", int64(id)))
+
 	return s.repo.FindByID(ctx, id)
 }
 
-func (s *taskService) GetTasks(ctx context.Context, query *domain.TaskQuery) ([]*domain.Task, int, error) {
-	return s.repo.FindTasks(ctx, query)
+func (s *taskService) GetTasks(ctx context.Context, query *domain.TaskQuery) (_ []*domain.Task, _ int, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.GetTasks")
+	defer tracing.End(span, &err)
+	sortBy, sortOrder := query.EffectiveSort()
+	span.SetAttributes(
+		attribute.String("task.query.sort", sortBy+" "+sortOrder),
+		attribute.Int("task.query.page_size", query.PageSize),
+		attribute.Bool("task.query.cursor", query.Cursor != nil),
+		attribute.Bool("task.query.search", query.Search != ""),
+		attribute.Bool("task.query.filter", query.Filter != nil),
+	)
+
+	tasks, total, err := s.repo.FindTasks(ctx, query)
+	span.SetAttributes(attribute.Int("task.query.results", len(tasks)))
+	return tasks, total, err
+}
+
+func (s *taskService) AllTasks(ctx context.Context) iter.Seq2[*domain.Task, error] {
+	return s.repo.All(ctx)
 }
 
-func (s *taskService) UpdateTask(ctx context.Context, id uint, title, description *string, status *domain.TaskStatus, dueAt *time.Time, categoryID *uint) (*domain.Task, error) {
+func (s *taskService) UpdateTask(ctx context.Context, id uint, title, description *string, status *domain.TaskStatus, dueAt *time.Time, categoryID *uint) (_ *domain.Task, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.UpdateTask")
+	defer tracing.End(span, &err)
+	span.SetAttributes(attribute.Int64("task.id", int64(id)))
+
 	task, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -60,9 +104,22 @@ func (s *taskService) UpdateTask(ctx context.Context, id uint, title, descriptio
 		task.DueAt = dueAt
 	}
 	task.CategoryID = categoryID // Allow null to remove category
-	return s.repo.Update(ctx, task)
+	updated, err := s.repo.Update(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "task updated", "task_id", id)
+	return updated, nil
 }
 
-func (s *taskService) DeleteTask(ctx context.Context, id uint) error {
-	return s.repo.Delete(ctx, id)
+func (s *taskService) DeleteTask(ctx context.Context, id uint) (err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.DeleteTask")
+	defer tracing.End(span, &err)
+	span.SetAttributes(attribute.Int64("task.id", int64(id)))
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "task deleted", "task_id", id)
+	return nil
 }
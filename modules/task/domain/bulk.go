@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+type BulkOp string
+
+const (
+	BulkCreate BulkOp = "create"
+	// BulkUpdate changes the fields it sets and keeps the rest, including
+	// the category.
+	BulkUpdate    BulkOp = "update"
+	BulkSetStatus BulkOp = "set_status"
+	// BulkMove puts the task in CategoryID, or in no category when it is nil.
+	BulkMove   BulkOp = "move"
+	BulkDelete BulkOp = "delete"
+)
+
+type BulkMode string
+
+const (
+	// BulkAtomic applies every operation or none: the first failure rolls
+	// back the ones before it and skips the rest.
+	BulkAtomic BulkMode = "atomic"
+	// BulkBestEffort applies each operation on its own and carries on past
+	// failures.
+	BulkBestEffort BulkMode = "best_effort"
+)
+
+// BulkOperation is one change in a bulk request. ID names the task for
+// every operation but create.
+type BulkOperation struct {
+	Op          BulkOp
+	ID          uint
+	Title       *string
+	Description *string
+	Status      *TaskStatus
+	DueAt       *time.Time
+	CategoryID  *uint
+}
+
+// Validate checks the operation without looking at stored data.
+func (o *BulkOperation) Validate() error {
+	if o.Op != BulkCreate && o.ID == 0 {
+		return errors.New("id is required")
+	}
+	if o.Title != nil && strings.TrimSpace(*o.Title) == "" {
+		return errors.New("title must not be empty")
+	}
+	if o.Status != nil && !IsValidTaskStatus(*o.Status) {
+		return fmt.Errorf("invalid status %q", *o.Status)
+	}
+
+	// Each operation takes only its own fields, so a misplaced one is an
+	// error rather than silently ignored.
+	var allowed, set []string
+	switch o.Op {
+	case BulkCreate:
+		if o.Title == nil {
+			return errors.New("title is required")
+		}
+		if o.ID != 0 {
+			return errors.New("id is assigned on create")
+		}
+		allowed = []string{"title", "description", "due_at", "category_id"}
+	case BulkUpdate:
+		if o.Title == nil && o.Description == nil && o.Status == nil && o.DueAt == nil {
+			return errors.New("update must set title, description, status or due_at")
+		}
+		allowed = []string{"title", "description", "status", "due_at"}
+	case BulkSetStatus:
+		if o.Status == nil {
+			return errors.New("status is required")
+		}
+		allowed = []string{"status"}
+	case BulkMove:
+		allowed = []string{"category_id"}
+	case BulkDelete:
+	default:
+		return fmt.Errorf("unknown op %q, want create, update, set_status, move or delete", o.Op)
+	}
+	fields := []struct {
+		name string
+		set  bool
+	}{
+		{"title", o.Title != nil}, {"description", o.Description != nil}, {"status", o.Status != nil},
+		{"due_at", o.DueAt != nil}, {"category_id", o.CategoryID != nil},
+	}
+	for _, field := range fields {
+		if field.set && !slices.Contains(allowed, field.name) {
+			set = append(set, field.name)
+		}
+	}
+	if len(set) > 0 {
+		return fmt.Errorf("%s does not take %s", o.Op, strings.Join(set, ", "))
+	}
+	return nil
+}
+
+type BulkItemStatus string
+
+const (
+	BulkApplied BulkItemStatus = "applied"
+	BulkFailed  BulkItemStatus = "failed"
+	// BulkRolledBack marks operations that succeeded but were undone
+	// because a later one failed in atomic mode.
+	BulkRolledBack BulkItemStatus = "rolled_back"
+	// BulkSkipped marks operations not attempted after a failure in atomic
+	// mode.
+	BulkSkipped BulkItemStatus = "skipped"
+)
+
+// BulkItemResult is the outcome of the operation at Index. Task is the task
+// as it ended up, for applied creates and updates.
+type BulkItemResult struct {
+	Index  int            `json:"index"`
+	Op     BulkOp         `json:"op"`
+	ID     uint           `json:"id,omitempty"`
+	Status BulkItemStatus `json:"status"`
+	Task   *Task          `json:"task,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+type BulkResult struct {
+	Mode BulkMode `json:"mode"`
+	// Committed is false when an atomic request was rolled back.
+	Committed bool             `json:"committed"`
+	Applied   int              `json:"applied"`
+	Failed    int              `json:"failed"`
+	Results   []BulkItemResult `json:"results"`
+}
+
+// BulkValidationError lists the operations that failed validation; none
+// were applied.
+type BulkValidationError struct {
+	Problems []string
+}
+
+func (e *BulkValidationError) Error() string {
+	return strings.Join(e.Problems, "; ")
+}
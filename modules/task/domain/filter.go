@@ -0,0 +1,490 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	MaxFilterLength = 2000
+	maxFilterDepth  = 32
+)
+
+// FilterExpr is a node of a parsed task filter expression.
+type FilterExpr interface {
+	filterExpr()
+}
+
+type FilterAnd struct {
+	Exprs []FilterExpr
+}
+
+type FilterOr struct {
+	Exprs []FilterExpr
+}
+
+type FilterNot struct {
+	Expr FilterExpr
+}
+
+type FilterField string
+
+const (
+	FilterFieldID          FilterField = "id"
+	FilterFieldTitle       FilterField = "title"
+	FilterFieldDescription FilterField = "description"
+	FilterFieldStatus      FilterField = "status"
+	FilterFieldCategoryID  FilterField = "category_id"
+	FilterFieldDueAt       FilterField = "due_at"
+	FilterFieldCreatedAt   FilterField = "created_at"
+	FilterFieldUpdatedAt   FilterField = "updated_at"
+)
+
+type FilterOp string
+
+const (
+	FilterOpEq       FilterOp = "="
+	FilterOpNe       FilterOp = "!="
+	FilterOpLt       FilterOp = "<"
+	FilterOpLte      FilterOp = "<="
+	FilterOpGt       FilterOp = ">"
+	FilterOpGte      FilterOp = ">="
+	FilterOpContains FilterOp = "~"
+	FilterOpIn       FilterOp = "in"
+	FilterOpNotIn    FilterOp = "not in"
+	FilterOpIsNull   FilterOp = "is null"
+	FilterOpNotNull  FilterOp = "is not null"
+)
+
+// FilterCondition compares a field against one or more values. Values are
+// already typed for the field: string, TaskStatus, uint or time.Time.
+type FilterCondition struct {
+	Field  FilterField
+	Op     FilterOp
+	Values []interface{}
+}
+
+func (FilterAnd) filterExpr()       {}
+func (FilterOr) filterExpr()        {}
+func (FilterNot) filterExpr()       {}
+func (FilterCondition) filterExpr() {}
+
+type filterKind int
+
+const (
+	filterKindString filterKind = iota
+	filterKindStatus
+	filterKindID
+	filterKindTime
+)
+
+type filterFieldSpec struct {
+	kind     filterKind
+	nullable bool
+	ops      []FilterOp
+}
+
+var (
+	filterEqualityOps = []FilterOp{FilterOpEq, FilterOpNe, FilterOpIn, FilterOpNotIn}
+	filterOrderedOps  = []FilterOp{FilterOpEq, FilterOpNe, FilterOpLt, FilterOpLte, FilterOpGt, FilterOpGte, FilterOpIn, FilterOpNotIn}
+	filterTextOps     = []FilterOp{FilterOpEq, FilterOpNe, FilterOpContains, FilterOpIn, FilterOpNotIn}
+	filterTimeOps     = []FilterOp{FilterOpEq, FilterOpNe, FilterOpLt, FilterOpLte, FilterOpGt, FilterOpGte}
+)
+
+var filterFields = map[FilterField]filterFieldSpec{
+	FilterFieldID:          {kind: filterKindID, ops: filterOrderedOps},
+	FilterFieldTitle:       {kind: filterKindString, ops: filterTextOps},
+	FilterFieldDescription: {kind: filterKindString, ops: filterTextOps},
+	FilterFieldStatus:      {kind: filterKindStatus, ops: filterEqualityOps},
+	FilterFieldCategoryID:  {kind: filterKindID, nullable: true, ops: filterEqualityOps},
+	FilterFieldDueAt:       {kind: filterKindTime, nullable: true, ops: filterTimeOps},
+	FilterFieldCreatedAt:   {kind: filterKindTime, ops: filterTimeOps},
+	FilterFieldUpdatedAt:   {kind: filterKindTime, ops: filterTimeOps},
+}
+
+type FilterError struct {
+	Pos int
+	Msg string
+}
+
+func (e *FilterError) Error() string {
+	return fmt.Sprintf("filter: %s at position %d", e.Msg, e.Pos+1)
+}
+
+// ParseFilter parses a filter expression such as
+//
+//	status in (Pending, Doing) and due_at < now+7d and title ~ "deploy"
+//
+// Relative times (now, today, now-2h, today+1w) are resolved against now.
+func ParseFilter(input string, now time.Time) (FilterExpr, error) {
+	if len(input) > MaxFilterLength {
+		return nil, &FilterError{Pos: MaxFilterLength, Msg: fmt.Sprintf("expression longer than %d characters", MaxFilterLength)}
+	}
+
+	tokens, err := lexFilter(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterParser{tokens: tokens, now: now}
+	expr, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, &FilterError{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %q", tok.text)}
+	}
+	return expr, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type filterToken struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func isFilterWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-+:.", r)
+}
+
+func lexFilter(input string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, filterToken{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, filterToken{kind: tokenRParen, text: ")", pos: i})
+			i++
+		case r == ',':
+			tokens = append(tokens, filterToken{kind: tokenComma, text: ",", pos: i})
+			i++
+		case r == '"' || r == '\'':
+			start := i
+			var sb strings.Builder
+			i++
+			closed := false
+			for i < len(runes) {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					sb.WriteRune(runes[i+1])
+					i += 2
+					continue
+				}
+				if runes[i] == r {
+					closed = true
+					i++
+					break
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, &FilterError{Pos: start, Msg: "unterminated string"}
+			}
+			tokens = append(tokens, filterToken{kind: tokenString, text: sb.String(), pos: start})
+		case strings.ContainsRune("=!<>~", r):
+			start := i
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' && r != '=' && r != '~' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, &FilterError{Pos: start, Msg: "expected !="}
+			}
+			i += len(op)
+			tokens = append(tokens, filterToken{kind: tokenOp, text: op, pos: start})
+		case isFilterWordRune(r):
+			start := i
+			for i < len(runes) && isFilterWordRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, filterToken{kind: tokenWord, text: string(runes[start:i]), pos: start})
+		default:
+			return nil, &FilterError{Pos: i, Msg: fmt.Sprintf("unexpected character %q", r)}
+		}
+	}
+	tokens = append(tokens, filterToken{kind: tokenEOF, pos: len(runes)})
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+	now    time.Time
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() filterToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *filterParser) keyword(word string) bool {
+	tok := p.peek()
+	if tok.kind == tokenWord && strings.EqualFold(tok.text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) parseOr(depth int) (FilterExpr, error) {
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	exprs := []FilterExpr{left}
+	for p.keyword("or") {
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, right)
+	}
+	if len(exprs) == 1 {
+		return left, nil
+	}
+	return FilterOr{Exprs: exprs}, nil
+}
+
+func (p *filterParser) parseAnd(depth int) (FilterExpr, error) {
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+	exprs := []FilterExpr{left}
+	for p.keyword("and") {
+		right, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, right)
+	}
+	if len(exprs) == 1 {
+		return left, nil
+	}
+	return FilterAnd{Exprs: exprs}, nil
+}
+
+func (p *filterParser) parseUnary(depth int) (FilterExpr, error) {
+	if depth > maxFilterDepth {
+		return nil, &FilterError{Pos: p.peek().pos, Msg: "expression nested too deeply"}
+	}
+	if p.keyword("not") {
+		expr, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return FilterNot{Expr: expr}, nil
+	}
+	if p.peek().kind == tokenLParen {
+		p.next()
+		expr, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.next(); tok.kind != tokenRParen {
+			return nil, &FilterError{Pos: tok.pos, Msg: "expected )"}
+		}
+		return expr, nil
+	}
+	return p.parseCondition()
+}
+
+func (p *filterParser) parseCondition() (FilterExpr, error) {
+	tok := p.next()
+	if tok.kind != tokenWord {
+		return nil, &FilterError{Pos: tok.pos, Msg: "expected field name"}
+	}
+	field := FilterField(strings.ToLower(tok.text))
+	spec, ok := filterFields[field]
+	if !ok {
+		return nil, &FilterError{Pos: tok.pos, Msg: fmt.Sprintf("unknown field %q", tok.text)}
+	}
+
+	opTok := p.peek()
+	var op FilterOp
+	switch {
+	case opTok.kind == tokenOp:
+		p.next()
+		op = FilterOp(opTok.text)
+	case p.keyword("in"):
+		op = FilterOpIn
+	case p.keyword("not"):
+		if !p.keyword("in") {
+			return nil, &FilterError{Pos: p.peek().pos, Msg: "expected in after not"}
+		}
+		op = FilterOpNotIn
+	case p.keyword("is"):
+		op = FilterOpIsNull
+		if p.keyword("not") {
+			op = FilterOpNotNull
+		}
+		if !p.keyword("null") {
+			return nil, &FilterError{Pos: p.peek().pos, Msg: "expected null"}
+		}
+	default:
+		return nil, &FilterError{Pos: opTok.pos, Msg: "expected operator"}
+	}
+
+	if op == FilterOpIsNull || op == FilterOpNotNull {
+		if !spec.nullable {
+			return nil, &FilterError{Pos: opTok.pos, Msg: fmt.Sprintf("%s cannot be null", field)}
+		}
+		return FilterCondition{Field: field, Op: op}, nil
+	}
+
+	if !containsFilterOp(spec.ops, op) {
+		return nil, &FilterError{Pos: opTok.pos, Msg: fmt.Sprintf("operator %s is not supported for %s", op, field)}
+	}
+
+	if op == FilterOpIn || op == FilterOpNotIn {
+		if tok := p.next(); tok.kind != tokenLParen {
+			return nil, &FilterError{Pos: tok.pos, Msg: "expected ( after in"}
+		}
+		var values []interface{}
+		for {
+			value, err := p.parseValue(field, spec)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+			tok := p.next()
+			if tok.kind == tokenRParen {
+				break
+			}
+			if tok.kind != tokenComma {
+				return nil, &FilterError{Pos: tok.pos, Msg: "expected , or )"}
+			}
+		}
+		return FilterCondition{Field: field, Op: op, Values: values}, nil
+	}
+
+	valueTok := p.peek()
+	if valueTok.kind == tokenWord && strings.EqualFold(valueTok.text, "null") && (op == FilterOpEq || op == FilterOpNe) {
+		p.next()
+		if !spec.nullable {
+			return nil, &FilterError{Pos: valueTok.pos, Msg: fmt.Sprintf("%s cannot be null", field)}
+		}
+		if op == FilterOpEq {
+			return FilterCondition{Field: field, Op: FilterOpIsNull}, nil
+		}
+		return FilterCondition{Field: field, Op: FilterOpNotNull}, nil
+	}
+
+	value, err := p.parseValue(field, spec)
+	if err != nil {
+		return nil, err
+	}
+	return FilterCondition{Field: field, Op: op, Values: []interface{}{value}}, nil
+}
+
+func (p *filterParser) parseValue(field FilterField, spec filterFieldSpec) (interface{}, error) {
+	tok := p.next()
+	if tok.kind != tokenWord && tok.kind != tokenString {
+		return nil, &FilterError{Pos: tok.pos, Msg: fmt.Sprintf("expected value for %s", field)}
+	}
+
+	switch spec.kind {
+	case filterKindString:
+		return tok.text, nil
+	case filterKindStatus:
+		for _, status := range []TaskStatus{StatusPending, StatusDoing, StatusDone} {
+			if strings.EqualFold(tok.text, string(status)) {
+				return status, nil
+			}
+		}
+		return nil, &FilterError{Pos: tok.pos, Msg: fmt.Sprintf("invalid status %q", tok.text)}
+	case filterKindID:
+		id, err := strconv.ParseUint(tok.text, 10, 32)
+		if err != nil {
+			return nil, &FilterError{Pos: tok.pos, Msg: fmt.Sprintf("invalid %s %q", field, tok.text)}
+		}
+		return uint(id), nil
+	case filterKindTime:
+		t, err := parseFilterTime(tok.text, p.now)
+		if err != nil {
+			return nil, &FilterError{Pos: tok.pos, Msg: fmt.Sprintf("invalid time %q", tok.text)}
+		}
+		return t, nil
+	}
+	return nil, &FilterError{Pos: tok.pos, Msg: "unsupported value"}
+}
+
+func parseFilterTime(text string, now time.Time) (time.Time, error) {
+	lower := strings.ToLower(text)
+	for _, base := range []string{"now", "today"} {
+		if !strings.HasPrefix(lower, base) {
+			continue
+		}
+		t := now
+		if base == "today" {
+			t = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		}
+		rest := lower[len(base):]
+		if rest == "" {
+			return t, nil
+		}
+		if len(rest) < 3 || (rest[0] != '+' && rest[0] != '-') {
+			break
+		}
+		amount, err := strconv.Atoi(rest[1 : len(rest)-1])
+		if err != nil {
+			break
+		}
+		if rest[0] == '-' {
+			amount = -amount
+		}
+		switch rest[len(rest)-1] {
+		case 'm':
+			return t.Add(time.Duration(amount) * time.Minute), nil
+		case 'h':
+			return t.Add(time.Duration(amount) * time.Hour), nil
+		case 'd':
+			return t.AddDate(0, 0, amount), nil
+		case 'w':
+			return t.AddDate(0, 0, 7*amount), nil
+		}
+		break
+	}
+
+	if t, err := time.Parse(time.RFC3339, text); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", text, now.Location())
+}
+
+func containsFilterOp(ops []FilterOp, op FilterOp) bool {
+	for _, o := range ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
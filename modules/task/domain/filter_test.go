@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func cond(field FilterField, op FilterOp, values ...interface{}) FilterCondition {
+	return FilterCondition{Field: field, Op: op, Values: values}
+}
+
+func idIs(id uint) FilterCondition {
+	return cond(FilterFieldID, FilterOpEq, id)
+}
+
+func TestParseFilter(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 30, 0, 0, time.UTC)
+	today := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		input string
+		want  FilterExpr
+	}{
+		// Operators.
+		{name: "equal", input: "id = 3", want: cond(FilterFieldID, FilterOpEq, uint(3))},
+		{name: "not equal", input: "id != 3", want: cond(FilterFieldID, FilterOpNe, uint(3))},
+		{name: "less", input: "id < 3", want: cond(FilterFieldID, FilterOpLt, uint(3))},
+		{name: "less or equal", input: "id <= 3", want: cond(FilterFieldID, FilterOpLte, uint(3))},
+		{name: "greater", input: "id > 3", want: cond(FilterFieldID, FilterOpGt, uint(3))},
+		{name: "greater or equal", input: "id >= 3", want: cond(FilterFieldID, FilterOpGte, uint(3))},
+		{name: "operators need no spaces", input: "id>=3", want: cond(FilterFieldID, FilterOpGte, uint(3))},
+		{name: "contains", input: "title ~ deploy", want: cond(FilterFieldTitle, FilterOpContains, "deploy")},
+		{name: "in", input: "status in (Pending, doing)", want: cond(FilterFieldStatus, FilterOpIn, StatusPending, StatusDoing)},
+		{name: "not in", input: "status not in (DONE)", want: cond(FilterFieldStatus, FilterOpNotIn, StatusDone)},
+		{name: "is null", input: "category_id is null", want: FilterCondition{Field: FilterFieldCategoryID, Op: FilterOpIsNull}},
+		{name: "is not null", input: "due_at is not null", want: FilterCondition{Field: FilterFieldDueAt, Op: FilterOpNotNull}},
+		{name: "equal null", input: "category_id = null", want: FilterCondition{Field: FilterFieldCategoryID, Op: FilterOpIsNull}},
+		{name: "not equal null", input: "due_at != NULL", want: FilterCondition{Field: FilterFieldDueAt, Op: FilterOpNotNull}},
+		{name: "keywords and fields ignore case", input: "ID = 1 AND Title ~ x", want: FilterAnd{Exprs: []FilterExpr{idIs(1), cond(FilterFieldTitle, FilterOpContains, "x")}}},
+
+		// Precedence and parentheses.
+		{name: "and binds tighter than or", input: "id = 1 or id = 2 and id = 3", want: FilterOr{Exprs: []FilterExpr{idIs(1), FilterAnd{Exprs: []FilterExpr{idIs(2), idIs(3)}}}}},
+		{name: "parentheses group or", input: "(id = 1 or id = 2) and id = 3", want: FilterAnd{Exprs: []FilterExpr{FilterOr{Exprs: []FilterExpr{idIs(1), idIs(2)}}, idIs(3)}}},
+		{name: "chains flatten", input: "id = 1 and id = 2 and id = 3", want: FilterAnd{Exprs: []FilterExpr{idIs(1), idIs(2), idIs(3)}}},
+		{name: "not binds tighter than and", input: "not id = 1 and id = 2", want: FilterAnd{Exprs: []FilterExpr{FilterNot{Expr: idIs(1)}, idIs(2)}}},
+		{name: "not of a group", input: "not (id = 1 or id = 2)", want: FilterNot{Expr: FilterOr{Exprs: []FilterExpr{idIs(1), idIs(2)}}}},
+		{name: "double not", input: "not not id = 1", want: FilterNot{Expr: FilterNot{Expr: idIs(1)}}},
+		{name: "redundant parentheses", input: "((id = 1))", want: idIs(1)},
+
+		// Quoting and escapes.
+		{name: "double quotes", input: `title = "a and b"`, want: cond(FilterFieldTitle, FilterOpEq, "a and b")},
+		{name: "single quotes", input: `title = 'say "hi"'`, want: cond(FilterFieldTitle, FilterOpEq, `say "hi"`)},
+		{name: "escaped quote", input: `title = "say \"hi\""`, want: cond(FilterFieldTitle, FilterOpEq, `say "hi"`)},
+		{name: "escaped single quote", input: `title = 'it\'s'`, want: cond(FilterFieldTitle, FilterOpEq, "it's")},
+		{name: "escaped backslash", input: `title = "C:\\temp"`, want: cond(FilterFieldTitle, FilterOpEq, `C:\temp`)},
+		{name: "empty string", input: `description = ""`, want: cond(FilterFieldDescription, FilterOpEq, "")},
+		{name: "keywords inside quotes", input: `title ~ "not null"`, want: cond(FilterFieldTitle, FilterOpContains, "not null")},
+		{name: "unicode", input: `title ~ 'café'`, want: cond(FilterFieldTitle, FilterOpContains, "café")},
+		{name: "quoted status", input: `status = "Doing"`, want: cond(FilterFieldStatus, FilterOpEq, StatusDoing)},
+
+		// Relative and absolute times.
+		{name: "now", input: "due_at < now", want: cond(FilterFieldDueAt, FilterOpLt, now)},
+		{name: "today", input: "due_at >= today", want: cond(FilterFieldDueAt, FilterOpGte, today)},
+		{name: "minutes", input: "updated_at > now-30m", want: cond(FilterFieldUpdatedAt, FilterOpGt, now.Add(-30*time.Minute))},
+		{name: "hours", input: "created_at > now-2h", want: cond(FilterFieldCreatedAt, FilterOpGt, now.Add(-2*time.Hour))},
+		{name: "days", input: "due_at < now+7d", want: cond(FilterFieldDueAt, FilterOpLt, now.AddDate(0, 0, 7))},
+		{name: "weeks from today", input: "due_at < today+1w", want: cond(FilterFieldDueAt, FilterOpLt, today.AddDate(0, 0, 7))},
+		{name: "relative time ignores case", input: "due_at < NOW-1D", want: cond(FilterFieldDueAt, FilterOpLt, now.AddDate(0, 0, -1))},
+		{name: "date", input: "due_at = 2026-03-01", want: cond(FilterFieldDueAt, FilterOpEq, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))},
+		{name: "RFC 3339", input: "due_at < 2026-03-01T08:00:00Z", want: cond(FilterFieldDueAt, FilterOpLt, time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC))},
+
+		// In lists.
+		{name: "in ids", input: "id in (1, 2, 3)", want: cond(FilterFieldID, FilterOpIn, uint(1), uint(2), uint(3))},
+		{name: "in single value", input: "category_id in (4)", want: cond(FilterFieldCategoryID, FilterOpIn, uint(4))},
+		{name: "in strings", input: `title in ("a, b", c)`, want: cond(FilterFieldTitle, FilterOpIn, "a, b", "c")},
+		{name: "in without spaces", input: "id not in(1,2)", want: cond(FilterFieldID, FilterOpNotIn, uint(1), uint(2))},
+		{
+			name:  "in combined",
+			input: `status in (Pending,Doing) and due_at < now+7d and category_id = 3 and title ~ "deploy"`,
+			want: FilterAnd{Exprs: []FilterExpr{
+				cond(FilterFieldStatus, FilterOpIn, StatusPending, StatusDoing),
+				cond(FilterFieldDueAt, FilterOpLt, now.AddDate(0, 0, 7)),
+				cond(FilterFieldCategoryID, FilterOpEq, uint(3)),
+				cond(FilterFieldTitle, FilterOpContains, "deploy"),
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFilter(tt.input, now)
+			if err != nil {
+				t.Fatalf("ParseFilter(%q): %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFilter(%q) = %#v, want %#v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		input   string
+		wantPos int
+		wantMsg string
+	}{
+		{name: "empty", input: "", wantPos: 0, wantMsg: "expected field name"},
+		{name: "unknown field", input: "priority = 1", wantPos: 0, wantMsg: `unknown field "priority"`},
+		{name: "missing operator", input: "id 3", wantPos: 3, wantMsg: "expected operator"},
+		{name: "bang without equals", input: "id ! 3", wantPos: 3, wantMsg: "expected !="},
+		{name: "unsupported operator", input: "title < x", wantPos: 6, wantMsg: "operator < is not supported for title"},
+		{name: "contains on id", input: "id ~ 3", wantPos: 3, wantMsg: "operator ~ is not supported for id"},
+		{name: "missing value", input: "id =", wantPos: 4, wantMsg: "expected value for id"},
+		{name: "dangling and", input: "id = 1 and", wantPos: 10, wantMsg: "expected field name"},
+		{name: "unexpected character", input: "id = 1 & id = 2", wantPos: 7, wantMsg: `unexpected character '&'`},
+		{name: "invalid status", input: "status = Blocked", wantPos: 9, wantMsg: `invalid status "Blocked"`},
+		{name: "invalid id", input: "id = abc", wantPos: 5, wantMsg: `invalid id "abc"`},
+		{name: "negative id", input: "id = -1", wantPos: 5, wantMsg: `invalid id "-1"`},
+		{name: "unknown time", input: "due_at < tomorrow", wantPos: 9, wantMsg: `invalid time "tomorrow"`},
+		{name: "unknown time unit", input: "due_at < now+7y", wantPos: 9, wantMsg: `invalid time "now+7y"`},
+		{name: "relative time without amount", input: "due_at < now+d", wantPos: 9, wantMsg: `invalid time "now+d"`},
+		{name: "unterminated string", input: `title = "open`, wantPos: 8, wantMsg: "unterminated string"},
+		{name: "unbalanced close", input: "id = 1 )", wantPos: 7, wantMsg: `unexpected ")"`},
+		{name: "unbalanced open", input: "(id = 1", wantPos: 7, wantMsg: "expected )"},
+		{name: "trailing token", input: "id = 1 id = 2", wantPos: 7, wantMsg: `unexpected "id"`},
+		{name: "in without list", input: "id in 1, 2", wantPos: 6, wantMsg: "expected ( after in"},
+		{name: "in missing comma", input: "id in (1 2)", wantPos: 9, wantMsg: "expected , or )"},
+		{name: "in empty list", input: "id in ()", wantPos: 7, wantMsg: "expected value for id"},
+		{name: "in unterminated list", input: "id in (1,", wantPos: 9, wantMsg: "expected value for id"},
+		{name: "in on time", input: "due_at in (now)", wantPos: 7, wantMsg: "operator in is not supported for due_at"},
+		{name: "not without in", input: "id not 3", wantPos: 7, wantMsg: "expected in after not"},
+		{name: "is without null", input: "category_id is 3", wantPos: 15, wantMsg: "expected null"},
+		{name: "null on required field", input: "title is null", wantPos: 6, wantMsg: "title cannot be null"},
+		{name: "equal null on required field", input: "id = null", wantPos: 5, wantMsg: "id cannot be null"},
+		{name: "positions count characters", input: `title = "café" and x = 1`, wantPos: 19, wantMsg: `unknown field "x"`},
+		{name: "nested too deeply", input: strings.Repeat("(", 40) + "id = 1" + strings.Repeat(")", 40), wantPos: 33, wantMsg: "expression nested too deeply"},
+		{name: "too long", input: "title ~ " + strings.Repeat("x", MaxFilterLength), wantPos: MaxFilterLength, wantMsg: "expression longer than 2000 characters"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := ParseFilter(tt.input, now)
+			var ferr *FilterError
+			if !errors.As(err, &ferr) {
+				t.Fatalf("ParseFilter(%q) = %#v, %v; want a *FilterError", tt.input, expr, err)
+			}
+			if ferr.Pos != tt.wantPos || ferr.Msg != tt.wantMsg {
+				t.Errorf("ParseFilter(%q) error = %q at %d, want %q at %d", tt.input, ferr.Msg, ferr.Pos, tt.wantMsg, tt.wantPos)
+			}
+		})
+	}
+}
+
+func TestFilterErrorReportsOneBasedPosition(t *testing.T) {
+	_, err := ParseFilter("id = abc", time.Now())
+	if want := `filter: invalid id "abc" at position 6`; err == nil || err.Error() != want {
+		t.Errorf("error = %v, want %s", err, want)
+	}
+}
@@ -2,10 +2,11 @@ package domain
 
 import (
 	"context"
+	"iter"
 	"time"
 
-	"github.com/ltphat2204/domain-driven-golang/modules/category/domain"
 	"github.com/ltphat2204/domain-driven-golang/common"
+	"github.com/ltphat2204/domain-driven-golang/modules/category/domain"
 )
 
 type TaskStatus string
@@ -26,6 +27,9 @@ type Task struct {
 	DueAt       *time.Time       `gorm:"type:timestamp"`
 	CategoryID  *uint            `gorm:"foreignKey:CategoryID"` // Foreign key for Category
 	Category    *domain.Category `gorm:"foreignKey:CategoryID"` // Association with Category
+	// Populated only when the query has a search term.
+	SearchRank      float64 `gorm:"->;-:migration" json:",omitempty"`
+	SearchHighlight string  `gorm:"->;-:migration" json:",omitempty"`
 }
 
 type TaskQuery struct {
@@ -34,12 +38,47 @@ type TaskQuery struct {
 	SortBy    string
 	SortOrder string
 	Status    *TaskStatus
+	Filter    FilterExpr
+	// PerCategory, if set, keeps only the first tasks of each category, and
+	// of those without one, before the page is taken. It cannot be combined
+	// with sorting by relevance.
+	PerCategory int
+}
+
+// SortByRelevance orders search results by rank and requires a search term.
+const SortByRelevance = "relevance"
+
+var (
+	TaskSortFields = []string{"title", "due_at", "created_at", SortByRelevance}
+	SortOrders     = []string{"asc", "desc"}
+)
+
+// EffectiveSort returns the sort actually applied to the query. Both SortBy
+// and SortOrder must be set to override the default of newest first, except
+// for relevance which is always best match first.
+func (q *TaskQuery) EffectiveSort() (string, string) {
+	if q.SortBy == SortByRelevance {
+		return SortByRelevance, "desc"
+	}
+	if q.SortBy != "" && q.SortOrder != "" {
+		return q.SortBy, q.SortOrder
+	}
+	return "created_at", "desc"
+}
+
+// SupportsCursor reports whether the query's sort can be paginated by cursor.
+func (q *TaskQuery) SupportsCursor() bool {
+	sortBy, _ := q.EffectiveSort()
+	return sortBy != SortByRelevance
 }
 
 type TaskRepository interface {
 	Save(ctx context.Context, task *Task) (*Task, error)
 	FindByID(ctx context.Context, id uint) (*Task, error)
 	FindTasks(ctx context.Context, query *TaskQuery) ([]*Task, int, error)
+	// All yields every task in ID order with its category, without loading
+	// them all at once.
+	All(ctx context.Context) iter.Seq2[*Task, error]
 	Update(ctx context.Context, task *Task) (*Task, error)
 	Delete(ctx context.Context, id uint) error
 }
@@ -52,3 +91,22 @@ func IsValidTaskStatus(status TaskStatus) bool {
 		return false
 	}
 }
+
+// SortKey returns the value of the given sort field as stored in a cursor.
+func (t *Task) SortKey(field string) *string {
+	var value string
+	switch field {
+	case "title":
+		value = t.Title
+	case "due_at":
+		if t.DueAt == nil {
+			return nil
+		}
+		value = t.DueAt.UTC().Format(time.RFC3339Nano)
+	case "created_at":
+		value = t.CreatedAt.UTC().Format(time.RFC3339Nano)
+	default:
+		return nil
+	}
+	return &value
+}
@@ -22,13 +22,37 @@ type TaskUpdateDTO struct {
 	CategoryID  *uint      `json:"category_id"`
 }
 
+type TaskBulkDTO struct {
+	// Mode is atomic (the default) or best_effort.
+	Mode       string                 `json:"mode"`
+	Operations []TaskBulkOperationDTO `json:"operations" binding:"required"`
+}
+
+// TaskBulkOperationDTO is one operation. Op is create, update, set_status,
+// move or delete, and each takes only its own fields: create takes title
+// (required), description, due_at and category_id; update takes title,
+// description, status and due_at; set_status takes status; move takes
+// category_id, leaving the task uncategorized without it.
+type TaskBulkOperationDTO struct {
+	Op          string     `json:"op" binding:"required"`
+	ID          uint       `json:"id"`
+	Title       *string    `json:"title"`
+	Description *string    `json:"description"`
+	Status      *string    `json:"status"`
+	DueAt       *time.Time `json:"due_at"`
+	CategoryID  *uint      `json:"category_id"`
+}
+
 type TaskQueryDTO struct {
-	Page      int    `form:"page" binding:"omitempty,gte=1"`
-	PageSize  int    `form:"page_size" binding:"omitempty,gte=1"`
-	Search    string `form:"search"`
-	SortBy    string `form:"sort_by"`
-	SortOrder string `form:"sort_order"`
-	Status    string `form:"status"`
+	Page         int    `form:"page" binding:"omitempty,gte=1"`
+	PageSize     int    `form:"page_size" binding:"omitempty,gte=1"`
+	Search       string `form:"search"`
+	SortBy       string `form:"sort_by"`
+	SortOrder    string `form:"sort_order"`
+	Status       string `form:"status"`
+	Filter       string `form:"filter"`
+	Cursor       string `form:"cursor"`
+	IncludeTotal *bool  `form:"include_total"`
 }
 
 type TaskListResponse struct {
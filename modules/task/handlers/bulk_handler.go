@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ltphat2204/domain-driven-golang/common"
+	"github.com/ltphat2204/domain-driven-golang/modules/task/application"
+	"github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/task/dto"
+)
+
+type BulkHandler struct {
+	service application.BulkService
+}
+
+func NewBulkHandler(service application.BulkService) *BulkHandler {
+	return &BulkHandler{service: service}
+}
+
+// BulkTasks answers 200 with a result per operation once they have run,
+// even if some failed or an atomic request was rolled back, and 400 without
+// running any if one is invalid.
+func (h *BulkHandler) BulkTasks(c *gin.Context) {
+	var input dto.TaskBulkDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), err.Error()))
+		return
+	}
+
+	mode := domain.BulkAtomic
+	if input.Mode != "" {
+		mode = domain.BulkMode(input.Mode)
+	}
+	ops := make([]domain.BulkOperation, len(input.Operations))
+	for i, op := range input.Operations {
+		ops[i] = domain.BulkOperation{
+			Op:          domain.BulkOp(op.Op),
+			ID:          op.ID,
+			Title:       op.Title,
+			Description: op.Description,
+			DueAt:       op.DueAt,
+			CategoryID:  op.CategoryID,
+		}
+		if op.Status != nil {
+			status := domain.TaskStatus(*op.Status)
+			ops[i].Status = &status
+		}
+	}
+
+	result, err := h.service.Run(c.Request.Context(), mode, ops)
+	var invalid *domain.BulkValidationError
+	if errors.As(err, &invalid) {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponse(c.Request.Context(), http.StatusBadRequest, "Invalid bulk request", err.Error()))
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(c.Request.Context(), http.StatusInternalServerError, "Failed to run bulk operations", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, common.NewSuccessResponse(result))
+}
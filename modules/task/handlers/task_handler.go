@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/ltphat2204/domain-driven-golang/common"
@@ -22,13 +23,13 @@ func NewTaskHandler(service application.TaskService) *TaskHandler {
 func (h *TaskHandler) CreateTask(c *gin.Context) {
 	var input dto.TaskCreateDTO
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(err.Error()))
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), err.Error()))
 		return
 	}
 
 	task, err := h.service.CreateTask(c.Request.Context(), input.Title, input.Description, input.DueAt, input.CategoryID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(http.StatusInternalServerError, "Failed to create task", err.Error()))
+		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(c.Request.Context(), http.StatusInternalServerError, "Failed to create task", err.Error()))
 		return
 	}
 
@@ -38,13 +39,13 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 func (h *TaskHandler) GetTask(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse("Invalid ID"))
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Invalid ID"))
 		return
 	}
 
 	task, err := h.service.GetTaskByID(c.Request.Context(), uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, common.NewErrorResponse(http.StatusNotFound, "Task not found", err.Error()))
+		c.JSON(http.StatusNotFound, common.NewErrorResponse(c.Request.Context(), http.StatusNotFound, "Task not found", err.Error()))
 		return
 	}
 
@@ -54,7 +55,7 @@ func (h *TaskHandler) GetTask(c *gin.Context) {
 func (h *TaskHandler) GetTasks(c *gin.Context) {
 	var queryDTO dto.TaskQueryDTO
 	if err := c.ShouldBindQuery(&queryDTO); err != nil {
-		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(err.Error()))
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), err.Error()))
 		return
 	}
 
@@ -63,21 +64,23 @@ func (h *TaskHandler) GetTasks(c *gin.Context) {
 	if queryDTO.Page > 0 {
 		page = queryDTO.Page
 	}
-	pageSize := 10
+	pageSize := common.DefaultPageSize()
 	if queryDTO.PageSize > 0 {
 		pageSize = queryDTO.PageSize
 	}
 
-	allowedSortFields := []string{"title", "due_at", "created_at"}
-	allowedSortOrders := []string{"asc", "desc"}
+	if queryDTO.SortBy != "" && !contains(domain.TaskSortFields, queryDTO.SortBy) {
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Invalid sort_by"))
+		return
+	}
 
-	if queryDTO.SortBy != "" && !contains(allowedSortFields, queryDTO.SortBy) {
-		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse("Invalid sort_by"))
+	if queryDTO.SortOrder != "" && !contains(domain.SortOrders, queryDTO.SortOrder) {
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Invalid sort_order"))
 		return
 	}
 
-	if queryDTO.SortOrder != "" && !contains(allowedSortOrders, queryDTO.SortOrder) {
-		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse("Invalid sort_order"))
+	if queryDTO.SortBy == domain.SortByRelevance && queryDTO.Search == "" {
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "sort_by=relevance requires search"))
 		return
 	}
 
@@ -85,44 +88,73 @@ func (h *TaskHandler) GetTasks(c *gin.Context) {
 	if queryDTO.Status != "" {
 		s := domain.TaskStatus(queryDTO.Status)
 		if !domain.IsValidTaskStatus(s) {
-			c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse("Invalid status"))
+			c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Invalid status"))
 			return
 		}
 		status = &s
 	}
 
+	var filter domain.FilterExpr
+	if queryDTO.Filter != "" {
+		f, err := domain.ParseFilter(queryDTO.Filter, time.Now())
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.NewErrorResponse(c.Request.Context(), http.StatusBadRequest, "Invalid filter", err.Error()))
+			return
+		}
+		filter = f
+	}
+
 	query := &domain.TaskQuery{
 		BaseQuery: common.BaseQuery{
-			Page:     page,
-			PageSize: pageSize,
+			Page:      page,
+			PageSize:  pageSize,
+			SkipTotal: queryDTO.IncludeTotal != nil && !*queryDTO.IncludeTotal,
 		},
 		Search:    queryDTO.Search,
 		SortBy:    queryDTO.SortBy,
 		SortOrder: queryDTO.SortOrder,
 		Status:    status,
+		Filter:    filter,
+	}
+
+	sortBy, sortOrder := query.EffectiveSort()
+	if queryDTO.Cursor != "" {
+		if !query.SupportsCursor() {
+			c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Cursor pagination is not supported with sort_by=relevance"))
+			return
+		}
+		cursor, err := common.DecodeCursor(queryDTO.Cursor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Invalid cursor"))
+			return
+		}
+		if cursor.SortBy != sortBy || cursor.SortOrder != sortOrder {
+			c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Cursor does not match sort_by and sort_order"))
+			return
+		}
+		query.Cursor = cursor
 	}
 
 	tasks, total, err := h.service.GetTasks(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(http.StatusInternalServerError, "Failed to retrieve tasks", err.Error()))
+		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(c.Request.Context(), http.StatusInternalServerError, "Failed to retrieve tasks", err.Error()))
 		return
 	}
 
-	totalPages := 0
-	if pageSize > 0 {
-		totalPages = (total + pageSize - 1) / pageSize
-	}
-
-	meta := common.PaginationMeta{
-		Total:      total,
-		Page:       page,
-		PageSize:   pageSize,
-		TotalPages: totalPages,
+	var last *common.Cursor
+	if len(tasks) > 0 && query.SupportsCursor() {
+		lastTask := tasks[len(tasks)-1]
+		last = &common.Cursor{
+			SortBy:    sortBy,
+			SortOrder: sortOrder,
+			Value:     lastTask.SortKey(sortBy),
+			ID:        lastTask.ID,
+		}
 	}
 
 	response := dto.TaskListResponse{
 		Tasks: tasks,
-		Meta:  meta,
+		Meta:  common.NewPaginationMeta(query.BaseQuery, total, last),
 	}
 
 	c.JSON(http.StatusOK, common.NewSuccessResponse(response))
@@ -131,13 +163,13 @@ func (h *TaskHandler) GetTasks(c *gin.Context) {
 func (h *TaskHandler) UpdateTask(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse("Invalid ID"))
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Invalid ID"))
 		return
 	}
 
 	var input dto.TaskUpdateDTO
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(err.Error()))
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), err.Error()))
 		return
 	}
 
@@ -145,7 +177,7 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 	if input.Status != nil {
 		s := domain.TaskStatus(*input.Status)
 		if !domain.IsValidTaskStatus(s) {
-			c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse("Invalid status"))
+			c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Invalid status"))
 			return
 		}
 		status = &s
@@ -153,7 +185,7 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 
 	task, err := h.service.UpdateTask(c.Request.Context(), uint(id), input.Title, input.Description, status, input.DueAt, input.CategoryID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(http.StatusInternalServerError, "Failed to update task", err.Error()))
+		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(c.Request.Context(), http.StatusInternalServerError, "Failed to update task", err.Error()))
 		return
 	}
 
@@ -163,12 +195,12 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 func (h *TaskHandler) DeleteTask(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse("Invalid ID"))
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Invalid ID"))
 		return
 	}
 
 	if err := h.service.DeleteTask(c.Request.Context(), uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(http.StatusInternalServerError, "Failed to delete task", err.Error()))
+		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(c.Request.Context(), http.StatusInternalServerError, "Failed to delete task", err.Error()))
 		return
 	}
 
@@ -0,0 +1,299 @@
+package infrastructure
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ltphat2204/domain-driven-golang/database"
+	"github.com/ltphat2204/domain-driven-golang/fulltext"
+	categoryDomain "github.com/ltphat2204/domain-driven-golang/modules/category/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	"gorm.io/gorm"
+)
+
+// memoryTaskRepository keeps tasks in a map. It mirrors the search, filter,
+// sort and pagination behaviour of the GORM repository so it can stand in for
+// it in development and tests. Categories are looked up in categories to
+// check references and to fill in Task.Category.
+type memoryTaskRepository struct {
+	mu         sync.RWMutex
+	nextID     uint
+	tasks      map[uint]domain.Task
+	categories categoryDomain.CategoryRepository
+}
+
+// categoryReferrers is implemented by the memory category repository, which
+// asks its referrers before deleting a category.
+type categoryReferrers interface {
+	AddReferrer(referenced func(id uint) bool)
+}
+
+func NewMemoryTaskRepository(categories categoryDomain.CategoryRepository) domain.TaskRepository {
+	r := &memoryTaskRepository{tasks: make(map[uint]domain.Task), categories: categories}
+	if referrers, ok := categories.(categoryReferrers); ok {
+		referrers.AddReferrer(r.refersTo)
+	}
+	return r
+}
+
+func (r *memoryTaskRepository) Save(ctx context.Context, task *domain.Task) (*domain.Task, error) {
+	if err := r.checkCategory(ctx, task); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if task.ID == 0 {
+		r.nextID++
+		task.ID = r.nextID
+	} else if _, exists := r.tasks[task.ID]; exists {
+		return nil, fmt.Errorf("task %d already exists", task.ID)
+	} else {
+		r.nextID = max(r.nextID, task.ID)
+	}
+	now := time.Now()
+	if task.CreatedAt.IsZero() {
+		task.CreatedAt = now
+	}
+	if task.UpdatedAt.IsZero() {
+		task.UpdatedAt = now
+	}
+	if task.Status == "" {
+		task.Status = domain.StatusPending
+	}
+	r.tasks[task.ID] = storedTask(task)
+	return task, nil
+}
+
+func (r *memoryTaskRepository) FindByID(ctx context.Context, id uint) (*domain.Task, error) {
+	r.mu.RLock()
+	task, ok := r.tasks[id]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	if err := r.preloadCategory(ctx, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (r *memoryTaskRepository) FindTasks(ctx context.Context, query *domain.TaskQuery) ([]*domain.Task, int, error) {
+	sortBy, sortOrder := query.EffectiveSort()
+	column, ok := taskSortColumns[sortBy]
+	switch {
+	case sortBy == domain.SortByRelevance:
+		if query.Search == "" {
+			return nil, 0, fmt.Errorf("sorting by relevance requires a search term")
+		}
+		if query.Cursor != nil {
+			return nil, 0, fmt.Errorf("cursor pagination is not supported when sorting by relevance")
+		}
+	case !ok:
+		return nil, 0, fmt.Errorf("unsupported sort field: %s", sortBy)
+	}
+	if query.Cursor != nil {
+		if err := database.CheckCursor(column, query.Cursor); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	r.mu.RLock()
+	var tasks []*domain.Task
+	for _, t := range r.tasks {
+		task := t
+		if query.Search != "" {
+			task.SearchRank = fulltext.Score(query.Search, task.Title, task.Description)
+			if task.SearchRank == 0 {
+				continue
+			}
+		}
+		if query.Status != nil && task.Status != *query.Status {
+			continue
+		}
+		if query.Filter != nil {
+			match, err := evalFilter(query.Filter, &task)
+			if err != nil {
+				r.mu.RUnlock()
+				return nil, 0, err
+			}
+			if match != truthTrue {
+				continue
+			}
+		}
+		tasks = append(tasks, &task)
+	}
+	r.mu.RUnlock()
+
+	if sortBy == domain.SortByRelevance {
+		slices.SortFunc(tasks, func(a, b *domain.Task) int {
+			if c := cmp.Compare(b.SearchRank, a.SearchRank); c != 0 {
+				return c
+			}
+			return cmp.Compare(b.ID, a.ID)
+		})
+	} else {
+		slices.SortFunc(tasks, func(a, b *domain.Task) int {
+			return database.Compare(column, sortOrder, a.SortKey(sortBy), a.ID, b.SortKey(sortBy), b.ID)
+		})
+	}
+
+	total := 0
+	if !query.SkipTotal {
+		total = len(tasks)
+	}
+
+	if query.PerCategory > 0 {
+		if sortBy == domain.SortByRelevance {
+			return nil, 0, fmt.Errorf("a per-category limit cannot be combined with sorting by relevance")
+		}
+		counts := make(map[uint]int)
+		tasks = slices.DeleteFunc(tasks, func(t *domain.Task) bool {
+			var categoryID uint
+			if t.CategoryID != nil {
+				categoryID = *t.CategoryID
+			}
+			counts[categoryID]++
+			return counts[categoryID] > query.PerCategory
+		})
+	}
+
+	tasks = database.Paginate(tasks, &query.BaseQuery, func(t *domain.Task) bool {
+		return database.Compare(column, sortOrder, t.SortKey(sortBy), t.ID, query.Cursor.Value, query.Cursor.ID) > 0
+	})
+
+	for _, task := range tasks {
+		if err := r.preloadCategory(ctx, task); err != nil {
+			return nil, 0, err
+		}
+		if query.Search != "" {
+			task.SearchHighlight = fulltext.Highlight(query.Search, task.Title+" "+task.Description)
+		}
+	}
+
+	return tasks, total, nil
+}
+
+// All yields a copy of the tasks taken when iteration starts, so the caller
+// may write to the repository as it goes.
+func (r *memoryTaskRepository) All(ctx context.Context) iter.Seq2[*domain.Task, error] {
+	return func(yield func(*domain.Task, error) bool) {
+		r.mu.RLock()
+		tasks := slices.SortedFunc(maps.Values(r.tasks), func(a, b domain.Task) int { return cmp.Compare(a.ID, b.ID) })
+		r.mu.RUnlock()
+		for _, task := range tasks {
+			if err := r.preloadCategory(ctx, &task); err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(&task, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (r *memoryTaskRepository) Update(ctx context.Context, task *domain.Task) (*domain.Task, error) {
+	if err := r.checkCategory(ctx, task); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	if task.ID == 0 {
+		r.nextID++
+		task.ID = r.nextID
+	}
+	r.nextID = max(r.nextID, task.ID)
+	now := time.Now()
+	if task.CreatedAt.IsZero() {
+		task.CreatedAt = now
+	}
+	task.UpdatedAt = now
+	r.tasks[task.ID] = storedTask(task)
+	r.mu.Unlock()
+
+	return r.FindByID(ctx, task.ID)
+}
+
+func (r *memoryTaskRepository) Delete(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.tasks, id)
+	return nil
+}
+
+// Snapshot implements database.Snapshotter, so a memory transactor can roll
+// back the tasks.
+func (r *memoryTaskRepository) Snapshot() func() {
+	r.mu.RLock()
+	nextID, tasks := r.nextID, maps.Clone(r.tasks)
+	r.mu.RUnlock()
+	return func() {
+		r.mu.Lock()
+		r.nextID, r.tasks = nextID, tasks
+		r.mu.Unlock()
+	}
+}
+
+// checkCategory rejects a task pointing at a missing category, as the foreign
+// key does in SQL.
+func (r *memoryTaskRepository) checkCategory(ctx context.Context, task *domain.Task) error {
+	if task.CategoryID == nil {
+		return nil
+	}
+	_, err := r.categories.FindByID(ctx, *task.CategoryID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("category %d does not exist", *task.CategoryID)
+	}
+	return err
+}
+
+// refersTo reports whether a task is in the category. The category
+// repository calls it holding its lock, so the task repository must not hold
+// its own while calling the category repository.
+func (r *memoryTaskRepository) refersTo(categoryID uint) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, task := range r.tasks {
+		if task.CategoryID != nil && *task.CategoryID == categoryID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *memoryTaskRepository) preloadCategory(ctx context.Context, task *domain.Task) error {
+	task.Category = nil
+	if task.CategoryID == nil {
+		return nil
+	}
+	category, err := r.categories.FindByID(ctx, *task.CategoryID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("loading category %d: %w", *task.CategoryID, err)
+	}
+	task.Category = category
+	return nil
+}
+
+// storedTask returns the copy of task that is kept in the map, without the
+// association and the per-query search fields.
+func storedTask(task *domain.Task) domain.Task {
+	t := *task
+	t.Category = nil
+	t.SearchRank = 0
+	t.SearchHighlight = ""
+	return t
+}
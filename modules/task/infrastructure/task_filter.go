@@ -0,0 +1,255 @@
+package infrastructure
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+)
+
+var filterColumns = map[domain.FilterField]string{
+	domain.FilterFieldID:          "id",
+	domain.FilterFieldTitle:       "title",
+	domain.FilterFieldDescription: "description",
+	domain.FilterFieldStatus:      "status",
+	domain.FilterFieldCategoryID:  "category_id",
+	domain.FilterFieldDueAt:       "due_at",
+	domain.FilterFieldCreatedAt:   "created_at",
+	domain.FilterFieldUpdatedAt:   "updated_at",
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// compileFilter turns a parsed filter into a WHERE fragment. Column names only
+// ever come from filterColumns; every value is passed as a bind parameter.
+func compileFilter(expr domain.FilterExpr) (string, []interface{}, error) {
+	switch e := expr.(type) {
+	case domain.FilterAnd:
+		return compileFilterList(e.Exprs, " AND ")
+	case domain.FilterOr:
+		return compileFilterList(e.Exprs, " OR ")
+	case domain.FilterNot:
+		sql, args, err := compileFilter(e.Expr)
+		if err != nil {
+			return "", nil, err
+		}
+		return "NOT (" + sql + ")", args, nil
+	case domain.FilterCondition:
+		return compileFilterCondition(e)
+	}
+	return "", nil, fmt.Errorf("unsupported filter expression %T", expr)
+}
+
+func compileFilterList(exprs []domain.FilterExpr, separator string) (string, []interface{}, error) {
+	parts := make([]string, 0, len(exprs))
+	var args []interface{}
+	for _, expr := range exprs {
+		sql, exprArgs, err := compileFilter(expr)
+		if err != nil {
+			return "", nil, err
+		}
+		parts = append(parts, "("+sql+")")
+		args = append(args, exprArgs...)
+	}
+	return strings.Join(parts, separator), args, nil
+}
+
+func compileFilterCondition(cond domain.FilterCondition) (string, []interface{}, error) {
+	column, ok := filterColumns[cond.Field]
+	if !ok {
+		return "", nil, fmt.Errorf("unsupported filter field %q", cond.Field)
+	}
+
+	switch cond.Op {
+	case domain.FilterOpIsNull:
+		return column + " IS NULL", nil, nil
+	case domain.FilterOpNotNull:
+		return column + " IS NOT NULL", nil, nil
+	case domain.FilterOpIn:
+		return column + " IN ?", []interface{}{cond.Values}, nil
+	case domain.FilterOpNotIn:
+		return "(" + column + " NOT IN ? OR " + column + " IS NULL)", []interface{}{cond.Values}, nil
+	case domain.FilterOpContains:
+		value, ok := cond.Values[0].(string)
+		if !ok {
+			return "", nil, fmt.Errorf("operator ~ needs a string value")
+		}
+		return "LOWER(" + column + `) LIKE ? ESCAPE '\'`, []interface{}{"%" + likeEscaper.Replace(strings.ToLower(value)) + "%"}, nil
+	case domain.FilterOpNe:
+		return "(" + column + " <> ? OR " + column + " IS NULL)", []interface{}{cond.Values[0]}, nil
+	case domain.FilterOpEq, domain.FilterOpLt, domain.FilterOpLte, domain.FilterOpGt, domain.FilterOpGte:
+		return column + " " + string(cond.Op) + " ?", []interface{}{cond.Values[0]}, nil
+	}
+	return "", nil, fmt.Errorf("unsupported filter operator %q", cond.Op)
+}
+
+// truth is a SQL boolean: comparisons against NULL are unknown, and NOT
+// unknown is still unknown, so rows match only when the result is true.
+type truth int
+
+const (
+	truthFalse truth = iota
+	truthUnknown
+	truthTrue
+)
+
+func truthOf(b bool) truth {
+	if b {
+		return truthTrue
+	}
+	return truthFalse
+}
+
+// evalFilter evaluates a parsed filter against a task with the same semantics
+// as the SQL produced by compileFilter.
+func evalFilter(expr domain.FilterExpr, task *domain.Task) (truth, error) {
+	switch e := expr.(type) {
+	case domain.FilterAnd:
+		result := truthTrue
+		for _, sub := range e.Exprs {
+			t, err := evalFilter(sub, task)
+			if err != nil {
+				return truthFalse, err
+			}
+			result = min(result, t)
+		}
+		return result, nil
+	case domain.FilterOr:
+		result := truthFalse
+		for _, sub := range e.Exprs {
+			t, err := evalFilter(sub, task)
+			if err != nil {
+				return truthFalse, err
+			}
+			result = max(result, t)
+		}
+		return result, nil
+	case domain.FilterNot:
+		t, err := evalFilter(e.Expr, task)
+		if err != nil {
+			return truthFalse, err
+		}
+		return truthTrue - t, nil
+	case domain.FilterCondition:
+		return evalFilterCondition(e, task)
+	}
+	return truthFalse, fmt.Errorf("unsupported filter expression %T", expr)
+}
+
+func evalFilterCondition(cond domain.FilterCondition, task *domain.Task) (truth, error) {
+	value, err := filterFieldValue(cond.Field, task)
+	if err != nil {
+		return truthFalse, err
+	}
+
+	switch cond.Op {
+	case domain.FilterOpIsNull:
+		return truthOf(value == nil), nil
+	case domain.FilterOpNotNull:
+		return truthOf(value != nil), nil
+	case domain.FilterOpNe, domain.FilterOpNotIn:
+		if value == nil {
+			return truthTrue, nil
+		}
+	default:
+		if value == nil {
+			return truthUnknown, nil
+		}
+	}
+
+	switch cond.Op {
+	case domain.FilterOpIn, domain.FilterOpNotIn:
+		found := false
+		for _, v := range cond.Values {
+			c, err := compareFilterValues(value, v)
+			if err != nil {
+				return truthFalse, err
+			}
+			if c == 0 {
+				found = true
+				break
+			}
+		}
+		return truthOf(found == (cond.Op == domain.FilterOpIn)), nil
+	case domain.FilterOpContains:
+		text, ok1 := value.(string)
+		needle, ok2 := cond.Values[0].(string)
+		if !ok1 || !ok2 {
+			return truthFalse, fmt.Errorf("operator ~ needs a string value")
+		}
+		return truthOf(strings.Contains(strings.ToLower(text), strings.ToLower(needle))), nil
+	}
+
+	c, err := compareFilterValues(value, cond.Values[0])
+	if err != nil {
+		return truthFalse, err
+	}
+	switch cond.Op {
+	case domain.FilterOpEq:
+		return truthOf(c == 0), nil
+	case domain.FilterOpNe:
+		return truthOf(c != 0), nil
+	case domain.FilterOpLt:
+		return truthOf(c < 0), nil
+	case domain.FilterOpLte:
+		return truthOf(c <= 0), nil
+	case domain.FilterOpGt:
+		return truthOf(c > 0), nil
+	case domain.FilterOpGte:
+		return truthOf(c >= 0), nil
+	}
+	return truthFalse, fmt.Errorf("unsupported filter operator %q", cond.Op)
+}
+
+// filterFieldValue returns the task's value for field, or nil when it is NULL.
+func filterFieldValue(field domain.FilterField, task *domain.Task) (interface{}, error) {
+	switch field {
+	case domain.FilterFieldID:
+		return task.ID, nil
+	case domain.FilterFieldTitle:
+		return task.Title, nil
+	case domain.FilterFieldDescription:
+		return task.Description, nil
+	case domain.FilterFieldStatus:
+		return task.Status, nil
+	case domain.FilterFieldCategoryID:
+		if task.CategoryID == nil {
+			return nil, nil
+		}
+		return *task.CategoryID, nil
+	case domain.FilterFieldDueAt:
+		if task.DueAt == nil {
+			return nil, nil
+		}
+		return *task.DueAt, nil
+	case domain.FilterFieldCreatedAt:
+		return task.CreatedAt, nil
+	case domain.FilterFieldUpdatedAt:
+		return task.UpdatedAt, nil
+	}
+	return nil, fmt.Errorf("unsupported filter field %q", field)
+}
+
+func compareFilterValues(a, b interface{}) (int, error) {
+	switch a := a.(type) {
+	case string:
+		if b, ok := b.(string); ok {
+			return strings.Compare(a, b), nil
+		}
+	case domain.TaskStatus:
+		if b, ok := b.(domain.TaskStatus); ok {
+			return strings.Compare(string(a), string(b)), nil
+		}
+	case uint:
+		if b, ok := b.(uint); ok {
+			return cmp.Compare(a, b), nil
+		}
+	case time.Time:
+		if b, ok := b.(time.Time); ok {
+			return a.Compare(b), nil
+		}
+	}
+	return 0, fmt.Errorf("cannot compare %T with %T", a, b)
+}
@@ -2,21 +2,34 @@ package infrastructure
 
 import (
 	"context"
+	"fmt"
+	"iter"
 
+	"github.com/ltphat2204/domain-driven-golang/database"
+	"github.com/ltphat2204/domain-driven-golang/fulltext"
 	"github.com/ltphat2204/domain-driven-golang/modules/task/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// SearchDocument describes the columns covered by free-text search.
+var SearchDocument = fulltext.Document{
+	Table:   "tasks",
+	Columns: []string{"title", "description"},
+	Vector:  "search_vector",
+}
+
 type taskRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	search fulltext.Engine
 }
 
 func NewTaskRepository(db *gorm.DB) domain.TaskRepository {
-	return &taskRepository{db: db}
+	return &taskRepository{db: db, search: fulltext.ForDB(db)}
 }
 
 func (r *taskRepository) Save(ctx context.Context, task *domain.Task) (*domain.Task, error) {
-	result := r.db.WithContext(ctx).Create(task)
+	result := database.Conn(ctx, r.db).Create(task)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -25,56 +38,118 @@ func (r *taskRepository) Save(ctx context.Context, task *domain.Task) (*domain.T
 
 func (r *taskRepository) FindByID(ctx context.Context, id uint) (*domain.Task, error) {
 	var task domain.Task
-	result := r.db.WithContext(ctx).Preload("Category").First(&task, id)
+	result := database.Conn(ctx, r.db).Preload("Category").First(&task, id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return &task, nil
 }
 
+func (r *taskRepository) All(ctx context.Context) iter.Seq2[*domain.Task, error] {
+	db := database.Conn(ctx, r.db).Preload("Category")
+	return database.Batches(db, func(task *domain.Task) uint { return task.ID })
+}
+
+var taskSortColumns = map[string]database.KeysetColumn{
+	"title":      {Name: "title"},
+	"due_at":     {Name: "due_at", Nullable: true, Time: true},
+	"created_at": {Name: "created_at", Time: true},
+}
+
 func (r *taskRepository) FindTasks(ctx context.Context, query *domain.TaskQuery) ([]*domain.Task, int, error) {
-	db := r.db.WithContext(ctx).Preload("Category").Model(&domain.Task{})
+	db := database.Conn(ctx, r.db).Preload("Category").Model(&domain.Task{})
 
 	if query.Search != "" {
-		db = db.Where("title LIKE ? OR description LIKE ?", "%"+query.Search+"%", "%"+query.Search+"%")
+		db = r.search.Match(db, SearchDocument, query.Search)
 	}
 
 	if query.Status != nil {
 		db = db.Where("status = ?", *query.Status)
 	}
 
+	if query.Filter != nil {
+		sql, args, err := compileFilter(query.Filter)
+		if err != nil {
+			return nil, 0, err
+		}
+		db = db.Where(sql, args...)
+	}
+
 	var total int64
-	db.Count(&total)
+	if !query.SkipTotal {
+		if err := db.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if query.Search != "" {
+		db = r.search.Annotate(db, SearchDocument, query.Search)
+	}
 
 	var tasks []*domain.Task
-	dbQuery := db
-	if query.SortBy != "" && query.SortOrder != "" {
-		dbQuery = db.Order(query.SortBy + " " + query.SortOrder)
-	} else {
-		dbQuery = db.Order("created_at desc")
+	var dbQuery *gorm.DB
+	sortBy, sortOrder := query.EffectiveSort()
+	column, ok := taskSortColumns[sortBy]
+	switch {
+	case sortBy == domain.SortByRelevance:
+		if query.Search == "" {
+			return nil, 0, fmt.Errorf("sorting by relevance requires a search term")
+		}
+		if query.Cursor != nil {
+			return nil, 0, fmt.Errorf("cursor pagination is not supported when sorting by relevance")
+		}
+		dbQuery = r.search.OrderByRank(db, SearchDocument, query.Search)
+	case ok:
+		dbQuery = db.Scopes(database.OrderBy(column, sortOrder))
+	default:
+		return nil, 0, fmt.Errorf("unsupported sort field: %s", sortBy)
+	}
+
+	if query.PerCategory > 0 {
+		if sortBy == domain.SortByRelevance {
+			return nil, 0, fmt.Errorf("a per-category limit cannot be combined with sorting by relevance")
+		}
+		ranked := db.Select("tasks.*, ROW_NUMBER() OVER (PARTITION BY tasks.category_id ORDER BY " + database.OrderClause(column, sortOrder) + ") AS category_row")
+		dbQuery = database.Conn(ctx, r.db).Preload("Category").Table("(?) AS tasks", ranked).
+			Where("category_row <= ?", query.PerCategory).Scopes(database.OrderBy(column, sortOrder))
 	}
 
-	if query.Page > 0 && query.PageSize > 0 {
-		offset := (query.Page - 1) * query.PageSize
-		dbQuery = dbQuery.Offset(offset).Limit(query.PageSize)
+	if query.Cursor != nil {
+		after, err := database.After(column, sortOrder, query.Cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+		dbQuery = dbQuery.Scopes(after)
 	}
 
-	if err := dbQuery.Find(&tasks).Error; err != nil {
+	if err := dbQuery.Scopes(database.Window(&query.BaseQuery)).Find(&tasks).Error; err != nil {
 		return nil, 0, err
 	}
+	tasks = database.Trim(tasks, &query.BaseQuery)
+
+	if query.Search != "" {
+		for _, task := range tasks {
+			if task.SearchHighlight == "" {
+				task.SearchHighlight = fulltext.Highlight(query.Search, task.Title+" "+task.Description)
+			}
+		}
+	}
 
 	return tasks, int(total), nil
 }
 
+// Update saves the task's own columns. The preloaded Category is ignored, so
+// changing CategoryID is not undone by a stale association, and the task is
+// returned with its current category.
 func (r *taskRepository) Update(ctx context.Context, task *domain.Task) (*domain.Task, error) {
-	result := r.db.WithContext(ctx).Save(task)
+	result := database.Conn(ctx, r.db).Omit(clause.Associations).Save(task)
 	if result.Error != nil {
 		return nil, result.Error
 	}
-	return task, nil
+	return r.FindByID(ctx, task.ID)
 }
 
 func (r *taskRepository) Delete(ctx context.Context, id uint) error {
-	result := r.db.WithContext(ctx).Delete(&domain.Task{}, id)
+	result := database.Conn(ctx, r.db).Delete(&domain.Task{}, id)
 	return result.Error
 }
@@ -0,0 +1,31 @@
+package infrastructure
+
+import (
+	"testing"
+
+	"github.com/ltphat2204/domain-driven-golang/database/repotest"
+	categoryRepository "github.com/ltphat2204/domain-driven-golang/modules/category/infrastructure"
+)
+
+func TestTaskRepository(t *testing.T) {
+	t.Run("Memory", func(t *testing.T) {
+		repotest.RunTaskRepository(t, func(t *testing.T) repotest.TaskRepositories {
+			categories := categoryRepository.NewMemoryCategoryRepository()
+			return repotest.TaskRepositories{Tasks: NewMemoryTaskRepository(categories), Categories: categories}
+		})
+	})
+
+	t.Run("SQLite", func(t *testing.T) {
+		repotest.RunTaskRepository(t, func(t *testing.T) repotest.TaskRepositories {
+			db := repotest.OpenSQLite(t)
+			return repotest.TaskRepositories{Tasks: NewTaskRepository(db), Categories: categoryRepository.NewCategoryRepository(db)}
+		})
+	})
+
+	t.Run("Postgres", func(t *testing.T) {
+		repotest.RunTaskRepository(t, func(t *testing.T) repotest.TaskRepositories {
+			db := repotest.OpenPostgres(t)
+			return repotest.TaskRepositories{Tasks: NewTaskRepository(db), Categories: categoryRepository.NewCategoryRepository(db)}
+		})
+	})
+}
@@ -1,14 +1,45 @@
 package taskroutes
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/task/dto"
 	"github.com/ltphat2204/domain-driven-golang/modules/task/handlers"
+	"github.com/ltphat2204/domain-driven-golang/openapi"
 )
 
-func SetupRoutes(r *gin.Engine, taskHandler *handlers.TaskHandler) {
+func SetupRoutes(r *gin.Engine, taskHandler *handlers.TaskHandler, bulkHandler *handlers.BulkHandler) {
 	r.POST("/tasks", taskHandler.CreateTask)
+	r.POST("/tasks/bulk", bulkHandler.BulkTasks)
 	r.GET("/tasks/:id", taskHandler.GetTask)
 	r.GET("/tasks", taskHandler.GetTasks)
 	r.PATCH("/tasks/:id", taskHandler.UpdateTask)
 	r.DELETE("/tasks/:id", taskHandler.DeleteTask)
 }
+
+// Operations documents the routes SetupRoutes registers.
+func Operations() []openapi.Operation {
+	tags := []string{"tasks"}
+	return []openapi.Operation{
+		{Method: "POST", Path: "/tasks", ID: "createTask", Summary: "Create a task", Tags: tags,
+			Body: dto.TaskCreateDTO{}, Response: domain.Task{},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError}},
+		{Method: "POST", Path: "/tasks/bulk", ID: "bulkTasks", Summary: "Create, update, move and delete tasks in one request", Tags: tags,
+			Body: dto.TaskBulkDTO{}, Response: domain.BulkResult{},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError}},
+		{Method: "GET", Path: "/tasks/:id", ID: "getTask", Summary: "Get a task", Tags: tags,
+			Response: domain.Task{},
+			Errors:   []int{http.StatusBadRequest, http.StatusNotFound}},
+		{Method: "GET", Path: "/tasks", ID: "listTasks", Summary: "List, search and filter tasks", Tags: tags,
+			Query: dto.TaskQueryDTO{}, Response: dto.TaskListResponse{},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError}},
+		{Method: "PATCH", Path: "/tasks/:id", ID: "updateTask", Summary: "Update a task", Tags: tags,
+			Body: dto.TaskUpdateDTO{}, Response: domain.Task{},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError}},
+		{Method: "DELETE", Path: "/tasks/:id", ID: "deleteTask", Summary: "Delete a task", Tags: tags,
+			Response: "",
+			Errors:   []int{http.StatusBadRequest, http.StatusInternalServerError}},
+	}
+}
@@ -0,0 +1,267 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+
+	"github.com/ltphat2204/domain-driven-golang/common"
+	categoryRPC "github.com/ltphat2204/domain-driven-golang/modules/category/rpc"
+	streamApplication "github.com/ltphat2204/domain-driven-golang/modules/stream/application"
+	streamDomain "github.com/ltphat2204/domain-driven-golang/modules/stream/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/task/application"
+	"github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	pb "github.com/ltphat2204/domain-driven-golang/proto/taskmanager/v1"
+)
+
+type TaskServer struct {
+	pb.UnimplementedTaskServiceServer
+	service application.TaskService
+	hub     *streamApplication.Hub
+}
+
+// NewTaskServer serves service, whose changes WatchTasks reads from hub.
+func NewTaskServer(service application.TaskService, hub *streamApplication.Hub) *TaskServer {
+	return &TaskServer{service: service, hub: hub}
+}
+
+func (s *TaskServer) CreateTask(ctx context.Context, req *pb.CreateTaskRequest) (*pb.Task, error) {
+	if req.GetTitle() == "" {
+		return nil, status.Error(codes.InvalidArgument, "title is required")
+	}
+	task, err := s.service.CreateTask(ctx, req.GetTitle(), req.GetDescription(), toTime(req.GetDueAt()), toID(req.CategoryId))
+	if err != nil {
+		return nil, serviceError(err, "failed to create task")
+	}
+	return ToProto(task), nil
+}
+
+func (s *TaskServer) GetTask(ctx context.Context, req *pb.GetTaskRequest) (*pb.Task, error) {
+	task, err := s.service.GetTaskByID(ctx, uint(req.GetId()))
+	if err != nil {
+		return nil, serviceError(err, "failed to get task")
+	}
+	return ToProto(task), nil
+}
+
+func (s *TaskServer) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	if req.GetPage() < 0 || req.GetPageSize() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page and page_size must not be negative")
+	}
+	if req.GetSortBy() != "" && !slices.Contains(domain.TaskSortFields, req.GetSortBy()) {
+		return nil, status.Error(codes.InvalidArgument, "invalid sort_by")
+	}
+	if req.GetSortOrder() != "" && !slices.Contains(domain.SortOrders, req.GetSortOrder()) {
+		return nil, status.Error(codes.InvalidArgument, "invalid sort_order")
+	}
+	if req.GetSortBy() == domain.SortByRelevance && req.GetSearch() == "" {
+		return nil, status.Error(codes.InvalidArgument, "sort_by=relevance requires search")
+	}
+
+	query := &domain.TaskQuery{
+		BaseQuery: common.BaseQuery{
+			Page:      max(int(req.GetPage()), 1),
+			PageSize:  int(req.GetPageSize()),
+			SkipTotal: req.IncludeTotal != nil && !req.GetIncludeTotal(),
+		},
+		Search:    req.GetSearch(),
+		SortBy:    req.GetSortBy(),
+		SortOrder: req.GetSortOrder(),
+	}
+	if query.PageSize == 0 {
+		query.PageSize = common.DefaultPageSize()
+	}
+	if req.GetStatus() != pb.TaskStatus_TASK_STATUS_UNSPECIFIED {
+		taskStatus, ok := toStatus(req.GetStatus())
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "invalid status")
+		}
+		query.Status = &taskStatus
+	}
+	if req.GetFilter() != "" {
+		filter, err := domain.ParseFilter(req.GetFilter(), time.Now())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		query.Filter = filter
+	}
+
+	sortBy, sortOrder := query.EffectiveSort()
+	if req.GetCursor() != "" {
+		if !query.SupportsCursor() {
+			return nil, status.Error(codes.InvalidArgument, "cursor pagination is not supported with sort_by=relevance")
+		}
+		cursor, err := common.DecodeCursor(req.GetCursor())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid cursor")
+		}
+		if cursor.SortBy != sortBy || cursor.SortOrder != sortOrder {
+			return nil, status.Error(codes.InvalidArgument, "cursor does not match sort_by and sort_order")
+		}
+		query.Cursor = cursor
+	}
+
+	tasks, total, err := s.service.GetTasks(ctx, query)
+	if err != nil {
+		return nil, serviceError(err, "failed to list tasks")
+	}
+
+	var last *common.Cursor
+	if len(tasks) > 0 && query.SupportsCursor() {
+		lastTask := tasks[len(tasks)-1]
+		last = &common.Cursor{SortBy: sortBy, SortOrder: sortOrder, Value: lastTask.SortKey(sortBy), ID: lastTask.ID}
+	}
+	response := &pb.ListTasksResponse{
+		Tasks:    make([]*pb.Task, len(tasks)),
+		PageInfo: categoryRPC.PageInfo(common.NewPaginationMeta(query.BaseQuery, total, last)),
+	}
+	for i, task := range tasks {
+		response.Tasks[i] = ToProto(task)
+	}
+	return response, nil
+}
+
+func (s *TaskServer) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*pb.Task, error) {
+	var taskStatus *domain.TaskStatus
+	if req.GetStatus() != pb.TaskStatus_TASK_STATUS_UNSPECIFIED {
+		st, ok := toStatus(req.GetStatus())
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "invalid status")
+		}
+		taskStatus = &st
+	}
+	task, err := s.service.UpdateTask(ctx, uint(req.GetId()), req.Title, req.Description, taskStatus, toTime(req.GetDueAt()), toID(req.CategoryId))
+	if err != nil {
+		return nil, serviceError(err, "failed to update task")
+	}
+	return ToProto(task), nil
+}
+
+func (s *TaskServer) DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest) (*pb.DeleteTaskResponse, error) {
+	if err := s.service.DeleteTask(ctx, uint(req.GetId())); err != nil {
+		return nil, serviceError(err, "failed to delete task")
+	}
+	return &pb.DeleteTaskResponse{}, nil
+}
+
+func (s *TaskServer) WatchTasks(req *pb.WatchTasksRequest, stream grpc.ServerStreamingServer[pb.TaskEvent]) error {
+	ctx := stream.Context()
+	sub := s.hub.Subscribe(ctx, streamDomain.Filter{}, nil)
+	// Send the headers now so the client knows the watch has started.
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+	for event := range sub.Events {
+		if event.Task == nil {
+			continue
+		}
+		if err := stream.Send(eventToProto(event)); err != nil {
+			return err
+		}
+	}
+	switch {
+	case ctx.Err() != nil:
+		return status.FromContextError(ctx.Err()).Err()
+	case s.hub.Closed():
+		return status.Error(codes.Unavailable, "server is shutting down")
+	}
+	return status.Error(codes.ResourceExhausted, "watcher fell behind; call WatchTasks again")
+}
+
+// serviceError maps a service error to a gRPC status: NotFound for missing
+// records, Internal otherwise.
+func serviceError(err error, message string) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return status.Error(codes.NotFound, "task not found")
+	}
+	return status.Errorf(codes.Internal, "%s: %v", message, err)
+}
+
+var statuses = map[pb.TaskStatus]domain.TaskStatus{
+	pb.TaskStatus_TASK_STATUS_PENDING: domain.StatusPending,
+	pb.TaskStatus_TASK_STATUS_DOING:   domain.StatusDoing,
+	pb.TaskStatus_TASK_STATUS_DONE:    domain.StatusDone,
+}
+
+func toStatus(s pb.TaskStatus) (domain.TaskStatus, bool) {
+	taskStatus, ok := statuses[s]
+	return taskStatus, ok
+}
+
+func statusToProto(s domain.TaskStatus) pb.TaskStatus {
+	for p, taskStatus := range statuses {
+		if taskStatus == s {
+			return p
+		}
+	}
+	return pb.TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func toTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func timeToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func toID(id *uint32) *uint {
+	if id == nil {
+		return nil
+	}
+	v := uint(*id)
+	return &v
+}
+
+func ToProto(task *domain.Task) *pb.Task {
+	p := &pb.Task{
+		Id:              uint32(task.ID),
+		Title:           task.Title,
+		Description:     task.Description,
+		Status:          statusToProto(task.Status),
+		CreatedAt:       timestamppb.New(task.CreatedAt),
+		UpdatedAt:       timestamppb.New(task.UpdatedAt),
+		DueAt:           timeToProto(task.DueAt),
+		Category:        categoryRPC.ToProto(task.Category),
+		SearchRank:      task.SearchRank,
+		SearchHighlight: task.SearchHighlight,
+	}
+	if task.CategoryID != nil {
+		id := uint32(*task.CategoryID)
+		p.CategoryId = &id
+	}
+	return p
+}
+
+var eventTypes = map[streamDomain.EventType]pb.TaskEvent_Type{
+	streamDomain.TaskCreated: pb.TaskEvent_TYPE_CREATED,
+	streamDomain.TaskUpdated: pb.TaskEvent_TYPE_UPDATED,
+	streamDomain.TaskDeleted: pb.TaskEvent_TYPE_DELETED,
+}
+
+// eventToProto converts a task event; deletions carry only the task's ID.
+func eventToProto(event streamDomain.Event) *pb.TaskEvent {
+	task := &pb.Task{Id: uint32(event.Task.ID)}
+	if event.Type != streamDomain.TaskDeleted {
+		task = ToProto(event.Task)
+	}
+	return &pb.TaskEvent{
+		Type:       eventTypes[event.Type],
+		Task:       task,
+		OccurredAt: timestamppb.New(event.OccurredAt),
+	}
+}
@@ -0,0 +1,392 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"log/slog"
+	"maps"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+
+	"github.com/ltphat2204/domain-driven-golang/database"
+	categoryApplication "github.com/ltphat2204/domain-driven-golang/modules/category/application"
+	categoryDomain "github.com/ltphat2204/domain-driven-golang/modules/category/domain"
+	taskApplication "github.com/ltphat2204/domain-driven-golang/modules/task/application"
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/domain"
+	"github.com/ltphat2204/domain-driven-golang/tracing"
+)
+
+var tracer = otel.Tracer("github.com/ltphat2204/domain-driven-golang/modules/transfer/application")
+
+type TransferService interface {
+	// Export writes every category and then every task to w, each in ID
+	// order, reading them in batches as it goes.
+	Export(ctx context.Context, format domain.Format, w io.Writer) error
+	// Import creates or updates a category or task for each row of r, and
+	// reports the rows it skipped. format may also name an importer. It
+	// returns a *domain.FileError, having imported nothing, if r cannot be
+	// read.
+	Import(ctx context.Context, format domain.Format, r io.Reader, opts domain.ImportOptions) (*domain.ImportReport, error)
+}
+
+type transferService struct {
+	tasks      taskApplication.TaskService
+	categories categoryApplication.CategoryService
+	refs       domain.ExternalRefRepository
+	transactor database.Transactor
+	codecs     map[domain.Format]domain.Codec
+	importers  map[domain.Format]domain.Importer
+}
+
+// NewTransferService imports through tasks and categories, so rows are
+// validated, recorded and published like any other change. importers read
+// the files of other trackers.
+func NewTransferService(tasks taskApplication.TaskService, categories categoryApplication.CategoryService, refs domain.ExternalRefRepository, transactor database.Transactor, codecs map[domain.Format]domain.Codec, importers map[domain.Format]domain.Importer) TransferService {
+	return &transferService{tasks: tasks, categories: categories, refs: refs, transactor: transactor, codecs: codecs, importers: importers}
+}
+
+func (s *transferService) codec(format domain.Format) (domain.Codec, error) {
+	codec, ok := s.codecs[format]
+	if !ok {
+		return nil, &domain.FileError{Err: fmt.Errorf("unsupported format %q", format)}
+	}
+	return codec, nil
+}
+
+// newReader reads r with the codec or importer for format. Only codecs take
+// a mapping, since the other trackers' files have fixed fields.
+func (s *transferService) newReader(format domain.Format, r io.Reader, opts domain.ImportOptions) (domain.RecordReader, error) {
+	if importer, ok := s.importers[format]; ok {
+		if len(opts.Mapping) > 0 {
+			return nil, fmt.Errorf("%s files cannot be mapped", format)
+		}
+		return importer.NewReader(r, opts.Project)
+	}
+	codec, ok := s.codecs[format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+	if opts.Project != "" {
+		return nil, errors.New("project only applies to a single Todoist CSV")
+	}
+	return codec.NewReader(r, opts.Mapping)
+}
+
+func (s *transferService) Export(ctx context.Context, format domain.Format, w io.Writer) (err error) {
+	ctx, span := tracer.Start(ctx, "TransferService.Export")
+	defer tracing.End(span, &err)
+	span.SetAttributes(attribute.String("transfer.format", string(format)))
+
+	codec, err := s.codec(format)
+	if err != nil {
+		return err
+	}
+	writer := codec.NewWriter(w)
+	categories, err := exportBatches(ctx, s.refs, domain.KindCategory, s.categories.AllCategories(ctx), writer, func(category *categoryDomain.Category) (uint, *domain.Record) {
+		description, color, createdAt := category.Description, category.Color, category.CreatedAt
+		return category.ID, &domain.Record{
+			Type: domain.KindCategory, ID: category.ID, Title: category.Name,
+			Description: &description, Color: &color, CreatedAt: &createdAt,
+		}
+	})
+	if err != nil {
+		return err
+	}
+	tasks, err := exportBatches(ctx, s.refs, domain.KindTask, s.tasks.AllTasks(ctx), writer, func(task *taskDomain.Task) (uint, *domain.Record) {
+		description, status, category := task.Description, task.Status, ""
+		if task.Category != nil {
+			category = task.Category.Name
+		}
+		return task.ID, &domain.Record{
+			Type: domain.KindTask, ID: task.ID, Title: task.Title,
+			Description: &description, Status: &status, DueAt: task.DueAt, Category: &category,
+			CreatedAt: &task.CreatedAt, UpdatedAt: &task.UpdatedAt,
+		}
+	})
+	if err != nil {
+		return err
+	}
+	span.SetAttributes(attribute.Int("transfer.categories", categories), attribute.Int("transfer.tasks", tasks))
+	return writer.Close()
+}
+
+// exportBatches writes the records of all, looking up their external IDs a
+// batch at a time, and returns how many it wrote.
+func exportBatches[T any](ctx context.Context, refs domain.ExternalRefRepository, kind domain.Kind, all iter.Seq2[*T, error], writer domain.RecordWriter, record func(*T) (uint, *domain.Record)) (int, error) {
+	var ids []uint
+	var batch []*domain.Record
+	written := 0
+	flush := func() error {
+		externalIDs, err := refs.FindByEntityIDs(ctx, kind, ids)
+		if err != nil {
+			return err
+		}
+		for i, r := range batch {
+			r.ExternalID = externalIDs[ids[i]]
+			if err := writer.Write(r); err != nil {
+				return err
+			}
+		}
+		written += len(batch)
+		ids, batch = ids[:0], batch[:0]
+		return nil
+	}
+
+	for item, err := range all {
+		if err != nil {
+			return written, err
+		}
+		id, r := record(item)
+		ids, batch = append(ids, id), append(batch, r)
+		if len(batch) == database.BatchSize {
+			if err := flush(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, flush()
+}
+
+// errDryRun rolls back a dry run once every row has been tried.
+var errDryRun = errors.New("dry run")
+
+func (s *transferService) Import(ctx context.Context, format domain.Format, r io.Reader, opts domain.ImportOptions) (_ *domain.ImportReport, err error) {
+	ctx, span := tracer.Start(ctx, "TransferService.Import")
+	defer tracing.End(span, &err)
+	span.SetAttributes(attribute.String("transfer.format", string(format)), attribute.Bool("transfer.dry_run", opts.DryRun))
+
+	reader, err := s.newReader(format, r, opts)
+	if err != nil {
+		return nil, &domain.FileError{Err: err}
+	}
+
+	report := &domain.ImportReport{DryRun: opts.DryRun, Errors: []domain.RowError{}}
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		imp := &importer{transferService: s, report: report, names: make(map[string]uint)}
+		for category, err := range s.categories.AllCategories(ctx) {
+			if err != nil {
+				return err
+			}
+			imp.names[category.Name] = category.ID
+		}
+
+		for {
+			row, values, err := reader.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			var rowErr *domain.RowError
+			if errors.As(err, &rowErr) {
+				imp.fail(*rowErr)
+				continue
+			}
+			if err != nil {
+				return &domain.FileError{Row: row, Err: err}
+			}
+			if err := imp.importRow(ctx, row, values); err != nil {
+				return err
+			}
+		}
+		if opts.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("transfer.rows", report.Rows), attribute.Int("transfer.failed", report.Failed))
+	slog.InfoContext(ctx, "import run", "format", format, "dry_run", opts.DryRun, "rows", report.Rows, "failed", report.Failed)
+	return report, nil
+}
+
+// importer holds the state of one import. names maps category names to IDs,
+// so tasks can name their category.
+type importer struct {
+	*transferService
+	report *domain.ImportReport
+	names  map[string]uint
+}
+
+// rowChanges are what a row did, applied to the importer once the row's
+// transaction commits.
+type rowChanges struct {
+	created, updated domain.ImportCounts
+	names            map[string]uint
+	renamed          uint
+}
+
+func (imp *importer) fail(rowErr domain.RowError) {
+	imp.report.Rows++
+	imp.report.Failed++
+	if len(imp.report.Errors) < domain.MaxRowErrors {
+		imp.report.Errors = append(imp.report.Errors, rowErr)
+	}
+}
+
+// importRow applies a row in a nested transaction, so a failure undoes the
+// row's changes alone and is reported. Only a cancelled context stops the
+// import.
+func (imp *importer) importRow(ctx context.Context, row int, values map[string]string) error {
+	record, err := domain.ParseRecord(values)
+	if err != nil {
+		imp.fail(domain.RowError{Row: row, ExternalID: values["external_id"], Message: err.Error()})
+		return nil
+	}
+
+	changes := &rowChanges{names: make(map[string]uint)}
+	err = imp.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if record.Type == domain.KindCategory {
+			return imp.importCategory(ctx, record, changes)
+		}
+		return imp.importTask(ctx, record, changes)
+	})
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err != nil {
+		imp.fail(domain.RowError{Row: row, ExternalID: record.ExternalID, Message: err.Error()})
+		return nil
+	}
+
+	imp.report.Rows++
+	imp.report.Created.Categories += changes.created.Categories
+	imp.report.Created.Tasks += changes.created.Tasks
+	imp.report.Updated.Categories += changes.updated.Categories
+	imp.report.Updated.Tasks += changes.updated.Tasks
+	if changes.renamed != 0 {
+		maps.DeleteFunc(imp.names, func(_ string, id uint) bool { return id == changes.renamed })
+	}
+	maps.Copy(imp.names, changes.names)
+	return nil
+}
+
+// findRef returns the ID externalID was imported as, if it still exists.
+func (imp *importer) findRef(ctx context.Context, kind domain.Kind, externalID string) (uint, bool, error) {
+	if externalID == "" {
+		return 0, false, nil
+	}
+	ref, err := imp.refs.Find(ctx, kind, externalID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	if kind == domain.KindCategory {
+		_, err = imp.categories.GetCategoryByID(ctx, ref.EntityID)
+	} else {
+		_, err = imp.tasks.GetTaskByID(ctx, ref.EntityID)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, false, nil
+	}
+	return ref.EntityID, err == nil, err
+}
+
+func (imp *importer) saveRef(ctx context.Context, kind domain.Kind, externalID string, id uint) error {
+	if externalID == "" {
+		return nil
+	}
+	return imp.refs.Save(ctx, &domain.ExternalRef{Entity: kind, ExternalID: externalID, EntityID: id})
+}
+
+// importCategory updates the category imported with the same external ID,
+// or else the one with the same name, or creates one.
+func (imp *importer) importCategory(ctx context.Context, record *domain.Record, changes *rowChanges) error {
+	id, found, err := imp.findRef(ctx, domain.KindCategory, record.ExternalID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		id, found = imp.names[record.Title]
+	}
+
+	if found {
+		if _, err := imp.categories.UpdateCategory(ctx, id, &record.Title, record.Description, record.Color); err != nil {
+			return err
+		}
+		changes.updated.Categories++
+		changes.renamed = id
+	} else {
+		description := ""
+		if record.Description != nil {
+			description = *record.Description
+		}
+		category, err := imp.categories.CreateCategory(ctx, record.Title, description)
+		if err != nil {
+			return err
+		}
+		if record.Color != nil {
+			if _, err := imp.categories.UpdateCategory(ctx, category.ID, nil, nil, record.Color); err != nil {
+				return err
+			}
+		}
+		id = category.ID
+		changes.created.Categories++
+	}
+	changes.names[record.Title] = id
+	return imp.saveRef(ctx, domain.KindCategory, record.ExternalID, id)
+}
+
+// importTask updates the task imported with the same external ID, or
+// creates one. A category it names that does not exist is created.
+func (imp *importer) importTask(ctx context.Context, record *domain.Record, changes *rowChanges) error {
+	var categoryID *uint
+	if record.Category != nil && *record.Category != "" {
+		id, ok := imp.names[*record.Category]
+		if !ok {
+			category, err := imp.categories.CreateCategory(ctx, *record.Category, "")
+			if err != nil {
+				return err
+			}
+			id = category.ID
+			changes.names[category.Name] = id
+			changes.created.Categories++
+		}
+		categoryID = &id
+	}
+
+	id, found, err := imp.findRef(ctx, domain.KindTask, record.ExternalID)
+	if err != nil {
+		return err
+	}
+	if found {
+		if record.Category == nil {
+			// UpdateTask sets the category it is given, so pass the current one.
+			current, err := imp.tasks.GetTaskByID(ctx, id)
+			if err != nil {
+				return err
+			}
+			categoryID = current.CategoryID
+		}
+		if _, err := imp.tasks.UpdateTask(ctx, id, &record.Title, record.Description, record.Status, record.DueAt, categoryID); err != nil {
+			return err
+		}
+		changes.updated.Tasks++
+	} else {
+		description := ""
+		if record.Description != nil {
+			description = *record.Description
+		}
+		task, err := imp.tasks.CreateTask(ctx, record.Title, description, record.DueAt, categoryID)
+		if err != nil {
+			return err
+		}
+		if record.Status != nil && *record.Status != task.Status {
+			if _, err := imp.tasks.UpdateTask(ctx, task.ID, nil, nil, record.Status, nil, categoryID); err != nil {
+				return err
+			}
+		}
+		id = task.ID
+		changes.created.Tasks++
+	}
+	return imp.saveRef(ctx, domain.KindTask, record.ExternalID, id)
+}
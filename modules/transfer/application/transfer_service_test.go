@@ -0,0 +1,242 @@
+package application_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ltphat2204/domain-driven-golang/config"
+	"github.com/ltphat2204/domain-driven-golang/database"
+	"github.com/ltphat2204/domain-driven-golang/database/repotest"
+	categoryApplication "github.com/ltphat2204/domain-driven-golang/modules/category/application"
+	categoryDomain "github.com/ltphat2204/domain-driven-golang/modules/category/domain"
+	categoryInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/category/infrastructure"
+	streamApplication "github.com/ltphat2204/domain-driven-golang/modules/stream/application"
+	streamDomain "github.com/ltphat2204/domain-driven-golang/modules/stream/domain"
+	taskApplication "github.com/ltphat2204/domain-driven-golang/modules/task/application"
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	taskInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/task/infrastructure"
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/application"
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/infrastructure"
+)
+
+type storage struct {
+	tasks      taskDomain.TaskRepository
+	categories categoryDomain.CategoryRepository
+	refs       domain.ExternalRefRepository
+	transactor database.Transactor
+}
+
+func TestTransferService(t *testing.T) {
+	t.Run("Memory", func(t *testing.T) {
+		categories := categoryInfrastructure.NewMemoryCategoryRepository()
+		tasks := taskInfrastructure.NewMemoryTaskRepository(categories)
+		refs := infrastructure.NewMemoryExternalRefRepository()
+		transactor := database.NewMemoryTransactor(tasks.(database.Snapshotter), categories.(database.Snapshotter), refs.(database.Snapshotter))
+		testTransferService(t, storage{tasks, categories, refs, transactor})
+	})
+
+	t.Run("SQLite", func(t *testing.T) {
+		db := repotest.OpenSQLite(t)
+		testTransferService(t, storage{taskInfrastructure.NewTaskRepository(db), categoryInfrastructure.NewCategoryRepository(db), infrastructure.NewExternalRefRepository(db), database.NewTransactor(db)})
+	})
+
+	t.Run("Postgres", func(t *testing.T) {
+		db := repotest.OpenPostgres(t)
+		testTransferService(t, storage{taskInfrastructure.NewTaskRepository(db), categoryInfrastructure.NewCategoryRepository(db), infrastructure.NewExternalRefRepository(db), database.NewTransactor(db)})
+	})
+}
+
+const sheet = `Task,Deadline,Status,Category,Ref
+Write report,2025-06-03,doing,Work,r1
+Buy milk,,,Errands,r2
+Water plants,,Done,,
+,2025-06-04,,Work,r3
+Paint fence,,,Garden,r4
+`
+
+func testTransferService(t *testing.T, s storage) {
+	ctx := context.Background()
+	hub := streamApplication.NewHub(100)
+	tasks := hub.TaskService(taskApplication.NewTaskService(s.tasks))
+	categories := categoryApplication.NewCategoryService(s.categories, config.ColorPalette)
+	service := application.NewTransferService(tasks, categories, s.refs, s.transactor, infrastructure.Codecs(), infrastructure.Importers())
+	if _, err := categories.CreateCategory(ctx, "Work", "office"); err != nil {
+		t.Fatal(err)
+	}
+	mapping := map[string]string{"title": "Task", "due_at": "Deadline", "external_id": "Ref"}
+
+	// A dry run reports the import and leaves nothing behind.
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	published := hub.Subscribe(subCtx, streamDomain.Filter{}, nil)
+	report, err := service.Import(ctx, domain.FormatCSV, strings.NewReader(sheet), domain.ImportOptions{DryRun: true, Mapping: mapping})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Rows != 5 || report.Created.Tasks != 4 || report.Created.Categories != 2 || report.Failed != 1 || report.Errors[0].Row != 5 {
+		t.Errorf("dry run report = %+v, want 4 tasks and 2 categories created and row 5 failed", report)
+	}
+	if all, _, _ := tasks.GetTasks(ctx, &taskDomain.TaskQuery{}); len(all) != 0 {
+		t.Errorf("%d tasks after a dry run, want none", len(all))
+	}
+	cancel()
+	for event := range published.Events {
+		t.Errorf("dry run published %s for task %d", event.Type, event.Task.ID)
+	}
+
+	if _, err := service.Import(ctx, domain.FormatCSV, strings.NewReader(sheet), domain.ImportOptions{Mapping: mapping}); err != nil {
+		t.Fatal(err)
+	}
+	var export bytes.Buffer
+	if err := service.Export(ctx, domain.FormatCSV, &export); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"type,title,status,due_at,category,external_id",
+		"category,Work,,,,",
+		"category,Errands,,,,",
+		"category,Garden,,,,",
+		"task,Write report,Doing,2025-06-03T00:00:00Z,Work,r1",
+		"task,Buy milk,Pending,,Errands,r2",
+		"task,Water plants,Done,,,",
+		"task,Paint fence,Pending,,Garden,r4",
+	}
+	if got := columnsOf(t, export.String(), "type", "title", "status", "due_at", "category", "external_id"); got != strings.Join(want, "\n") {
+		t.Errorf("export =\n%s\nwant\n%s", got, strings.Join(want, "\n"))
+	}
+
+	// Importing the export again updates the rows with an external ID and
+	// adds copies of the others; an empty category clears it.
+	edited := strings.Replace(export.String(), "Buy milk,,Pending,,Errands,", "Buy oat milk,,Done,,,", 1)
+	report, err = service.Import(ctx, domain.FormatCSV, strings.NewReader(edited), domain.ImportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Updated.Categories != 3 || report.Updated.Tasks != 3 || report.Created.Tasks != 1 || report.Failed != 0 {
+		t.Errorf("re-import report = %+v, want 3 categories and 3 tasks updated, 1 task created", report)
+	}
+	ref, err := s.refs.Find(ctx, domain.KindTask, "r2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	milk, err := tasks.GetTaskByID(ctx, ref.EntityID)
+	if err != nil || milk.Title != "Buy oat milk" || milk.Status != taskDomain.StatusDone || milk.CategoryID != nil {
+		t.Errorf("task r2 after re-import = %+v, %v", milk, err)
+	}
+
+	// A row failing part way leaves nothing from that row.
+	report, err = service.Import(ctx, domain.FormatNDJSON, strings.NewReader(`{"type":"category","title":"Hobbies","color":"#000000"}`+"\n"), domain.ImportOptions{})
+	if err != nil || report.Failed != 1 || report.Created.Categories != 0 {
+		t.Errorf("import of an invalid color = %+v, %v", report, err)
+	}
+	for category, err := range categories.AllCategories(ctx) {
+		if err == nil && category.Name == "Hobbies" {
+			t.Error("the category of a failed row was kept")
+		}
+	}
+
+	if _, err := service.Import(ctx, domain.FormatJSON, strings.NewReader(`{}`), domain.ImportOptions{}); err == nil {
+		t.Error("import of a JSON object succeeded")
+	}
+
+	// Importing a newer export of a Trello board updates what the first
+	// import created.
+	board, err := os.ReadFile("../infrastructure/testdata/trello_board.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err = service.Import(ctx, domain.FormatTrello, bytes.NewReader(board), domain.ImportOptions{})
+	if err != nil || report.Created.Categories != 3 || report.Created.Tasks != 3 || report.Failed != 0 {
+		t.Errorf("Trello import = %+v, %v", report, err)
+	}
+	board = bytes.Replace(board, []byte(`"name": "Pick a colour scheme"`), []byte(`"name": "Pick a color scheme", "closed": true`), 1)
+	board = bytes.Replace(board, []byte(`"closed": false,
+      "due": "2025-06-03`), []byte(`"due": "2025-06-03`), 1)
+	report, err = service.Import(ctx, domain.FormatTrello, bytes.NewReader(board), domain.ImportOptions{})
+	if err != nil || report.Created != (domain.ImportCounts{}) || report.Updated.Categories != 3 || report.Updated.Tasks != 3 {
+		t.Errorf("Trello re-import = %+v, %v, want everything updated", report, err)
+	}
+	ref, err = s.refs.Find(ctx, domain.KindTask, "trello:card:65f1c0a2b3d4e5f600001001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	card, err := tasks.GetTaskByID(ctx, ref.EntityID)
+	if err != nil || card.Title != "Pick a color scheme" || card.Status != taskDomain.StatusDone || card.Category == nil || card.Category.Name != "In progress" {
+		t.Errorf("card after re-import = %+v, %v", card, err)
+	}
+
+	if _, err := service.Import(ctx, domain.FormatGitHub, strings.NewReader(`[]`), domain.ImportOptions{Mapping: mapping}); err == nil {
+		t.Error("import of a GitHub file with a mapping succeeded")
+	}
+
+	// The same tasks exported from two Todoist projects stay apart: the
+	// second project's import creates its own, and re-importing the first
+	// updates only the first's.
+	project, err := os.ReadFile("../infrastructure/testdata/todoist_project.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		project          string
+		created, updated domain.ImportCounts
+	}{
+		{"Home", domain.ImportCounts{Categories: 1, Tasks: 3}, domain.ImportCounts{}},
+		{"Cabin", domain.ImportCounts{Categories: 1, Tasks: 3}, domain.ImportCounts{}},
+		{"Home", domain.ImportCounts{}, domain.ImportCounts{Categories: 1, Tasks: 3}},
+	} {
+		report, err := service.Import(ctx, domain.FormatTodoist, bytes.NewReader(project), domain.ImportOptions{Project: tt.project})
+		if err != nil || report.Created != tt.created || report.Updated != tt.updated || report.Failed != 0 {
+			t.Errorf("Todoist import of %s = %+v, %v; want %+v created, %+v updated", tt.project, report, err, tt.created, tt.updated)
+		}
+	}
+	for _, name := range []string{"Home", "Cabin"} {
+		query := &taskDomain.TaskQuery{Search: "Water plants"}
+		found, _, err := tasks.GetTasks(ctx, query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		inProject := 0
+		for _, task := range found {
+			if task.Category != nil && task.Category.Name == name {
+				inProject++
+			}
+		}
+		if inProject != 2 {
+			t.Errorf("%d Water plants tasks in %s, want 2", inProject, name)
+		}
+	}
+
+	var fileErr *domain.FileError
+	if _, err := service.Import(ctx, domain.FormatTodoist, bytes.NewReader(project), domain.ImportOptions{}); !errors.As(err, &fileErr) {
+		t.Errorf("Todoist CSV import without a project error = %v, want a FileError", err)
+	}
+	if _, err := service.Import(ctx, domain.FormatCSV, strings.NewReader(sheet), domain.ImportOptions{Project: "Home"}); !errors.As(err, &fileErr) {
+		t.Errorf("CSV import with a project error = %v, want a FileError", err)
+	}
+}
+
+// columnsOf returns the named columns of a CSV export, one line per row.
+func columnsOf(t *testing.T, export string, names ...string) string {
+	t.Helper()
+	lines := strings.Split(strings.TrimSpace(export), "\n")
+	header := strings.Split(lines[0], ",")
+	var out []string
+	for _, line := range lines {
+		values := strings.Split(line, ",")
+		var row []string
+		for _, name := range names {
+			for i, column := range header {
+				if column == name {
+					row = append(row, values[i])
+				}
+			}
+		}
+		out = append(out, strings.Join(row, ","))
+	}
+	return strings.Join(out, "\n")
+}
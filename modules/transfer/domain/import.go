@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ExternalRef links an ID from an imported file, or from the system it came
+// from, to the task or category it was imported as, so importing the file
+// again updates them instead of adding copies.
+type ExternalRef struct {
+	ID         uint      `gorm:"primaryKey"`
+	Entity     Kind      `gorm:"type:varchar(20);not null"`
+	ExternalID string    `gorm:"not null"`
+	EntityID   uint      `gorm:"not null"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+type ExternalRefRepository interface {
+	// Find returns the ref for externalID, or gorm.ErrRecordNotFound.
+	Find(ctx context.Context, entity Kind, externalID string) (*ExternalRef, error)
+	// FindByEntityIDs returns the external ID of each of ids that has one.
+	// An entity with several gets the one recorded first.
+	FindByEntityIDs(ctx context.Context, entity Kind, ids []uint) (map[uint]string, error)
+	// Save records ref, replacing any ref with the same entity and external
+	// ID.
+	Save(ctx context.Context, ref *ExternalRef) error
+}
+
+type ImportOptions struct {
+	// DryRun reports what the import would do and then rolls it back.
+	DryRun bool
+	// Mapping is passed to the codec; see Codec.
+	Mapping map[string]string
+	// Project is passed to the importer; see Importer.
+	Project string
+}
+
+type ImportCounts struct {
+	Categories int `json:"categories"`
+	Tasks      int `json:"tasks"`
+}
+
+// RowError is why an imported row was skipped. Row counts from 1: the
+// line of a CSV or NDJSON file, with the CSV header as line 1, or the
+// position in a JSON array.
+type RowError struct {
+	Row        int    `json:"row"`
+	ExternalID string `json:"external_id,omitempty"`
+	Message    string `json:"error"`
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d: %s", e.Row, e.Message)
+}
+
+// ImportReport is the outcome of an import. Categories created because a
+// task named them count as created.
+type ImportReport struct {
+	DryRun  bool         `json:"dry_run"`
+	Rows    int          `json:"rows"`
+	Created ImportCounts `json:"created"`
+	Updated ImportCounts `json:"updated"`
+	Failed  int          `json:"failed"`
+	// Errors lists the first MaxRowErrors failed rows.
+	Errors []RowError `json:"errors"`
+}
+
+const MaxRowErrors = 100
+
+// FileError means an import could not be read past Row, so nothing was
+// imported.
+type FileError struct {
+	Row int
+	Err error
+}
+
+func (e *FileError) Error() string {
+	if e.Row == 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,227 @@
+package domain
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+	// FormatNDJSON is JSON with one record per line and no enclosing array.
+	FormatNDJSON Format = "ndjson"
+
+	// The export files of other trackers, which are only imported.
+	FormatTrello  Format = "trello"
+	FormatTodoist Format = "todoist"
+	FormatGitHub  Format = "github"
+)
+
+// Formats are the formats files are exported and imported in.
+var Formats = []Format{FormatCSV, FormatJSON, FormatNDJSON}
+
+// FormatOf returns the format whose content type is mediaType, if any.
+func FormatOf(mediaType string) (Format, bool) {
+	mediaType, _, _ = strings.Cut(mediaType, ";")
+	for _, format := range Formats {
+		base, _, _ := strings.Cut(format.ContentType(), ";")
+		if strings.EqualFold(strings.TrimSpace(mediaType), base) {
+			return format, true
+		}
+	}
+	return "", false
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV, FormatTodoist:
+		return "text/csv; charset=utf-8"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+type Kind string
+
+const (
+	KindCategory Kind = "category"
+	KindTask     Kind = "task"
+)
+
+// Fields name the values of a record, in the order exports write them: the
+// CSV header and the JSON keys.
+var Fields = []string{
+	"type", "id", "external_id", "title", "description", "status", "due_at",
+	"category", "color", "created_at", "updated_at",
+}
+
+// Record is one row of an export or import, a category or a task. A
+// category's name is its Title. Tasks name their category rather than
+// pointing at its ID, so files read well and move between instances.
+//
+// The optional fields are nil when a file leaves them out, which an import
+// treats as "keep what is there".
+type Record struct {
+	Type        Kind
+	ID          uint
+	ExternalID  string
+	Title       string
+	Description *string
+	Status      *taskDomain.TaskStatus
+	DueAt       *time.Time
+	// Category is the task's category name; empty means no category.
+	Category  *string
+	Color     *string
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+}
+
+// Values returns the record's fields as strings, in the order of Fields.
+// Times are RFC 3339 in UTC and missing values are empty.
+func (r *Record) Values() []string {
+	str := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	ts := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	status := ""
+	if r.Status != nil {
+		status = string(*r.Status)
+	}
+	id := ""
+	if r.ID != 0 {
+		id = strconv.FormatUint(uint64(r.ID), 10)
+	}
+	return []string{
+		string(r.Type), id, r.ExternalID, r.Title, str(r.Description), status, ts(r.DueAt),
+		str(r.Category), str(r.Color), ts(r.CreatedAt), ts(r.UpdatedAt),
+	}
+}
+
+// ParseRecord builds a record from the named values of an imported row.
+// Fields left out of values stay nil, while an empty one clears a task's
+// category. The type defaults to task; id and the timestamps are ignored,
+// since the importing instance assigns its own.
+func ParseRecord(values map[string]string) (*Record, error) {
+	record := &Record{
+		Type:       KindTask,
+		ExternalID: strings.TrimSpace(values["external_id"]),
+		Title:      strings.TrimSpace(values["title"]),
+	}
+	if kind := strings.ToLower(strings.TrimSpace(values["type"])); kind != "" {
+		record.Type = Kind(kind)
+	}
+	if record.Type != KindTask && record.Type != KindCategory {
+		return nil, fmt.Errorf("unknown type %q, want task or category", values["type"])
+	}
+	if record.Title == "" {
+		return nil, fmt.Errorf("title is required")
+	}
+	if description, ok := values["description"]; ok {
+		record.Description = &description
+	}
+	if color := strings.TrimSpace(values["color"]); color != "" {
+		record.Color = &color
+	}
+
+	// A task's fields may appear, empty, on category rows of a CSV file that
+	// has both, and the other way round.
+	other := map[Kind][]string{KindCategory: {"status", "due_at", "category"}, KindTask: {"color"}}
+	for _, field := range other[record.Type] {
+		if strings.TrimSpace(values[field]) != "" {
+			return nil, fmt.Errorf("a %s does not take %s", record.Type, field)
+		}
+	}
+	if record.Type == KindCategory {
+		return record, nil
+	}
+
+	if value := strings.TrimSpace(values["status"]); value != "" {
+		status, err := parseStatus(value)
+		if err != nil {
+			return nil, err
+		}
+		record.Status = &status
+	}
+	if value := strings.TrimSpace(values["due_at"]); value != "" {
+		dueAt, err := parseTime(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due_at %q, want RFC 3339 or YYYY-MM-DD", value)
+		}
+		record.DueAt = &dueAt
+	}
+	if category, ok := values["category"]; ok {
+		category = strings.TrimSpace(category)
+		record.Category = &category
+	}
+	return record, nil
+}
+
+// parseStatus accepts the task statuses in any case, as spreadsheets have
+// them.
+func parseStatus(value string) (taskDomain.TaskStatus, error) {
+	for _, status := range []taskDomain.TaskStatus{taskDomain.StatusPending, taskDomain.StatusDoing, taskDomain.StatusDone} {
+		if strings.EqualFold(value, string(status)) {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("invalid status %q, want Pending, Doing or Done", value)
+}
+
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+// RecordWriter writes an export.
+type RecordWriter interface {
+	Write(record *Record) error
+	// Close finishes the file, without closing what it was writing to.
+	Close() error
+}
+
+// RecordReader reads an import one row at a time.
+type RecordReader interface {
+	// Next returns the next row's number and named values, and io.EOF after
+	// the last. A *RowError reports a row that could not be read but can be
+	// skipped; any other error means the rest of the file cannot be read.
+	Next() (row int, values map[string]string, err error)
+}
+
+// Importer reads the export file of another tracker as records: its lists,
+// projects or labels become categories, and its cards or issues tasks, Done
+// when closed and Pending when open. External IDs start with the tracker's
+// format, such as trello:card:<id>, so importing a newer export updates
+// what the last one created. Rows count from 1 in the order Next returns
+// them, categories first.
+//
+// project names the project of a file that does not name its own, a single
+// Todoist CSV; importers whose files always do reject it.
+type Importer interface {
+	NewReader(r io.Reader, project string) (RecordReader, error)
+}
+
+// Codec reads and writes records in one format. mapping renames columns
+// on import: mapping[field] is the column, or JSON key, holding field.
+type Codec interface {
+	NewWriter(w io.Writer) RecordWriter
+	NewReader(r io.Reader, mapping map[string]string) (RecordReader, error)
+}
@@ -0,0 +1,19 @@
+package dto
+
+type ExportQueryDTO struct {
+	// Format defaults to csv.
+	Format string `form:"format" binding:"omitempty,oneof=csv json ndjson"`
+}
+
+type ImportQueryDTO struct {
+	// Format defaults to the one the Content-Type names. trello, todoist and
+	// github read those trackers' export files, and take no Map.
+	Format string `form:"format" binding:"omitempty,oneof=csv json ndjson trello todoist github"`
+	DryRun bool   `form:"dry_run"`
+	// Map may be repeated. Each is a field:column pair naming the column, or
+	// JSON key, to read a field from, such as title:Task Name.
+	Map []string `form:"map"`
+	// Project names the project of a single Todoist CSV, which does not
+	// name its own; backups and other formats take none.
+	Project string `form:"project"`
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ltphat2204/domain-driven-golang/common"
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/application"
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/dto"
+)
+
+const (
+	// maxImportBytes caps an uploaded file, which is read outside the
+	// server's read timeout.
+	maxImportBytes = 64 << 20
+	// importTimeout replaces the server's read and write timeouts for an
+	// import, which a large file outlasts.
+	importTimeout = 10 * time.Minute
+)
+
+type TransferHandler struct {
+	service       application.TransferService
+	maxImport     int64
+	importTimeout time.Duration
+}
+
+func NewTransferHandler(service application.TransferService) *TransferHandler {
+	return &TransferHandler{service: service, maxImport: maxImportBytes, importTimeout: importTimeout}
+}
+
+// Export streams the file as it is read from the database. A failure after
+// the first bytes were sent can only be logged, and cuts the file short.
+func (h *TransferHandler) Export(c *gin.Context) {
+	var query dto.ExportQueryDTO
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), err.Error()))
+		return
+	}
+	format := domain.FormatCSV
+	if query.Format != "" {
+		format = domain.Format(query.Format)
+	}
+
+	// A large export outlasts the server's write timeout.
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="tasks.%s"`, format))
+
+	w := bufio.NewWriter(c.Writer)
+	err := h.service.Export(c.Request.Context(), format, w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		return
+	}
+	if c.Writer.Written() {
+		slog.ErrorContext(c.Request.Context(), "export failed", "error", err)
+		return
+	}
+	c.Writer.Header().Del("Content-Disposition")
+	c.Writer.Header().Del("Content-Type")
+	c.JSON(http.StatusInternalServerError, common.NewErrorResponse(c.Request.Context(), http.StatusInternalServerError, "Failed to export", err.Error()))
+}
+
+// Import answers 200 with a report once every row has been tried, even if
+// some failed, 400 without importing anything if the file cannot be read,
+// and 413 if it is larger than maxImportBytes.
+func (h *TransferHandler) Import(c *gin.Context) {
+	var query dto.ImportQueryDTO
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), err.Error()))
+		return
+	}
+
+	format, ok := domain.Format(query.Format), query.Format != ""
+	if !ok {
+		format, ok = domain.FormatOf(c.ContentType())
+	}
+	if !ok {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponse(c.Request.Context(), http.StatusBadRequest, "Invalid import request", "set format, or a Content-Type of text/csv, application/json or application/x-ndjson"))
+		return
+	}
+	opts := domain.ImportOptions{DryRun: query.DryRun, Mapping: make(map[string]string), Project: query.Project}
+	for _, pair := range query.Map {
+		field, column, ok := strings.Cut(pair, ":")
+		if !ok || field == "" || column == "" {
+			c.JSON(http.StatusBadRequest, common.NewErrorResponse(c.Request.Context(), http.StatusBadRequest, "Invalid import request", fmt.Sprintf("map %q is not field:column", pair)))
+			return
+		}
+		opts.Mapping[field] = column
+	}
+
+	// Reading the file and sending the report must both outlast the server's
+	// timeouts, or a committed import could be reported as failed.
+	rc := http.NewResponseController(c.Writer)
+	deadline := time.Now().Add(h.importTimeout)
+	_ = rc.SetReadDeadline(deadline)
+	_ = rc.SetWriteDeadline(deadline)
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, h.maxImport)
+
+	report, err := h.service.Import(c.Request.Context(), format, body, opts)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, common.NewErrorResponse(c.Request.Context(), http.StatusRequestEntityTooLarge, "Invalid import file", fmt.Sprintf("the file is larger than %d bytes", tooLarge.Limit)))
+		return
+	}
+	var fileErr *domain.FileError
+	if errors.As(err, &fileErr) {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponse(c.Request.Context(), http.StatusBadRequest, "Invalid import file", err.Error()))
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(c.Request.Context(), http.StatusInternalServerError, "Failed to import", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, common.NewSuccessResponse(report))
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ltphat2204/domain-driven-golang/config"
+	"github.com/ltphat2204/domain-driven-golang/database"
+	categoryApplication "github.com/ltphat2204/domain-driven-golang/modules/category/application"
+	categoryInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/category/infrastructure"
+	taskApplication "github.com/ltphat2204/domain-driven-golang/modules/task/application"
+	taskInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/task/infrastructure"
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/application"
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/infrastructure"
+)
+
+// serverTimeout stands in for the server's read and write timeouts.
+const serverTimeout = 100 * time.Millisecond
+
+func newServer(t *testing.T, configure func(*TransferHandler)) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+	categories := categoryInfrastructure.NewMemoryCategoryRepository()
+	tasks := taskInfrastructure.NewMemoryTaskRepository(categories)
+	refs := infrastructure.NewMemoryExternalRefRepository()
+	transactor := database.NewMemoryTransactor(tasks.(database.Snapshotter), categories.(database.Snapshotter), refs.(database.Snapshotter))
+	service := application.NewTransferService(taskApplication.NewTaskService(tasks), categoryApplication.NewCategoryService(categories, config.ColorPalette), refs, transactor, infrastructure.Codecs(), infrastructure.Importers())
+
+	h := NewTransferHandler(service)
+	if configure != nil {
+		configure(h)
+	}
+	r := gin.New()
+	r.POST("/import", h.Import)
+	srv := httptest.NewUnstartedServer(r)
+	srv.Config.ReadTimeout = serverTimeout
+	srv.Config.WriteTimeout = serverTimeout
+	srv.Start()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestImportOutlastsServerTimeouts(t *testing.T) {
+	srv := newServer(t, nil)
+
+	// The file trickles in for several times the server's timeouts.
+	const rows = 6
+	body, w := io.Pipe()
+	go func() {
+		fmt.Fprintln(w, "title,external_id")
+		for i := range rows {
+			time.Sleep(serverTimeout / 2)
+			fmt.Fprintf(w, "task %d,slow:%d\n", i, i)
+		}
+		w.Close()
+	}()
+
+	resp, err := http.Post(srv.URL+"/import?format=csv", "text/csv", body)
+	if err != nil {
+		t.Fatalf("import past the server's timeouts: %v", err)
+	}
+	defer resp.Body.Close()
+	var envelope struct {
+		Data domain.ImportReport `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		t.Fatalf("reading the report: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || envelope.Data.Rows != rows || envelope.Data.Failed != 0 {
+		t.Errorf("import = %d %+v, want 200 with %d rows", resp.StatusCode, envelope.Data, rows)
+	}
+}
+
+func TestImportRejectsLargeFiles(t *testing.T) {
+	srv := newServer(t, func(h *TransferHandler) { h.maxImport = 64 })
+
+	file := "title\n" + strings.Repeat("a task with a long enough title\n", 4)
+	resp, err := http.Post(srv.URL+"/import?format=csv", "text/csv", strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		body, _ := io.ReadAll(resp.Body)
+		t.Errorf("import of %d bytes = %d %s, want 413", len(file), resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,345 @@
+package infrastructure
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/domain"
+)
+
+// Codecs returns a codec for every format.
+func Codecs() map[domain.Format]domain.Codec {
+	return map[domain.Format]domain.Codec{
+		domain.FormatCSV:    csvCodec{},
+		domain.FormatJSON:   jsonCodec{},
+		domain.FormatNDJSON: ndjsonCodec{},
+	}
+}
+
+// columns resolves mapping into the name each field is read from, in lower
+// case since headers and keys are matched without regard to case.
+func columns(mapping map[string]string) (map[string]string, error) {
+	names := make(map[string]string, len(domain.Fields))
+	for _, field := range domain.Fields {
+		names[field] = field
+	}
+	for field, column := range mapping {
+		if !slices.Contains(domain.Fields, field) {
+			return nil, fmt.Errorf("cannot map unknown field %q", field)
+		}
+		names[field] = strings.ToLower(strings.TrimSpace(column))
+	}
+	return names, nil
+}
+
+type csvCodec struct{}
+
+func (csvCodec) NewWriter(w io.Writer) domain.RecordWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+type csvWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (w *csvWriter) Write(record *domain.Record) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	return w.w.Write(record.Values())
+}
+
+func (w *csvWriter) Close() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func (w *csvWriter) writeHeader() error {
+	if w.header {
+		return nil
+	}
+	w.header = true
+	return w.w.Write(domain.Fields)
+}
+
+// NewReader reads the header and requires a title column; other fields
+// may be missing.
+func (csvCodec) NewReader(r io.Reader, mapping map[string]string) (domain.RecordReader, error) {
+	names, err := columns(mapping)
+	if err != nil {
+		return nil, err
+	}
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("the file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Spreadsheet programs may start the file with a byte order mark.
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	index := make(map[string]int, len(header))
+	for i, column := range header {
+		index[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	fields := make(map[string]int)
+	for field, column := range names {
+		if i, ok := index[column]; ok {
+			fields[field] = i
+		} else if _, mapped := mapping[field]; mapped {
+			return nil, fmt.Errorf("column %q is not in the header", mapping[field])
+		}
+	}
+	if _, ok := fields["title"]; !ok {
+		return nil, errors.New("the header has no title column; map one with map=title:<column>")
+	}
+	return &csvReader{r: reader, fields: fields}, nil
+}
+
+type csvReader struct {
+	r      *csv.Reader
+	fields map[string]int
+}
+
+func (r *csvReader) Next() (int, map[string]string, error) {
+	record, err := r.r.Read()
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		if errors.Is(err, csv.ErrFieldCount) {
+			return parseErr.StartLine, nil, &domain.RowError{Row: parseErr.StartLine, Message: fmt.Sprintf("has %d values, the header has %d", len(record), r.r.FieldsPerRecord)}
+		}
+		return parseErr.StartLine, nil, parseErr.Err
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	line, _ := r.r.FieldPos(0)
+	values := make(map[string]string, len(r.fields))
+	for field, i := range r.fields {
+		values[field] = record[i]
+	}
+	return line, values, nil
+}
+
+// jsonRecord is a record as JSON writes it. Fields that do not apply to a
+// kind of record are left out; the others are always written, so an import
+// of the file sets them.
+type jsonRecord struct {
+	Type        domain.Kind `json:"type"`
+	ID          uint        `json:"id,omitempty"`
+	ExternalID  string      `json:"external_id,omitempty"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	Status      string      `json:"status,omitempty"`
+	DueAt       string      `json:"due_at,omitempty"`
+	Category    *string     `json:"category,omitempty"`
+	Color       string      `json:"color,omitempty"`
+	CreatedAt   string      `json:"created_at,omitempty"`
+	UpdatedAt   string      `json:"updated_at,omitempty"`
+}
+
+func encodeRecord(record *domain.Record) ([]byte, error) {
+	values := record.Values()
+	value := func(field string) string {
+		return values[slices.Index(domain.Fields, field)]
+	}
+	out := jsonRecord{
+		Type:        record.Type,
+		ID:          record.ID,
+		ExternalID:  record.ExternalID,
+		Title:       record.Title,
+		Description: value("description"),
+		Status:      value("status"),
+		DueAt:       value("due_at"),
+		Color:       value("color"),
+		CreatedAt:   value("created_at"),
+		UpdatedAt:   value("updated_at"),
+	}
+	if record.Type == domain.KindTask {
+		category := value("category")
+		out.Category = &category
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(out); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// decodeRecord reads the named values of one JSON object. Keys are matched
+// without regard to case, and null values count as missing.
+func decodeRecord(row int, data []byte, names map[string]string) (map[string]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil || object == nil {
+		return nil, &domain.RowError{Row: row, Message: "is not a JSON object"}
+	}
+	keys := make(map[string]interface{}, len(object))
+	for key, value := range object {
+		keys[strings.ToLower(key)] = value
+	}
+
+	values := make(map[string]string)
+	for field, key := range names {
+		switch value := keys[key].(type) {
+		case nil:
+		case string:
+			values[field] = value
+		case json.Number:
+			values[field] = value.String()
+		case bool:
+			values[field] = strconv.FormatBool(value)
+		default:
+			return nil, &domain.RowError{Row: row, Message: fmt.Sprintf("%s must be a string or a number", key)}
+		}
+	}
+	return values, nil
+}
+
+// jsonCodec writes an array with a record per line, which diffs well.
+type jsonCodec struct{}
+
+func (jsonCodec) NewWriter(w io.Writer) domain.RecordWriter {
+	return &jsonWriter{w: w}
+}
+
+type jsonWriter struct {
+	w       io.Writer
+	written bool
+}
+
+func (w *jsonWriter) Write(record *domain.Record) error {
+	data, err := encodeRecord(record)
+	if err != nil {
+		return err
+	}
+	separator := ",\n"
+	if !w.written {
+		separator, w.written = "[\n", true
+	}
+	if _, err := io.WriteString(w.w, separator); err != nil {
+		return err
+	}
+	_, err = w.w.Write(data)
+	return err
+}
+
+func (w *jsonWriter) Close() error {
+	end := "\n]\n"
+	if !w.written {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(w.w, end)
+	return err
+}
+
+func (jsonCodec) NewReader(r io.Reader, mapping map[string]string) (domain.RecordReader, error) {
+	names, err := columns(mapping)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(r)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, errors.New("the file is not a JSON array")
+	}
+	return &jsonReader{decoder: decoder, names: names}, nil
+}
+
+type jsonReader struct {
+	decoder *json.Decoder
+	names   map[string]string
+	row     int
+}
+
+func (r *jsonReader) Next() (int, map[string]string, error) {
+	if !r.decoder.More() {
+		if _, err := r.decoder.Token(); err != nil {
+			return r.row, nil, err
+		}
+		return r.row, nil, io.EOF
+	}
+	r.row++
+	var data json.RawMessage
+	if err := r.decoder.Decode(&data); err != nil {
+		return r.row, nil, err
+	}
+	values, err := decodeRecord(r.row, data, r.names)
+	return r.row, values, err
+}
+
+type ndjsonCodec struct{}
+
+func (ndjsonCodec) NewWriter(w io.Writer) domain.RecordWriter {
+	return &ndjsonWriter{w: w}
+}
+
+type ndjsonWriter struct {
+	w io.Writer
+}
+
+func (w *ndjsonWriter) Write(record *domain.Record) error {
+	data, err := encodeRecord(record)
+	if err != nil {
+		return err
+	}
+	_, err = w.w.Write(append(data, '\n'))
+	return err
+}
+
+func (w *ndjsonWriter) Close() error {
+	return nil
+}
+
+// maxLine bounds an NDJSON line, which is one record.
+const maxLine = 1 << 20
+
+func (ndjsonCodec) NewReader(r io.Reader, mapping map[string]string) (domain.RecordReader, error) {
+	names, err := columns(mapping)
+	if err != nil {
+		return nil, err
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLine)
+	return &ndjsonReader{scanner: scanner, names: names}, nil
+}
+
+type ndjsonReader struct {
+	scanner *bufio.Scanner
+	names   map[string]string
+	line    int
+}
+
+// Next skips blank lines. A line that is not JSON only fails its row.
+func (r *ndjsonReader) Next() (int, map[string]string, error) {
+	for r.scanner.Scan() {
+		r.line++
+		line := bytes.TrimSpace(r.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		values, err := decodeRecord(r.line, line, r.names)
+		return r.line, values, err
+	}
+	if err := r.scanner.Err(); err != nil {
+		return r.line + 1, nil, err
+	}
+	return r.line, nil, io.EOF
+}
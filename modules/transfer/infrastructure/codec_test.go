@@ -0,0 +1,137 @@
+package infrastructure
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"maps"
+	"strings"
+	"testing"
+	"time"
+
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/domain"
+)
+
+type row struct {
+	line   int
+	values map[string]string
+	err    string
+}
+
+func readAll(t *testing.T, reader domain.RecordReader) []row {
+	t.Helper()
+	var rows []row
+	for {
+		line, values, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return rows
+		}
+		var rowErr *domain.RowError
+		if err != nil && !errors.As(err, &rowErr) {
+			t.Fatalf("Next: %v", err)
+		}
+		r := row{line: line, values: values}
+		if err != nil {
+			r.err = rowErr.Message
+		}
+		rows = append(rows, r)
+	}
+}
+
+func TestReaders(t *testing.T) {
+	tests := []struct {
+		format  domain.Format
+		input   string
+		mapping map[string]string
+		want    []row
+	}{
+		{
+			format:  domain.FormatCSV,
+			input:   "\ufeffName,STATUS,Notes\n\"Write\nreport\",done,x\nshort\nBuy milk,,\n",
+			mapping: map[string]string{"title": "name"},
+			want: []row{
+				{line: 2, values: map[string]string{"title": "Write\nreport", "status": "done"}},
+				{line: 4, err: "has 1 values, the header has 3"},
+				{line: 5, values: map[string]string{"title": "Buy milk", "status": ""}},
+			},
+		},
+		{
+			format:  domain.FormatJSON,
+			input:   `[{"Task":"Write","id":7,"category":null}, "loose", {"title":"Nested","description":{"a":1}}]`,
+			mapping: map[string]string{"title": "task"},
+			want: []row{
+				{line: 1, values: map[string]string{"title": "Write", "id": "7"}},
+				{line: 2, err: "is not a JSON object"},
+				{line: 3, err: "description must be a string or a number"},
+			},
+		},
+		{
+			format: domain.FormatNDJSON,
+			input:  "{\"title\":\"Write\",\"done\":true}\n\n{\"title\":\n",
+			want: []row{
+				{line: 1, values: map[string]string{"title": "Write"}},
+				{line: 3, err: "is not a JSON object"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			reader, err := Codecs()[tt.format].NewReader(strings.NewReader(tt.input), tt.mapping)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := readAll(t, reader)
+			if len(got) != len(tt.want) {
+				t.Fatalf("read %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i].line != tt.want[i].line || got[i].err != tt.want[i].err || !maps.Equal(got[i].values, tt.want[i].values) {
+					t.Errorf("row %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	due := time.Date(2025, 6, 3, 17, 0, 0, 0, time.UTC)
+	name, empty, color, status := "Work", "", "#4363d8", taskDomain.StatusDoing
+	records := []*domain.Record{
+		{Type: domain.KindCategory, ID: 1, Title: name, Description: &empty, Color: &color},
+		{Type: domain.KindTask, ID: 7, ExternalID: "sheet-12", Title: `Write "the" report, v2`, Description: &empty, Status: &status, DueAt: &due, Category: &name},
+	}
+	for format, codec := range Codecs() {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			writer := codec.NewWriter(&buf)
+			for _, record := range records {
+				if err := writer.Write(record); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			reader, err := codec.NewReader(&buf, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rows := readAll(t, reader)
+			if len(rows) != len(records) {
+				t.Fatalf("read %d rows, want %d", len(rows), len(records))
+			}
+			for i, r := range rows {
+				record, err := domain.ParseRecord(r.values)
+				if err != nil {
+					t.Fatalf("row %d: %v", i, err)
+				}
+				record.ID = records[i].ID
+				if got, want := strings.Join(record.Values(), "|"), strings.Join(records[i].Values(), "|"); got != want {
+					t.Errorf("row %d = %s, want %s", i, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/ltphat2204/domain-driven-golang/database"
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type externalRefRepository struct {
+	db *gorm.DB
+}
+
+func NewExternalRefRepository(db *gorm.DB) domain.ExternalRefRepository {
+	return &externalRefRepository{db: db}
+}
+
+func (r *externalRefRepository) Find(ctx context.Context, entity domain.Kind, externalID string) (*domain.ExternalRef, error) {
+	var ref domain.ExternalRef
+	result := database.Conn(ctx, r.db).Where("entity = ? AND external_id = ?", entity, externalID).First(&ref)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &ref, nil
+}
+
+func (r *externalRefRepository) FindByEntityIDs(ctx context.Context, entity domain.Kind, ids []uint) (map[uint]string, error) {
+	externalIDs := make(map[uint]string)
+	if len(ids) == 0 {
+		return externalIDs, nil
+	}
+	var refs []*domain.ExternalRef
+	err := database.Conn(ctx, r.db).Where("entity = ? AND entity_id IN ?", entity, ids).Order("id").Find(&refs).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range refs {
+		if _, ok := externalIDs[ref.EntityID]; !ok {
+			externalIDs[ref.EntityID] = ref.ExternalID
+		}
+	}
+	return externalIDs, nil
+}
+
+func (r *externalRefRepository) Save(ctx context.Context, ref *domain.ExternalRef) error {
+	return database.Conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "entity"}, {Name: "external_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"entity_id"}),
+	}).Create(ref).Error
+}
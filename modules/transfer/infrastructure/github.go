@@ -0,0 +1,90 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/domain"
+)
+
+// githubIssue is the part of an issue that is imported, as both the REST
+// API and gh issue list --json write it.
+type githubIssue struct {
+	Number int     `json:"number"`
+	Title  string  `json:"title"`
+	Body   *string `json:"body"`
+	State  string  `json:"state"`
+	Labels []struct {
+		Name        string  `json:"name"`
+		Description *string `json:"description"`
+	} `json:"labels"`
+	URL         string          `json:"url"`
+	HTMLURL     string          `json:"html_url"`
+	PullRequest json.RawMessage `json:"pull_request"`
+}
+
+// githubImporter imports a JSON array of issues. Labels become categories,
+// and an issue is in the category of its first label, since a task has one.
+// Pull requests, which the REST API lists with issues, are skipped.
+type githubImporter struct{}
+
+func (githubImporter) NewReader(r io.Reader, project string) (domain.RecordReader, error) {
+	if project != "" {
+		return nil, errors.New("project only applies to a single Todoist CSV")
+	}
+	var issues []githubIssue
+	if err := json.NewDecoder(r).Decode(&issues); err != nil {
+		return nil, errors.New("the file is not a JSON array of GitHub issues")
+	}
+
+	var rows rows
+	for _, issue := range issues {
+		// Some exports write "pull_request": null on plain issues.
+		if len(issue.PullRequest) > 0 && string(issue.PullRequest) != "null" {
+			continue
+		}
+		repo := githubRepo(issue)
+		for _, label := range issue.Labels {
+			rows.category("github:label:"+repo+":"+label.Name, label.Name, label.Description)
+		}
+
+		values := map[string]string{
+			"external_id": "github:issue:" + repo + "#" + strconv.Itoa(issue.Number),
+			"title":       issue.Title,
+			"description": "",
+			"status":      status(strings.EqualFold(issue.State, "closed")),
+			"category":    "",
+		}
+		if issue.Body != nil {
+			values["description"] = *issue.Body
+		}
+		if len(issue.Labels) > 0 {
+			values["category"] = issue.Labels[0].Name
+		}
+		rows.task(values)
+	}
+	return rows.reader(), nil
+}
+
+// githubRepo returns the owner/name of the issue's repository, from its web
+// URL or its API URL, so issues of several repositories can share a file.
+func githubRepo(issue githubIssue) string {
+	for _, link := range []string{issue.HTMLURL, issue.URL} {
+		u, err := url.Parse(link)
+		if err != nil {
+			continue
+		}
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if len(parts) > 0 && parts[0] == "repos" {
+			parts = parts[1:]
+		}
+		if len(parts) >= 2 && parts[0] != "" {
+			return parts[0] + "/" + parts[1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,73 @@
+package infrastructure
+
+import (
+	"io"
+
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/domain"
+)
+
+// Importers returns an importer for every tracker whose files can be
+// imported.
+func Importers() map[domain.Format]domain.Importer {
+	return map[domain.Format]domain.Importer{
+		domain.FormatTrello:  trelloImporter{},
+		domain.FormatTodoist: todoistImporter{},
+		domain.FormatGitHub:  githubImporter{},
+	}
+}
+
+// rows collects the rows of a tracker's file, categories before tasks, so a
+// task's category is imported with its external ID before the task names
+// it. Each category is added once.
+type rows struct {
+	categories []map[string]string
+	tasks      []map[string]string
+	seen       map[string]bool
+}
+
+func (r *rows) category(externalID, name string, description *string) {
+	if r.seen == nil {
+		r.seen = make(map[string]bool)
+	}
+	if r.seen[externalID] {
+		return
+	}
+	r.seen[externalID] = true
+	values := map[string]string{"type": string(domain.KindCategory), "external_id": externalID, "title": name}
+	if description != nil {
+		values["description"] = *description
+	}
+	r.categories = append(r.categories, values)
+}
+
+func (r *rows) task(values map[string]string) {
+	values["type"] = string(domain.KindTask)
+	r.tasks = append(r.tasks, values)
+}
+
+func (r *rows) reader() domain.RecordReader {
+	return &rowsReader{rows: append(r.categories, r.tasks...)}
+}
+
+// rowsReader reads rows an importer has already built.
+type rowsReader struct {
+	rows []map[string]string
+	next int
+}
+
+func (r *rowsReader) Next() (int, map[string]string, error) {
+	if r.next == len(r.rows) {
+		return r.next, nil, io.EOF
+	}
+	r.next++
+	return r.next, r.rows[r.next-1], nil
+}
+
+// status maps the open or closed state of a card or issue.
+func status(closed bool) string {
+	if closed {
+		return string(taskDomain.StatusDone)
+	}
+	return string(taskDomain.StatusPending)
+}
@@ -0,0 +1,234 @@
+package infrastructure
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/domain"
+)
+
+func category(externalID, name string) map[string]string {
+	return map[string]string{"type": "category", "external_id": externalID, "title": name}
+}
+
+// todoistBackup zips the CSVs of testdata/todoist_backup as Todoist does.
+func todoistBackup(t *testing.T) []byte {
+	t.Helper()
+	files, err := filepath.Glob("testdata/todoist_backup/*.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w, err := archive.Create(filepath.Base(file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestImporters(t *testing.T) {
+	fixture := func(name string) func(*testing.T) []byte {
+		return func(t *testing.T) []byte {
+			data, err := os.ReadFile(filepath.Join("testdata", name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return data
+		}
+	}
+	tests := []struct {
+		name    string
+		format  domain.Format
+		file    func(*testing.T) []byte
+		project string
+		// want leaves out Todoist's hashed external IDs, which are checked
+		// apart.
+		want []map[string]string
+	}{
+		{
+			name: "trello", format: domain.FormatTrello, file: fixture("trello_board.json"),
+			want: []map[string]string{
+				category("trello:list:65f1c0a2b3d4e5f600000101", "Backlog"),
+				category("trello:list:65f1c0a2b3d4e5f600000102", "In progress"),
+				category("trello:list:65f1c0a2b3d4e5f600000103", "Shipped"),
+				{"type": "task", "external_id": "trello:card:65f1c0a2b3d4e5f600001001", "title": "Pick a colour scheme", "description": "Two options, light and dark.", "status": "Pending", "due_at": "2025-06-03T17:00:00Z", "category": "In progress"},
+				{"type": "task", "external_id": "trello:card:65f1c0a2b3d4e5f600001002", "title": "Write the launch post", "description": "", "status": "Done", "due_at": "2025-05-20T09:00:00Z", "category": "Backlog"},
+				{"type": "task", "external_id": "trello:card:65f1c0a2b3d4e5f600001003", "title": "Old landing page", "description": "Archived after the redesign.", "status": "Done", "category": "Shipped"},
+			},
+		},
+		{
+			name: "todoist project", format: domain.FormatTodoist, file: fixture("todoist_project.csv"), project: "Home",
+			want: []map[string]string{
+				category("todoist:project:Home", "Home"),
+				{"type": "task", "title": "Renew passport", "description": "Bring two photos", "status": "Pending", "due_at": "2025-06-03T00:00:00Z", "category": "Home"},
+				{"type": "task", "title": "Water plants", "description": "", "status": "Pending", "category": "Home"},
+				{"type": "task", "title": "Water plants", "description": "", "status": "Pending", "category": "Home"},
+			},
+		},
+		{
+			name: "todoist backup", format: domain.FormatTodoist, file: todoistBackup,
+			want: []map[string]string{
+				category("todoist:project:2203306142", "Errands"),
+				category("todoist:project:2203306141", "Work"),
+				{"type": "task", "title": "Buy milk", "description": "Oat", "status": "Pending", "category": "Errands"},
+				{"type": "task", "title": "Prepare the quarterly review", "description": "", "status": "Pending", "due_at": "2025-06-30T14:00:00Z", "category": "Work"},
+			},
+		},
+		{
+			name: "github api", format: domain.FormatGitHub, file: fixture("github_issues.json"),
+			want: []map[string]string{
+				{"type": "category", "external_id": "github:label:acme/website:bug", "title": "bug", "description": "Something isn't working"},
+				category("github:label:acme/website:frontend", "frontend"),
+				{"type": "task", "external_id": "github:issue:acme/website#12", "title": "Footer links are broken", "description": "The privacy link 404s.", "status": "Pending", "category": "bug"},
+				{"type": "task", "external_id": "github:issue:acme/website#14", "title": "Dark mode flickers on load", "description": "", "status": "Pending", "category": ""},
+				{"type": "task", "external_id": "github:issue:acme/website#9", "title": "Add a sitemap", "description": "", "status": "Done", "category": ""},
+			},
+		},
+		{
+			name: "github cli", format: domain.FormatGitHub, file: fixture("github_gh_issues.json"),
+			want: []map[string]string{
+				{"type": "category", "external_id": "github:label:acme/cli:bug", "title": "bug", "description": "Something isn't working"},
+				{"type": "task", "external_id": "github:issue:acme/cli#4", "title": "Crash on empty config", "description": "", "status": "Done", "category": "bug"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.file(t)
+			read := func() []row {
+				reader, err := Importers()[tt.format].NewReader(bytes.NewReader(data), tt.project)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return readAll(t, reader)
+			}
+			got, again := read(), read()
+			if len(got) != len(tt.want) {
+				t.Fatalf("read %+v, want %+v", got, tt.want)
+			}
+
+			ids := make(map[string]bool)
+			for i, r := range got {
+				if r.line != i+1 || r.err != "" {
+					t.Errorf("row %d = %+v", i, r)
+				}
+				values := maps.Clone(r.values)
+				if id := values["external_id"]; tt.format == domain.FormatTodoist && values["type"] == "task" {
+					if !strings.HasPrefix(id, "todoist:task:") || ids[id] || again[i].values["external_id"] != id {
+						t.Errorf("row %d has external ID %q, want a stable one unique to the task", i, id)
+					}
+					ids[id] = true
+					delete(values, "external_id")
+				}
+				if !maps.Equal(values, tt.want[i]) {
+					t.Errorf("row %d = %v, want %v", i, values, tt.want[i])
+				}
+				if _, err := domain.ParseRecord(r.values); err != nil {
+					t.Errorf("row %d: %v", i, err)
+				}
+			}
+		})
+	}
+}
+
+func TestImportersRejectOtherFiles(t *testing.T) {
+	for format, importer := range Importers() {
+		project := ""
+		if format == domain.FormatTodoist {
+			project = "Inbox"
+		}
+		if _, err := importer.NewReader(strings.NewReader("title\nShip it\n"), project); err == nil {
+			t.Errorf("%s read a generic CSV file", format)
+		}
+	}
+}
+
+func TestTodoistProjectsStayApart(t *testing.T) {
+	data, err := os.ReadFile("testdata/todoist_project.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make(map[string]string)
+	for _, project := range []string{"Home", "Cabin"} {
+		reader, err := todoistImporter{}.NewReader(bytes.NewReader(data), project)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range readAll(t, reader) {
+			id := r.values["external_id"]
+			if other, ok := ids[id]; ok {
+				t.Errorf("%s and %s share the external ID %q", other, project, id)
+			}
+			ids[id] = project
+		}
+	}
+}
+
+func TestTodoistProjectNaming(t *testing.T) {
+	data, err := os.ReadFile("testdata/todoist_project.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (todoistImporter{}).NewReader(bytes.NewReader(data), " "); err == nil {
+		t.Error("read a single CSV without a project")
+	}
+	if _, err := (todoistImporter{}).NewReader(bytes.NewReader(todoistBackup(t)), "Home"); err == nil {
+		t.Error("read a backup given a project")
+	}
+	for format, importer := range Importers() {
+		if format == domain.FormatTodoist {
+			continue
+		}
+		if _, err := importer.NewReader(strings.NewReader("[]"), "Home"); err == nil {
+			t.Errorf("%s took a project", format)
+		}
+	}
+}
+
+func TestTodoistBackupUnzippedLimit(t *testing.T) {
+	// Blank lines are skipped, so only the limit stops the read, and they
+	// compress to a few hundred kilobytes.
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	w, err := archive.Create("Inbox [1].csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := "TYPE,CONTENT,DESCRIPTION,PRIORITY,INDENT,AUTHOR,RESPONSIBLE,DATE,DATE_LANG,TIMEZONE,DURATION,DURATION_UNIT\n"
+	if _, err := io.WriteString(w, header); err != nil {
+		t.Fatal(err)
+	}
+	blank := bytes.Repeat([]byte("\n"), 1<<20)
+	for range maxUnzipped>>20 + 1 {
+		if _, err := w.Write(blank); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := (todoistImporter{}).NewReader(&buf, ""); !errors.Is(err, errUnzippedTooLarge) {
+		t.Errorf("read a %d-byte backup that unzips past the limit: error = %v", buf.Len(), err)
+	}
+}
@@ -0,0 +1,91 @@
+package infrastructure
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/domain"
+	"gorm.io/gorm"
+)
+
+type refKey struct {
+	entity     domain.Kind
+	externalID string
+}
+
+// memoryExternalRefRepository keeps refs in a map for the memory database
+// driver.
+type memoryExternalRefRepository struct {
+	mu     sync.RWMutex
+	nextID uint
+	refs   map[refKey]domain.ExternalRef
+}
+
+func NewMemoryExternalRefRepository() domain.ExternalRefRepository {
+	return &memoryExternalRefRepository{refs: make(map[refKey]domain.ExternalRef)}
+}
+
+func (r *memoryExternalRefRepository) Find(ctx context.Context, entity domain.Kind, externalID string) (*domain.ExternalRef, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ref, ok := r.refs[refKey{entity, externalID}]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &ref, nil
+}
+
+func (r *memoryExternalRefRepository) FindByEntityIDs(ctx context.Context, entity domain.Kind, ids []uint) (map[uint]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	first := make(map[uint]domain.ExternalRef)
+	for _, ref := range r.refs {
+		if ref.Entity != entity || !wanted[ref.EntityID] {
+			continue
+		}
+		if seen, ok := first[ref.EntityID]; !ok || ref.ID < seen.ID {
+			first[ref.EntityID] = ref
+		}
+	}
+	externalIDs := make(map[uint]string, len(first))
+	for id, ref := range first {
+		externalIDs[id] = ref.ExternalID
+	}
+	return externalIDs, nil
+}
+
+func (r *memoryExternalRefRepository) Save(ctx context.Context, ref *domain.ExternalRef) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := refKey{ref.Entity, ref.ExternalID}
+	if existing, ok := r.refs[key]; ok {
+		ref.ID, ref.CreatedAt = existing.ID, existing.CreatedAt
+	} else {
+		r.nextID++
+		ref.ID, ref.CreatedAt = r.nextID, time.Now()
+	}
+	r.refs[key] = *ref
+	return nil
+}
+
+// Snapshot implements database.Snapshotter, so a memory transactor can roll
+// back the refs.
+func (r *memoryExternalRefRepository) Snapshot() func() {
+	r.mu.RLock()
+	nextID, refs := r.nextID, maps.Clone(r.refs)
+	r.mu.RUnlock()
+	return func() {
+		r.mu.Lock()
+		r.nextID, r.refs = nextID, refs
+		r.mu.Unlock()
+	}
+}
@@ -0,0 +1,203 @@
+package infrastructure
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/domain"
+)
+
+const (
+	// maxBackup bounds a Todoist backup, which is read whole to unzip it.
+	maxBackup = 32 << 20
+	// maxUnzipped bounds the CSVs of a backup once decompressed, since a
+	// small zip can expand to far more, and every row is held in memory.
+	maxUnzipped = 128 << 20
+)
+
+var errUnzippedTooLarge = fmt.Errorf("the backup is larger than %d MB unzipped", maxUnzipped>>20)
+
+// todoistImporter imports a project's CSV export, or a backup: a zip of
+// every project's CSV, each named after its project. Projects become
+// categories and their tasks are Pending, since exports leave completed
+// tasks out. A single CSV does not name its project, so the import must.
+//
+// Todoist does not export task IDs. A task's external ID is instead a hash
+// of its project and content, and of how many tasks before it in the
+// project share the content, so a re-import finds the task as long as it
+// was not renamed.
+type todoistImporter struct{}
+
+func (todoistImporter) NewReader(r io.Reader, project string) (domain.RecordReader, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxBackup+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxBackup {
+		return nil, fmt.Errorf("the file is larger than %d MB", maxBackup>>20)
+	}
+
+	var rows rows
+	if !bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		// Without its project, the same task in two projects would get the
+		// same external ID, and one import would overwrite the other.
+		if strings.TrimSpace(project) == "" {
+			return nil, errors.New("a single Todoist CSV does not name its project; set project")
+		}
+		if err := readTodoistProject(&rows, strings.TrimSpace(project), "", bytes.NewReader(data)); err != nil {
+			return nil, err
+		}
+		return rows.reader(), nil
+	}
+	if project != "" {
+		return nil, errors.New("a Todoist backup names its projects, so takes no project")
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("the file is not a Todoist backup: %w", err)
+	}
+	unzipped := &unzipLimit{left: maxUnzipped}
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() || !strings.EqualFold(path.Ext(file.Name), ".csv") {
+			continue
+		}
+		name, id := todoistProject(file.Name)
+		f, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		unzipped.r = f
+		err = readTodoistProject(&rows, name, id, unzipped)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Name, err)
+		}
+	}
+	return rows.reader(), nil
+}
+
+// unzipLimit reads the entries of a backup in turn, failing once together
+// they pass maxUnzipped bytes.
+type unzipLimit struct {
+	r    io.Reader
+	left int64
+}
+
+func (l *unzipLimit) Read(p []byte) (int, error) {
+	// Reading one byte past the limit tells an entry that ends on it from
+	// one that goes on.
+	if int64(len(p)) > l.left+1 {
+		p = p[:l.left+1]
+	}
+	n, err := l.r.Read(p)
+	l.left -= int64(n)
+	if l.left < 0 {
+		return n, errUnzippedTooLarge
+	}
+	return n, err
+}
+
+// todoistFile matches the name of a project's CSV in a backup, such as
+// "Work [2203306141].csv".
+var todoistFile = regexp.MustCompile(`^(.*?)(?: \[(\w+)\])?\.csv$`)
+
+// todoistProject returns the name and ID, if it has one, of the project
+// whose CSV is named file.
+func todoistProject(file string) (name, id string) {
+	m := todoistFile.FindStringSubmatch(path.Base(file))
+	if m == nil {
+		return strings.TrimSuffix(path.Base(file), path.Ext(file)), ""
+	}
+	return m[1], m[2]
+}
+
+// readTodoistProject adds the tasks of a project's CSV, and the project
+// unless name is empty. Sections and comments are skipped.
+func readTodoistProject(rows *rows, name, id string, r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return errors.New("the file is empty")
+	}
+	if err != nil {
+		return err
+	}
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	index := make(map[string]int, len(header))
+	for i, column := range header {
+		index[strings.ToUpper(strings.TrimSpace(column))] = i
+	}
+	for _, column := range []string{"TYPE", "CONTENT"} {
+		if _, ok := index[column]; !ok {
+			return fmt.Errorf("the header has no %s column; is this a Todoist export?", column)
+		}
+	}
+
+	project := id
+	if project == "" {
+		project = name
+	}
+	if name != "" {
+		rows.category("todoist:project:"+project, name, nil)
+	}
+	seen := make(map[string]int)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		value := func(column string) string {
+			if i, ok := index[column]; ok && i < len(record) {
+				return record[i]
+			}
+			return ""
+		}
+		if !strings.EqualFold(strings.TrimSpace(value("TYPE")), "task") {
+			continue
+		}
+
+		content := value("CONTENT")
+		seen[content]++
+		hash := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%s\x00%d", project, content, seen[content])))
+		values := map[string]string{
+			"external_id": "todoist:task:" + hex.EncodeToString(hash[:12]),
+			"title":       content,
+			"description": value("DESCRIPTION"),
+			"status":      status(false),
+		}
+		if dueAt, ok := todoistDate(value("DATE")); ok {
+			values["due_at"] = dueAt
+		}
+		if name != "" {
+			values["category"] = name
+		}
+		rows.task(values)
+	}
+}
+
+// todoistDate returns the due date of a task, if it is a date rather than a
+// recurrence such as "every monday".
+func todoistDate(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{time.RFC3339, time.DateTime, "2006-01-02 15:04", time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC().Format(time.RFC3339), true
+		}
+	}
+	return "", false
+}
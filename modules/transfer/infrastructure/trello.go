@@ -0,0 +1,65 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/domain"
+)
+
+// trelloBoard is the part of a Trello board's JSON export that is imported.
+type trelloBoard struct {
+	Lists []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"lists"`
+	Cards []struct {
+		ID          string     `json:"id"`
+		Name        string     `json:"name"`
+		Desc        string     `json:"desc"`
+		Closed      bool       `json:"closed"`
+		Due         *time.Time `json:"due"`
+		DueComplete bool       `json:"dueComplete"`
+		IDList      string     `json:"idList"`
+	} `json:"cards"`
+}
+
+// trelloImporter imports a board: its lists become categories and its
+// cards tasks. A card is Done once archived or its due date is marked
+// complete.
+type trelloImporter struct{}
+
+func (trelloImporter) NewReader(r io.Reader, project string) (domain.RecordReader, error) {
+	if project != "" {
+		return nil, errors.New("project only applies to a single Todoist CSV")
+	}
+	var board trelloBoard
+	if err := json.NewDecoder(r).Decode(&board); err != nil {
+		return nil, errors.New("the file is not a Trello board export")
+	}
+
+	var rows rows
+	lists := make(map[string]string, len(board.Lists))
+	for _, list := range board.Lists {
+		lists[list.ID] = list.Name
+		rows.category("trello:list:"+list.ID, list.Name, nil)
+	}
+	for _, card := range board.Cards {
+		values := map[string]string{
+			"external_id": "trello:card:" + card.ID,
+			"title":       card.Name,
+			"description": card.Desc,
+			"status":      status(card.Closed || card.DueComplete),
+		}
+		if card.Due != nil {
+			values["due_at"] = card.Due.UTC().Format(time.RFC3339)
+		}
+		if list, ok := lists[card.IDList]; ok {
+			values["category"] = list
+		}
+		rows.task(values)
+	}
+	return rows.reader(), nil
+}
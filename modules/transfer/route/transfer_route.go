@@ -0,0 +1,36 @@
+package route
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/dto"
+	"github.com/ltphat2204/domain-driven-golang/modules/transfer/handler"
+	"github.com/ltphat2204/domain-driven-golang/openapi"
+)
+
+func SetupRoutes(r *gin.Engine, transferHandler *handler.TransferHandler) {
+	r.GET("/export", transferHandler.Export)
+	r.POST("/import", transferHandler.Import)
+}
+
+// Operations documents the routes SetupRoutes registers. Both take files in
+// any of the formats; imports also take Todoist backups.
+func Operations() []openapi.Operation {
+	tags := []string{"transfer"}
+	var contentTypes []string
+	for _, format := range domain.Formats {
+		contentType, _, _ := strings.Cut(format.ContentType(), ";")
+		contentTypes = append(contentTypes, contentType)
+	}
+	return []openapi.Operation{
+		{Method: "GET", Path: "/export", ID: "exportTasks", Summary: "Export every category and task", Tags: tags,
+			Query: dto.ExportQueryDTO{}, ContentTypes: contentTypes,
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError}},
+		{Method: "POST", Path: "/import", ID: "importTasks", Summary: "Import categories and tasks, updating those imported before", Tags: tags,
+			Query: dto.ImportQueryDTO{}, BodyTypes: append(contentTypes, "application/zip"), Response: domain.ImportReport{},
+			Errors: []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusInternalServerError}},
+	}
+}
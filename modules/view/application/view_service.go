@@ -0,0 +1,171 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ltphat2204/domain-driven-golang/common"
+	taskApplication "github.com/ltphat2204/domain-driven-golang/modules/task/application"
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/view/domain"
+)
+
+type ViewService interface {
+	CreateView(ctx context.Context, view *domain.View) (*domain.View, error)
+	GetViewByID(ctx context.Context, userID string, id uint) (*domain.View, error)
+	GetViews(ctx context.Context, userID string) ([]*domain.View, error)
+	UpdateView(ctx context.Context, userID string, id uint, update *domain.ViewUpdate) (*domain.View, error)
+	DeleteView(ctx context.Context, userID string, id uint) error
+	GetViewTasks(ctx context.Context, userID string, id uint, page common.BaseQuery) (*domain.View, *taskDomain.TaskQuery, []*taskDomain.Task, int, error)
+}
+
+type viewService struct {
+	repo  domain.ViewRepository
+	tasks taskApplication.TaskService
+}
+
+func NewViewService(repo domain.ViewRepository, tasks taskApplication.TaskService) ViewService {
+	return &viewService{repo: repo, tasks: tasks}
+}
+
+func (s *viewService) CreateView(ctx context.Context, view *domain.View) (*domain.View, error) {
+	if view.Visibility == "" {
+		view.Visibility = domain.VisibilityPersonal
+	}
+	if err := view.Validate(); err != nil {
+		return nil, err
+	}
+	pinned, position := view.Pinned, view.Position
+	view, err := s.repo.Save(ctx, view)
+	if err != nil {
+		return nil, err
+	}
+	view.Pinned, view.Position = pinned, position
+	if pinned || position != 0 {
+		if err := s.repo.SavePin(ctx, view.Pin(view.OwnerID)); err != nil {
+			return nil, err
+		}
+	}
+	return view, nil
+}
+
+func (s *viewService) GetViewByID(ctx context.Context, userID string, id uint) (*domain.View, error) {
+	view, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !view.VisibleTo(userID) {
+		return nil, domain.ErrViewNotFound
+	}
+	pin, err := s.repo.FindPin(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	view.Pinned, view.Position = pin.Pinned, pin.Position
+	return view, nil
+}
+
+func (s *viewService) GetViews(ctx context.Context, userID string) ([]*domain.View, error) {
+	return s.repo.FindVisibleTo(ctx, userID)
+}
+
+// UpdateView changes the view for its owner. Pinned and Position only move
+// the caller's own pin, so anyone who can see the view may set them.
+func (s *viewService) UpdateView(ctx context.Context, userID string, id uint, update *domain.ViewUpdate) (*domain.View, error) {
+	view, err := s.GetViewByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if update.ChangesView() && view.OwnerID != userID {
+		return nil, domain.ErrViewForbidden
+	}
+
+	if update.Name != nil && *update.Name != "" {
+		view.Name = *update.Name
+	}
+	if update.Visibility != nil {
+		view.Visibility = *update.Visibility
+	}
+	if update.Search != nil {
+		view.Search = *update.Search
+	}
+	if update.Status != nil {
+		view.Status = update.Status
+		if *update.Status == "" {
+			view.Status = nil
+		}
+	}
+	if update.Filter != nil {
+		view.Filter = *update.Filter
+	}
+	if update.SortBy != nil {
+		view.SortBy = *update.SortBy
+	}
+	if update.SortOrder != nil {
+		view.SortOrder = *update.SortOrder
+	}
+	if update.GroupBy != nil {
+		view.GroupBy = *update.GroupBy
+	}
+
+	if err := view.Validate(); err != nil {
+		return nil, err
+	}
+
+	if update.ChangesPin() {
+		if update.Pinned != nil {
+			view.Pinned = *update.Pinned
+		}
+		if update.Position != nil {
+			view.Position = *update.Position
+		}
+		if err := s.repo.SavePin(ctx, view.Pin(userID)); err != nil {
+			return nil, err
+		}
+	}
+	if !update.ChangesView() {
+		return view, nil
+	}
+	return s.repo.Update(ctx, view)
+}
+
+func (s *viewService) DeleteView(ctx context.Context, userID string, id uint) error {
+	view, err := s.GetViewByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if view.OwnerID != userID {
+		return domain.ErrViewForbidden
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *viewService) GetViewTasks(ctx context.Context, userID string, id uint, page common.BaseQuery) (*domain.View, *taskDomain.TaskQuery, []*taskDomain.Task, int, error) {
+	view, err := s.GetViewByID(ctx, userID, id)
+	if err != nil {
+		return nil, nil, nil, 0, err
+	}
+
+	query, err := view.TaskQuery(time.Now())
+	if err != nil {
+		return nil, nil, nil, 0, err
+	}
+	query.BaseQuery = page
+
+	if page.Cursor != nil {
+		if !query.SupportsCursor() {
+			return nil, nil, nil, 0, fmt.Errorf("%w: cursor pagination is not supported when sorting by relevance", domain.ErrInvalidView)
+		}
+		sortBy, sortOrder := query.EffectiveSort()
+		if page.Cursor.SortBy != sortBy || page.Cursor.SortOrder != sortOrder {
+			return nil, nil, nil, 0, fmt.Errorf("%w: cursor does not match the view's sort", domain.ErrInvalidView)
+		}
+	}
+
+	tasks, total, err := s.tasks.GetTasks(ctx, query)
+	if err != nil {
+		return nil, nil, nil, 0, err
+	}
+	return view, query, tasks, total, nil
+}
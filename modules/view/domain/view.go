@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+)
+
+var (
+	ErrViewNotFound  = errors.New("view not found")
+	ErrViewForbidden = errors.New("view belongs to another user")
+	ErrInvalidView   = errors.New("invalid view")
+)
+
+type ViewVisibility string
+
+const (
+	VisibilityPersonal ViewVisibility = "personal"
+	VisibilityShared   ViewVisibility = "shared"
+)
+
+type ViewGroupBy string
+
+const (
+	GroupByNone     ViewGroupBy = ""
+	GroupByStatus   ViewGroupBy = "status"
+	GroupByCategory ViewGroupBy = "category"
+)
+
+// View is a saved task query. Personal views are only visible to their
+// owner, shared views to the whole workspace. Pinned and Position come from
+// the ViewPin of the user reading the view, not from the view itself.
+type View struct {
+	ID         uint           `gorm:"primaryKey"`
+	Name       string         `gorm:"not null"`
+	OwnerID    string         `gorm:"type:varchar(64);not null;index"`
+	Visibility ViewVisibility `gorm:"type:varchar(10);default:'personal'"`
+	Pinned     bool           `gorm:"->;-:migration"`
+	Position   int            `gorm:"->;-:migration"`
+	Search     string
+	Status     *taskDomain.TaskStatus `gorm:"type:varchar(10)"`
+	Filter     string
+	SortBy     string      `gorm:"type:varchar(20)"`
+	SortOrder  string      `gorm:"type:varchar(4)"`
+	GroupBy    ViewGroupBy `gorm:"type:varchar(20)"`
+	CreatedAt  time.Time   `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time   `gorm:"autoUpdateTime"`
+}
+
+// ViewPin is one user's sidebar placement of a view. Every user who can see
+// a view pins and orders it for themselves, shared views included.
+type ViewPin struct {
+	UserID   string `gorm:"type:varchar(64);primaryKey"`
+	ViewID   uint   `gorm:"primaryKey"`
+	Pinned   bool   `gorm:"not null;default:false"`
+	Position int    `gorm:"not null;default:0"`
+}
+
+type ViewUpdate struct {
+	Name       *string
+	Visibility *ViewVisibility
+	Pinned     *bool
+	Position   *int
+	Search     *string
+	Status     *taskDomain.TaskStatus
+	Filter     *string
+	SortBy     *string
+	SortOrder  *string
+	GroupBy    *ViewGroupBy
+}
+
+// TaskGroup holds the tasks of one page that share a grouping key.
+type TaskGroup struct {
+	Key   string             `json:"key"`
+	Label string             `json:"label"`
+	Tasks []*taskDomain.Task `json:"tasks"`
+}
+
+type ViewRepository interface {
+	Save(ctx context.Context, view *View) (*View, error)
+	FindByID(ctx context.Context, id uint) (*View, error)
+	// FindVisibleTo returns the views userID may see with userID's pins
+	// applied, in sidebar order: pinned first, then by position.
+	FindVisibleTo(ctx context.Context, userID string) ([]*View, error)
+	Update(ctx context.Context, view *View) (*View, error)
+	// Delete removes the view along with every user's pin of it.
+	Delete(ctx context.Context, id uint) error
+	// FindPin returns userID's pin of the view, unpinned at position 0 when
+	// the user never placed it.
+	FindPin(ctx context.Context, userID string, viewID uint) (*ViewPin, error)
+	SavePin(ctx context.Context, pin *ViewPin) error
+}
+
+func IsValidVisibility(visibility ViewVisibility) bool {
+	return visibility == VisibilityPersonal || visibility == VisibilityShared
+}
+
+func IsValidGroupBy(groupBy ViewGroupBy) bool {
+	switch groupBy {
+	case GroupByNone, GroupByStatus, GroupByCategory:
+		return true
+	default:
+		return false
+	}
+}
+
+// ChangesView reports whether the update touches the view itself rather than
+// only the caller's pin, which only the owner may do.
+func (u *ViewUpdate) ChangesView() bool {
+	return u.Name != nil || u.Visibility != nil || u.Search != nil || u.Status != nil ||
+		u.Filter != nil || u.SortBy != nil || u.SortOrder != nil || u.GroupBy != nil
+}
+
+// ChangesPin reports whether the update moves the caller's pin.
+func (u *ViewUpdate) ChangesPin() bool {
+	return u.Pinned != nil || u.Position != nil
+}
+
+// Pin returns the user's placement of the view as last read.
+func (v *View) Pin(userID string) *ViewPin {
+	return &ViewPin{UserID: userID, ViewID: v.ID, Pinned: v.Pinned, Position: v.Position}
+}
+
+// VisibleTo reports whether the user may see and run the view.
+func (v *View) VisibleTo(userID string) bool {
+	return v.Visibility == VisibilityShared || v.OwnerID == userID
+}
+
+// Validate checks the view's own fields and that its query can be built.
+func (v *View) Validate() error {
+	if v.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidView)
+	}
+	if v.OwnerID == "" {
+		return fmt.Errorf("%w: owner is required", ErrInvalidView)
+	}
+	if !IsValidVisibility(v.Visibility) {
+		return fmt.Errorf("%w: visibility must be personal or shared", ErrInvalidView)
+	}
+	if !IsValidGroupBy(v.GroupBy) {
+		return fmt.Errorf("%w: group_by must be status or category", ErrInvalidView)
+	}
+	_, err := v.TaskQuery(time.Now())
+	return err
+}
+
+// TaskQuery builds the task query stored in the view. Relative times in the
+// filter are resolved against now, so running a view is always current.
+func (v *View) TaskQuery(now time.Time) (*taskDomain.TaskQuery, error) {
+	if v.SortBy != "" && !slices.Contains(taskDomain.TaskSortFields, v.SortBy) {
+		return nil, fmt.Errorf("%w: invalid sort_by", ErrInvalidView)
+	}
+	if v.SortBy == taskDomain.SortByRelevance && v.Search == "" {
+		return nil, fmt.Errorf("%w: sort_by=relevance requires search", ErrInvalidView)
+	}
+	if v.SortOrder != "" && !slices.Contains(taskDomain.SortOrders, v.SortOrder) {
+		return nil, fmt.Errorf("%w: invalid sort_order", ErrInvalidView)
+	}
+	if v.Status != nil && !taskDomain.IsValidTaskStatus(*v.Status) {
+		return nil, fmt.Errorf("%w: invalid status", ErrInvalidView)
+	}
+
+	query := &taskDomain.TaskQuery{
+		Search:    v.Search,
+		SortBy:    v.SortBy,
+		SortOrder: v.SortOrder,
+		Status:    v.Status,
+	}
+	if v.Filter != "" {
+		filter, err := taskDomain.ParseFilter(v.Filter, now)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidView, err)
+		}
+		query.Filter = filter
+	}
+	return query, nil
+}
+
+// GroupTasks splits tasks by the view's grouping key, keeping their order.
+func (v *View) GroupTasks(tasks []*taskDomain.Task) []*TaskGroup {
+	if v.GroupBy == GroupByNone {
+		return nil
+	}
+
+	var groups []*TaskGroup
+	index := map[string]*TaskGroup{}
+	for _, task := range tasks {
+		key, label := "", ""
+		switch v.GroupBy {
+		case GroupByStatus:
+			key, label = string(task.Status), string(task.Status)
+		case GroupByCategory:
+			key, label = "none", "No category"
+			if task.CategoryID != nil {
+				key = fmt.Sprint(*task.CategoryID)
+				label = key
+				if task.Category != nil {
+					label = task.Category.Name
+				}
+			}
+		}
+
+		group, ok := index[key]
+		if !ok {
+			group = &TaskGroup{Key: key, Label: label}
+			index[key] = group
+			groups = append(groups, group)
+		}
+		group.Tasks = append(group.Tasks, task)
+	}
+	return groups
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"github.com/ltphat2204/domain-driven-golang/common"
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/view/domain"
+)
+
+type ViewCreateDTO struct {
+	Name       string `json:"name" binding:"required"`
+	Visibility string `json:"visibility"`
+	Pinned     bool   `json:"pinned"`
+	Position   int    `json:"position"`
+	Search     string `json:"search"`
+	Status     string `json:"status"`
+	Filter     string `json:"filter"`
+	SortBy     string `json:"sort_by"`
+	SortOrder  string `json:"sort_order"`
+	GroupBy    string `json:"group_by"`
+}
+
+type ViewUpdateDTO struct {
+	Name       *string `json:"name"`
+	Visibility *string `json:"visibility"`
+	Pinned     *bool   `json:"pinned"`
+	Position   *int    `json:"position"`
+	Search     *string `json:"search"`
+	Status     *string `json:"status"`
+	Filter     *string `json:"filter"`
+	SortBy     *string `json:"sort_by"`
+	SortOrder  *string `json:"sort_order"`
+	GroupBy    *string `json:"group_by"`
+}
+
+type ViewTasksQueryDTO struct {
+	Page         int    `form:"page" binding:"omitempty,gte=1"`
+	PageSize     int    `form:"page_size" binding:"omitempty,gte=1"`
+	Cursor       string `form:"cursor"`
+	IncludeTotal *bool  `form:"include_total"`
+}
+
+type ViewListResponse struct {
+	Views []*domain.View `json:"views"`
+}
+
+type ViewTasksResponse struct {
+	View   *domain.View          `json:"view"`
+	Tasks  []*taskDomain.Task    `json:"tasks"`
+	Groups []*domain.TaskGroup   `json:"groups,omitempty"`
+	Meta   common.PaginationMeta `json:"meta"`
+}
@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ltphat2204/domain-driven-golang/common"
+	taskDomain "github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/view/application"
+	"github.com/ltphat2204/domain-driven-golang/modules/view/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/view/dto"
+)
+
+// UserIDHeader identifies the caller until the API gains authentication.
+const UserIDHeader = "X-User-ID"
+
+type ViewHandler struct {
+	service application.ViewService
+}
+
+func NewViewHandler(service application.ViewService) *ViewHandler {
+	return &ViewHandler{service: service}
+}
+
+func (h *ViewHandler) CreateView(c *gin.Context) {
+	userID, ok := requireUser(c)
+	if !ok {
+		return
+	}
+
+	var input dto.ViewCreateDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), err.Error()))
+		return
+	}
+
+	view := &domain.View{
+		Name:       input.Name,
+		OwnerID:    userID,
+		Visibility: domain.ViewVisibility(input.Visibility),
+		Pinned:     input.Pinned,
+		Position:   input.Position,
+		Search:     input.Search,
+		Filter:     input.Filter,
+		SortBy:     input.SortBy,
+		SortOrder:  input.SortOrder,
+		GroupBy:    domain.ViewGroupBy(input.GroupBy),
+	}
+	if input.Status != "" {
+		status := taskDomain.TaskStatus(input.Status)
+		view.Status = &status
+	}
+
+	view, err := h.service.CreateView(c.Request.Context(), view)
+	if err != nil {
+		respondViewError(c, "Failed to create view", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, common.NewSuccessResponse(view))
+}
+
+func (h *ViewHandler) GetView(c *gin.Context) {
+	userID, ok := requireUser(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Invalid ID"))
+		return
+	}
+
+	view, err := h.service.GetViewByID(c.Request.Context(), userID, uint(id))
+	if err != nil {
+		respondViewError(c, "Failed to retrieve view", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, common.NewSuccessResponse(view))
+}
+
+func (h *ViewHandler) GetViews(c *gin.Context) {
+	userID, ok := requireUser(c)
+	if !ok {
+		return
+	}
+
+	views, err := h.service.GetViews(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(c.Request.Context(), http.StatusInternalServerError, "Failed to retrieve views", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, common.NewSuccessResponse(dto.ViewListResponse{Views: views}))
+}
+
+func (h *ViewHandler) UpdateView(c *gin.Context) {
+	userID, ok := requireUser(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Invalid ID"))
+		return
+	}
+
+	var input dto.ViewUpdateDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), err.Error()))
+		return
+	}
+
+	update := &domain.ViewUpdate{
+		Name:      input.Name,
+		Pinned:    input.Pinned,
+		Position:  input.Position,
+		Search:    input.Search,
+		Filter:    input.Filter,
+		SortBy:    input.SortBy,
+		SortOrder: input.SortOrder,
+	}
+	if input.Visibility != nil {
+		visibility := domain.ViewVisibility(*input.Visibility)
+		update.Visibility = &visibility
+	}
+	if input.Status != nil {
+		status := taskDomain.TaskStatus(*input.Status)
+		update.Status = &status
+	}
+	if input.GroupBy != nil {
+		groupBy := domain.ViewGroupBy(*input.GroupBy)
+		update.GroupBy = &groupBy
+	}
+
+	view, err := h.service.UpdateView(c.Request.Context(), userID, uint(id), update)
+	if err != nil {
+		respondViewError(c, "Failed to update view", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, common.NewSuccessResponse(view))
+}
+
+func (h *ViewHandler) DeleteView(c *gin.Context) {
+	userID, ok := requireUser(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Invalid ID"))
+		return
+	}
+
+	if err := h.service.DeleteView(c.Request.Context(), userID, uint(id)); err != nil {
+		respondViewError(c, "Failed to delete view", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, common.NewSimpleSuccessResponse("View deleted"))
+}
+
+func (h *ViewHandler) GetViewTasks(c *gin.Context) {
+	userID, ok := requireUser(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Invalid ID"))
+		return
+	}
+
+	var queryDTO dto.ViewTasksQueryDTO
+	if err := c.ShouldBindQuery(&queryDTO); err != nil {
+		c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), err.Error()))
+		return
+	}
+
+	// Set defaults
+	page := 1
+	if queryDTO.Page > 0 {
+		page = queryDTO.Page
+	}
+	pageSize := common.DefaultPageSize()
+	if queryDTO.PageSize > 0 {
+		pageSize = queryDTO.PageSize
+	}
+
+	pagination := common.BaseQuery{
+		Page:      page,
+		PageSize:  pageSize,
+		SkipTotal: queryDTO.IncludeTotal != nil && !*queryDTO.IncludeTotal,
+	}
+	if queryDTO.Cursor != "" {
+		cursor, err := common.DecodeCursor(queryDTO.Cursor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.NewSimpleErrorResponse(c.Request.Context(), "Invalid cursor"))
+			return
+		}
+		pagination.Cursor = cursor
+	}
+
+	view, query, tasks, total, err := h.service.GetViewTasks(c.Request.Context(), userID, uint(id), pagination)
+	if err != nil {
+		respondViewError(c, "Failed to run view", err)
+		return
+	}
+
+	sortBy, sortOrder := query.EffectiveSort()
+	var last *common.Cursor
+	if len(tasks) > 0 && query.SupportsCursor() {
+		lastTask := tasks[len(tasks)-1]
+		last = &common.Cursor{
+			SortBy:    sortBy,
+			SortOrder: sortOrder,
+			Value:     lastTask.SortKey(sortBy),
+			ID:        lastTask.ID,
+		}
+	}
+
+	response := dto.ViewTasksResponse{
+		View:   view,
+		Tasks:  tasks,
+		Groups: view.GroupTasks(tasks),
+		Meta:   common.NewPaginationMeta(query.BaseQuery, total, last),
+	}
+
+	c.JSON(http.StatusOK, common.NewSuccessResponse(response))
+}
+
+func requireUser(c *gin.Context) (string, bool) {
+	userID := c.GetHeader(UserIDHeader)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, common.NewErrorResponse(c.Request.Context(), http.StatusUnauthorized, "Missing user", UserIDHeader+" header is required"))
+		return "", false
+	}
+	return userID, true
+}
+
+func respondViewError(c *gin.Context, message string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, domain.ErrViewNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrViewForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, domain.ErrInvalidView):
+		status = http.StatusBadRequest
+	}
+	c.JSON(status, common.NewErrorResponse(c.Request.Context(), status, message, err.Error()))
+}
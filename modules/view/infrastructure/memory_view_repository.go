@@ -0,0 +1,145 @@
+package infrastructure
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ltphat2204/domain-driven-golang/modules/view/domain"
+)
+
+// memoryViewRepository keeps views and their pins in maps for the memory
+// database driver.
+type memoryViewRepository struct {
+	mu     sync.RWMutex
+	nextID uint
+	views  map[uint]domain.View
+	pins   map[pinKey]domain.ViewPin
+}
+
+type pinKey struct {
+	userID string
+	viewID uint
+}
+
+func NewMemoryViewRepository() domain.ViewRepository {
+	return &memoryViewRepository{views: make(map[uint]domain.View), pins: make(map[pinKey]domain.ViewPin)}
+}
+
+func (r *memoryViewRepository) Save(ctx context.Context, view *domain.View) (*domain.View, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if view.ID == 0 {
+		r.nextID++
+		view.ID = r.nextID
+	}
+	r.nextID = max(r.nextID, view.ID)
+	now := time.Now()
+	if view.CreatedAt.IsZero() {
+		view.CreatedAt = now
+	}
+	if view.UpdatedAt.IsZero() {
+		view.UpdatedAt = now
+	}
+	if view.Visibility == "" {
+		view.Visibility = domain.VisibilityPersonal
+	}
+	r.views[view.ID] = stored(view)
+	return view, nil
+}
+
+func (r *memoryViewRepository) FindByID(ctx context.Context, id uint) (*domain.View, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	view, ok := r.views[id]
+	if !ok {
+		return nil, domain.ErrViewNotFound
+	}
+	return &view, nil
+}
+
+// FindVisibleTo returns the user's personal views and all shared views in
+// sidebar order: pinned first, then by position.
+func (r *memoryViewRepository) FindVisibleTo(ctx context.Context, userID string) ([]*domain.View, error) {
+	r.mu.RLock()
+	views := []*domain.View{}
+	for _, v := range r.views {
+		if v.OwnerID == userID || v.Visibility == domain.VisibilityShared {
+			view := v
+			pin := r.pins[pinKey{userID, view.ID}]
+			view.Pinned, view.Position = pin.Pinned, pin.Position
+			views = append(views, &view)
+		}
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(views, func(a, b *domain.View) int {
+		if a.Pinned != b.Pinned {
+			if a.Pinned {
+				return -1
+			}
+			return 1
+		}
+		if c := cmp.Compare(a.Position, b.Position); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return views, nil
+}
+
+func (r *memoryViewRepository) Update(ctx context.Context, view *domain.View) (*domain.View, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	view.UpdatedAt = time.Now()
+	r.views[view.ID] = stored(view)
+	return view, nil
+}
+
+func (r *memoryViewRepository) Delete(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.views, id)
+	for key := range r.pins {
+		if key.viewID == id {
+			delete(r.pins, key)
+		}
+	}
+	return nil
+}
+
+func (r *memoryViewRepository) FindPin(ctx context.Context, userID string, viewID uint) (*domain.ViewPin, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	pin, ok := r.pins[pinKey{userID, viewID}]
+	if !ok {
+		pin = domain.ViewPin{UserID: userID, ViewID: viewID}
+	}
+	return &pin, nil
+}
+
+func (r *memoryViewRepository) SavePin(ctx context.Context, pin *domain.ViewPin) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.views[pin.ViewID]; !ok {
+		return domain.ErrViewNotFound
+	}
+	r.pins[pinKey{pin.UserID, pin.ViewID}] = *pin
+	return nil
+}
+
+// stored drops the reader's pin, which lives in pins, like the SQL
+// repository does.
+func stored(view *domain.View) domain.View {
+	v := *view
+	v.Pinned, v.Position = false, 0
+	return v
+}
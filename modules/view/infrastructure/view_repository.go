@@ -0,0 +1,86 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ltphat2204/domain-driven-golang/database"
+	"github.com/ltphat2204/domain-driven-golang/modules/view/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type viewRepository struct {
+	db *gorm.DB
+}
+
+func NewViewRepository(db *gorm.DB) domain.ViewRepository {
+	return &viewRepository{db: db}
+}
+
+func (r *viewRepository) Save(ctx context.Context, view *domain.View) (*domain.View, error) {
+	result := database.Conn(ctx, r.db).Create(view)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return view, nil
+}
+
+func (r *viewRepository) FindByID(ctx context.Context, id uint) (*domain.View, error) {
+	var view domain.View
+	result := database.Conn(ctx, r.db).First(&view, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrViewNotFound
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &view, nil
+}
+
+// FindVisibleTo returns the user's personal views and all shared views in
+// sidebar order: pinned first, then by position.
+func (r *viewRepository) FindVisibleTo(ctx context.Context, userID string) ([]*domain.View, error) {
+	var views []*domain.View
+	err := database.Conn(ctx, r.db).
+		Select("views.*, COALESCE(view_pins.pinned, FALSE) AS pinned, COALESCE(view_pins.position, 0) AS position").
+		Joins("LEFT JOIN view_pins ON view_pins.view_id = views.id AND view_pins.user_id = ?", userID).
+		Where("views.owner_id = ? OR views.visibility = ?", userID, domain.VisibilityShared).
+		Order("pinned desc").Order("position asc").Order("views.id asc").
+		Find(&views).Error
+	if err != nil {
+		return nil, err
+	}
+	return views, nil
+}
+
+func (r *viewRepository) Update(ctx context.Context, view *domain.View) (*domain.View, error) {
+	result := database.Conn(ctx, r.db).Save(view)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return view, nil
+}
+
+// Delete leaves removing the view's pins to the ON DELETE CASCADE of
+// view_pins.
+func (r *viewRepository) Delete(ctx context.Context, id uint) error {
+	result := database.Conn(ctx, r.db).Delete(&domain.View{}, id)
+	return result.Error
+}
+
+func (r *viewRepository) FindPin(ctx context.Context, userID string, viewID uint) (*domain.ViewPin, error) {
+	pin := domain.ViewPin{UserID: userID, ViewID: viewID}
+	err := database.Conn(ctx, r.db).Where("user_id = ? AND view_id = ?", userID, viewID).Limit(1).Find(&pin).Error
+	if err != nil {
+		return nil, err
+	}
+	return &pin, nil
+}
+
+func (r *viewRepository) SavePin(ctx context.Context, pin *domain.ViewPin) error {
+	return database.Conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "view_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"pinned", "position"}),
+	}).Create(pin).Error
+}
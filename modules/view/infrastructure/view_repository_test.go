@@ -0,0 +1,28 @@
+package infrastructure
+
+import (
+	"testing"
+
+	"github.com/ltphat2204/domain-driven-golang/database/repotest"
+	"github.com/ltphat2204/domain-driven-golang/modules/view/domain"
+)
+
+func TestViewRepository(t *testing.T) {
+	t.Run("Memory", func(t *testing.T) {
+		repotest.RunViewRepository(t, func(t *testing.T) domain.ViewRepository {
+			return NewMemoryViewRepository()
+		})
+	})
+
+	t.Run("SQLite", func(t *testing.T) {
+		repotest.RunViewRepository(t, func(t *testing.T) domain.ViewRepository {
+			return NewViewRepository(repotest.OpenSQLite(t))
+		})
+	})
+
+	t.Run("Postgres", func(t *testing.T) {
+		repotest.RunViewRepository(t, func(t *testing.T) domain.ViewRepository {
+			return NewViewRepository(repotest.OpenPostgres(t))
+		})
+	})
+}
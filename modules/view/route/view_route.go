@@ -0,0 +1,48 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ltphat2204/domain-driven-golang/modules/view/domain"
+	"github.com/ltphat2204/domain-driven-golang/modules/view/dto"
+	"github.com/ltphat2204/domain-driven-golang/modules/view/handler"
+	"github.com/ltphat2204/domain-driven-golang/openapi"
+)
+
+func SetupRoutes(r *gin.Engine, viewHandler *handler.ViewHandler) {
+	r.POST("/views", viewHandler.CreateView)
+	r.GET("/views/:id", viewHandler.GetView)
+	r.GET("/views", viewHandler.GetViews)
+	r.PATCH("/views/:id", viewHandler.UpdateView)
+	r.DELETE("/views/:id", viewHandler.DeleteView)
+	r.GET("/views/:id/tasks", viewHandler.GetViewTasks)
+}
+
+// Operations documents the routes SetupRoutes registers. Every view route
+// acts on behalf of the X-User-ID caller.
+func Operations() []openapi.Operation {
+	tags := []string{"views"}
+	headers := []string{handler.UserIDHeader}
+	errors := []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError}
+	return []openapi.Operation{
+		{Method: "POST", Path: "/views", ID: "createView", Summary: "Save a view", Tags: tags, Headers: headers,
+			Body: dto.ViewCreateDTO{}, Response: domain.View{},
+			Errors: errors},
+		{Method: "GET", Path: "/views/:id", ID: "getView", Summary: "Get a view", Tags: tags, Headers: headers,
+			Response: domain.View{},
+			Errors:   errors},
+		{Method: "GET", Path: "/views", ID: "listViews", Summary: "List the caller's and shared views", Tags: tags, Headers: headers,
+			Response: dto.ViewListResponse{},
+			Errors:   []int{http.StatusUnauthorized, http.StatusInternalServerError}},
+		{Method: "PATCH", Path: "/views/:id", ID: "updateView", Summary: "Update a view", Tags: tags, Headers: headers,
+			Body: dto.ViewUpdateDTO{}, Response: domain.View{},
+			Errors: errors},
+		{Method: "DELETE", Path: "/views/:id", ID: "deleteView", Summary: "Delete a view", Tags: tags, Headers: headers,
+			Response: "",
+			Errors:   errors},
+		{Method: "GET", Path: "/views/:id/tasks", ID: "listViewTasks", Summary: "List the tasks a view matches", Tags: tags, Headers: headers,
+			Query: dto.ViewTasksQueryDTO{}, Response: dto.ViewTasksResponse{},
+			Errors: errors},
+	}
+}
@@ -0,0 +1,57 @@
+package openapi
+
+import (
+	"embed"
+	"encoding/json"
+	"net/http"
+	"path"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed docs.html
+var docsPage []byte
+
+// swaggerUI holds the Swagger UI release files the docs page loads, vendored
+// so it needs no CDN. See swaggerui/NOTICE for the version and license.
+//
+//go:embed swaggerui/swagger-ui-bundle.js swaggerui/swagger-ui.css
+var swaggerUI embed.FS
+
+// assetTypes fixes the content types rather than asking mime, whose table
+// the host's mime.types can change.
+var assetTypes = map[string]string{
+	".js":  "text/javascript; charset=utf-8",
+	".css": "text/css; charset=utf-8",
+}
+
+// Handler serves the document as JSON.
+func (d *Document) Handler() gin.HandlerFunc {
+	body, err := json.Marshal(d)
+	if err != nil {
+		panic(err) // the document only holds marshalable values
+	}
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+	}
+}
+
+// DocsHandler serves a Swagger UI page rendering /openapi.json.
+func DocsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", docsPage)
+	}
+}
+
+// AssetHandler serves the named Swagger UI file loaded by the docs page.
+func AssetHandler(name string) gin.HandlerFunc {
+	body, err := swaggerUI.ReadFile(path.Join("swaggerui", name))
+	if err != nil {
+		panic(err) // only embedded files are routed
+	}
+	contentType := assetTypes[path.Ext(name)]
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=86400")
+		c.Data(http.StatusOK, contentType, body)
+	}
+}
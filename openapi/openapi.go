@@ -0,0 +1,242 @@
+// Package openapi builds the OpenAPI 3.1 document of the API from the
+// operations each module declares next to its routes. Request and response
+// schemas are derived from the DTO and domain structs: json and form tags
+// name the fields, and binding tags become required and min/max constraints.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ltphat2204/domain-driven-golang/common"
+)
+
+// Operation documents one route. Path uses gin's syntax, such as
+// /tasks/:id; every path parameter is an integer ID.
+type Operation struct {
+	Method  string
+	Path    string
+	ID      string
+	Summary string
+	Tags    []string
+	// Headers are required request headers, such as X-User-ID.
+	Headers []string
+	// Query is a struct whose form tags are the query parameters.
+	Query interface{}
+	// Body is the JSON request body.
+	Body interface{}
+	// BodyTypes are the media types of a request body read as it is, such
+	// as an uploaded file, in place of Body.
+	BodyTypes []string
+	// Response is the data of the success envelope, or the whole body when
+	// Raw is set.
+	Response interface{}
+	Raw      bool
+	// ContentType of the success response; application/json when empty.
+	// ContentTypes lists them when there are several.
+	ContentType  string
+	ContentTypes []string
+	// Statuses answered with Response; 200 when empty.
+	Statuses []int
+	// Errors are the statuses answered with the error envelope.
+	Errors []int
+}
+
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Paths      map[string]map[string]*operation `json:"paths"`
+	Components components                       `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+type operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []*parameter         `json:"parameters,omitempty"`
+	RequestBody *requestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*response `json:"responses"`
+}
+
+type parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+type requestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*mediaType `json:"content"`
+}
+
+type response struct {
+	Description string                `json:"description"`
+	Content     map[string]*mediaType `json:"content,omitempty"`
+}
+
+type mediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// New builds the document for ops. Two operations on the same method and
+// path are an error, as is an operation without an ID.
+func New(info Info, ops []Operation) (*Document, error) {
+	doc := &Document{
+		OpenAPI:    "3.1.0",
+		Info:       info,
+		Paths:      make(map[string]map[string]*operation),
+		Components: components{Schemas: make(map[string]*Schema)},
+	}
+	g := &generator{schemas: doc.Components.Schemas}
+	errorSchema := g.schema(reflect.TypeOf(common.ErrorResponse{}), false)
+
+	ids := make(map[string]bool)
+	for _, op := range ops {
+		if op.ID == "" || ids[op.ID] {
+			return nil, fmt.Errorf("%s %s: operation ID %q is missing or reused", op.Method, op.Path, op.ID)
+		}
+		ids[op.ID] = true
+
+		path, params := convertPath(op.Path)
+		method := strings.ToLower(op.Method)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]*operation)
+		}
+		if doc.Paths[path][method] != nil {
+			return nil, fmt.Errorf("%s %s is documented twice", op.Method, op.Path)
+		}
+
+		out := &operation{
+			OperationID: op.ID,
+			Summary:     op.Summary,
+			Tags:        op.Tags,
+			Responses:   make(map[string]*response),
+		}
+		for _, name := range params {
+			out.Parameters = append(out.Parameters, &parameter{
+				Name: name, In: "path", Required: true,
+				Schema: &Schema{Type: "integer", Minimum: ptr(0)},
+			})
+		}
+		for _, name := range op.Headers {
+			out.Parameters = append(out.Parameters, &parameter{
+				Name: name, In: "header", Required: true,
+				Schema: &Schema{Type: "string"},
+			})
+		}
+		if op.Query != nil {
+			out.Parameters = append(out.Parameters, g.queryParameters(reflect.TypeOf(op.Query))...)
+		}
+		if op.Body != nil {
+			out.RequestBody = &requestBody{
+				Required: true,
+				Content:  map[string]*mediaType{"application/json": {Schema: g.schema(reflect.TypeOf(op.Body), true)}},
+			}
+		}
+		if len(op.BodyTypes) > 0 {
+			out.RequestBody = &requestBody{Required: true, Content: make(map[string]*mediaType)}
+			for _, contentType := range op.BodyTypes {
+				out.RequestBody.Content[contentType] = &mediaType{Schema: &Schema{Type: "string"}}
+			}
+		}
+
+		contentTypes, body := op.ContentTypes, &Schema{Type: "string"}
+		if op.ContentType != "" {
+			contentTypes = []string{op.ContentType}
+		}
+		if len(contentTypes) == 0 {
+			contentTypes = []string{"application/json"}
+			body = g.responseSchema(op)
+		}
+		statuses := op.Statuses
+		if len(statuses) == 0 {
+			statuses = []int{http.StatusOK}
+		}
+		for _, status := range statuses {
+			content := make(map[string]*mediaType)
+			for _, contentType := range contentTypes {
+				content[contentType] = &mediaType{Schema: body}
+			}
+			out.Responses[fmt.Sprint(status)] = &response{
+				Description: http.StatusText(status),
+				Content:     content,
+			}
+		}
+		for _, status := range op.Errors {
+			out.Responses[fmt.Sprint(status)] = &response{
+				Description: http.StatusText(status),
+				Content:     map[string]*mediaType{"application/json": {Schema: errorSchema}},
+			}
+		}
+		doc.Paths[path][method] = out
+	}
+	return doc, nil
+}
+
+// Has reports whether the document describes method on a gin route path.
+func (d *Document) Has(method, ginPath string) bool {
+	path, _ := convertPath(ginPath)
+	return d.Paths[path][strings.ToLower(method)] != nil
+}
+
+// Routes lists the documented operations as "METHOD /path", sorted.
+func (d *Document) Routes() []string {
+	var routes []string
+	for path, ops := range d.Paths {
+		for method := range ops {
+			routes = append(routes, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+// responseSchema wraps the operation's response in the success envelope
+// unless it is raw.
+func (g *generator) responseSchema(op Operation) *Schema {
+	var data *Schema
+	if op.Response == nil {
+		data = &Schema{}
+	} else {
+		data = g.schema(reflect.TypeOf(op.Response), false)
+	}
+	if op.Raw {
+		return data
+	}
+	return &Schema{
+		Type:     "object",
+		Required: []string{"success", "data"},
+		Properties: map[string]*Schema{
+			"success": {Type: "boolean"},
+			"data":    data,
+		},
+	}
+}
+
+// convertPath turns /tasks/:id into /tasks/{id} and returns the parameter
+// names.
+func convertPath(path string) (string, []string) {
+	parts := strings.Split(path, "/")
+	var params []string
+	for i, part := range parts {
+		if strings.HasPrefix(part, ":") {
+			params = append(params, part[1:])
+			parts[i] = "{" + part[1:] + "}"
+		}
+	}
+	return strings.Join(parts, "/"), params
+}
@@ -0,0 +1,118 @@
+package openapi
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+)
+
+type base struct {
+	ID uint
+}
+
+type widget struct {
+	base
+	Name    string            `json:"name"`
+	Note    string            `json:"note,omitempty"`
+	Due     *time.Time        `json:"due"`
+	Parts   []*part           `json:"parts"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Ignored string            `json:"-"`
+	hidden  string
+}
+
+type part struct {
+	Size int
+}
+
+type widgetInput struct {
+	Name  string  `json:"name" binding:"required,min=3,max=20"`
+	Count int     `json:"count" binding:"omitempty,gte=1,lte=50"`
+	Kind  string  `json:"kind" binding:"oneof=small large"`
+	Owner *string `json:"owner"`
+}
+
+type widgetQuery struct {
+	Q      string `form:"q" binding:"required"`
+	Limit  int    `form:"limit" binding:"omitempty,gte=1,lte=50"`
+	Active *bool  `form:"active"`
+	Other  string
+}
+
+func TestNew(t *testing.T) {
+	doc, err := New(Info{Title: "test", Version: "1"}, []Operation{
+		{Method: "GET", Path: "/widgets/:id", ID: "getWidget", Response: widget{}, Errors: []int{404}},
+		{Method: "POST", Path: "/widgets", ID: "createWidget", Body: widgetInput{}, Query: widgetQuery{}, Response: widget{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := doc.Routes(); !slices.Equal(got, []string{"GET /widgets/{id}", "POST /widgets"}) {
+		t.Errorf("Routes = %v", got)
+	}
+	if !doc.Has("GET", "/widgets/:id") || doc.Has("DELETE", "/widgets/:id") {
+		t.Error("Has does not match the documented routes")
+	}
+
+	w := doc.Components.Schemas["Widget"]
+	if w == nil {
+		t.Fatalf("no Widget schema in %v", doc.Components.Schemas)
+	}
+	if got := keys(w.Properties); !slices.Equal(got, []string{"ID", "due", "labels", "name", "note", "parts"}) {
+		t.Errorf("Widget properties = %v", got)
+	}
+	if !slices.Equal(w.Required, []string{"ID", "name", "due", "parts"}) {
+		t.Errorf("Widget required = %v, want the fields without omitempty", w.Required)
+	}
+	assertJSON(t, "due", w.Properties["due"], `{"type":["string","null"],"format":"date-time"}`)
+	assertJSON(t, "parts", w.Properties["parts"], `{"type":"array","items":{"$ref":"#/components/schemas/Part"}}`)
+
+	in := doc.Components.Schemas["WidgetInput"]
+	if !slices.Equal(in.Required, []string{"name"}) {
+		t.Errorf("WidgetInput required = %v, want the binding:required fields", in.Required)
+	}
+	assertJSON(t, "name", in.Properties["name"], `{"type":"string","minLength":3,"maxLength":20}`)
+	assertJSON(t, "count", in.Properties["count"], `{"type":"integer","minimum":1,"maximum":50}`)
+	assertJSON(t, "kind", in.Properties["kind"], `{"type":"string","enum":["small","large"]}`)
+
+	create := doc.Paths["/widgets"]["post"]
+	assertJSON(t, "query parameters", create.Parameters, `[`+
+		`{"name":"q","in":"query","required":true,"schema":{"type":"string"}},`+
+		`{"name":"limit","in":"query","schema":{"type":"integer","minimum":1,"maximum":50}},`+
+		`{"name":"active","in":"query","schema":{"type":"boolean"}}]`)
+	get := doc.Paths["/widgets/{id}"]["get"]
+	assertJSON(t, "path parameters", get.Parameters, `[{"name":"id","in":"path","required":true,"schema":{"type":"integer","minimum":0}}]`)
+	assertJSON(t, "404", get.Responses["404"].Content["application/json"].Schema, `{"$ref":"#/components/schemas/ErrorResponse"}`)
+}
+
+func TestNewRejectsDuplicates(t *testing.T) {
+	for name, ops := range map[string][]Operation{
+		"route": {{Method: "GET", Path: "/a", ID: "a"}, {Method: "GET", Path: "/a", ID: "b"}},
+		"id":    {{Method: "GET", Path: "/a", ID: "a"}, {Method: "POST", Path: "/a", ID: "a"}},
+	} {
+		if _, err := New(Info{}, ops); err == nil {
+			t.Errorf("duplicate %s: New succeeded", name)
+		}
+	}
+}
+
+func keys(m map[string]*Schema) []string {
+	var out []string
+	for k := range m {
+		out = append(out, k)
+	}
+	slices.Sort(out)
+	return out
+}
+
+func assertJSON(t *testing.T, what string, got interface{}, want string) {
+	t.Helper()
+	out, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != want {
+		t.Errorf("%s = %s, want %s", what, out, want)
+	}
+}
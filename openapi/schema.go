@@ -0,0 +1,236 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Schema is the subset of JSON Schema 2020-12 the generator emits.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 interface{}        `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *float64           `json:"minLength,omitempty"`
+	MaxLength            *float64           `json:"maxLength,omitempty"`
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// generator turns Go types into schemas, registering structs as named
+// components. A request struct's required fields are those with
+// binding:"required"; a response struct's are those serialized without
+// omitempty.
+type generator struct {
+	schemas map[string]*Schema
+	types   map[string]reflect.Type
+}
+
+func (g *generator) schema(t reflect.Type, request bool) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.Pointer:
+		return nullable(g.schema(t.Elem(), request))
+	case t.Kind() == reflect.Struct:
+		return &Schema{Ref: "#/components/schemas/" + g.component(t, request)}
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return &Schema{Type: "string", Format: "byte"}
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		return &Schema{Type: "array", Items: g.schema(element(t), request)}
+	case t.Kind() == reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(element(t), request)}
+	case t.Kind() == reflect.Interface:
+		return &Schema{}
+	}
+	return primitive(t)
+}
+
+func primitive(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Minimum: ptr(0)}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	default:
+		return &Schema{Type: "string"}
+	}
+}
+
+// component registers the struct's schema under its exported type name and
+// returns the name. Types with the same name in different packages are told
+// apart by the package name.
+func (g *generator) component(t reflect.Type, request bool) string {
+	name := exported(t.Name())
+	if other, ok := g.types[name]; ok && other != t {
+		name = exported(lastElem(t.PkgPath())) + name
+	}
+	if _, ok := g.schemas[name]; ok {
+		return name
+	}
+	if g.types == nil {
+		g.types = make(map[string]reflect.Type)
+	}
+	g.types[name] = t
+
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.schemas[name] = s
+	g.fields(t, request, s)
+	return name
+}
+
+// fields adds t's fields to s the way encoding/json serializes them,
+// flattening untagged embedded structs.
+func (g *generator) fields(t reflect.Type, request bool, s *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				g.fields(embedded, request, s)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		property := g.schema(f.Type, request)
+		required := applyBinding(property, f.Tag.Get("binding"))
+		if !request {
+			required = !hasOption(opts, "omitempty")
+		}
+		s.Properties[name] = property
+		if required {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// queryParameters describes the form-tagged fields of a query struct.
+func (g *generator) queryParameters(t reflect.Type) []*parameter {
+	var params []*parameter
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := f.Tag.Get("form")
+		if name == "" || name == "-" {
+			continue
+		}
+		fieldType := f.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		schema := g.schema(fieldType, true)
+		required := applyBinding(schema, f.Tag.Get("binding"))
+		params = append(params, &parameter{Name: name, In: "query", Required: required, Schema: schema})
+	}
+	return params
+}
+
+// applyBinding copies gin binding constraints onto s and reports whether the
+// field is required.
+func applyBinding(s *Schema, binding string) bool {
+	required := false
+	isString := s.Type == "string"
+	for _, rule := range strings.Split(binding, ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "required":
+			required = true
+		case "oneof":
+			s.Enum = strings.Fields(value)
+		case "gte", "min", "lte", "max":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			lower := key == "gte" || key == "min"
+			switch {
+			case isString && lower:
+				s.MinLength = &n
+			case isString:
+				s.MaxLength = &n
+			case lower:
+				s.Minimum = &n
+			default:
+				s.Maximum = &n
+			}
+		}
+	}
+	return required
+}
+
+// element is the element type of a slice or map. The API never returns nil
+// elements, so pointers are not made nullable.
+func element(t reflect.Type) reflect.Type {
+	elem := t.Elem()
+	if elem.Kind() == reflect.Pointer {
+		return elem.Elem()
+	}
+	return elem
+}
+
+// nullable allows null in place of s.
+func nullable(s *Schema) *Schema {
+	switch typ := s.Type.(type) {
+	case string:
+		copied := *s
+		copied.Type = []string{typ, "null"}
+		return &copied
+	case nil:
+		if s.Ref == "" {
+			return s
+		}
+	}
+	return &Schema{OneOf: []*Schema{s, {Type: "null"}}}
+}
+
+func hasOption(opts, option string) bool {
+	for _, o := range strings.Split(opts, ",") {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+func exported(name string) string {
+	if name == "" {
+		return name
+	}
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func lastElem(path string) string {
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+func ptr(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,145 @@
+package router
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ltphat2204/domain-driven-golang/graphql"
+	graphqlAPI "github.com/ltphat2204/domain-driven-golang/graphql/api"
+	"github.com/ltphat2204/domain-driven-golang/logging"
+	"github.com/ltphat2204/domain-driven-golang/metrics"
+	"github.com/ltphat2204/domain-driven-golang/openapi"
+	"github.com/ltphat2204/domain-driven-golang/tracing"
+
+	categoryApplication "github.com/ltphat2204/domain-driven-golang/modules/category/application"
+	categoryHandler "github.com/ltphat2204/domain-driven-golang/modules/category/handler"
+	categoryRoutes "github.com/ltphat2204/domain-driven-golang/modules/category/route"
+
+	taskApplication "github.com/ltphat2204/domain-driven-golang/modules/task/application"
+	taskHandler "github.com/ltphat2204/domain-driven-golang/modules/task/handlers"
+	taskRoutes "github.com/ltphat2204/domain-driven-golang/modules/task/route"
+
+	searchApplication "github.com/ltphat2204/domain-driven-golang/modules/search/application"
+	searchHandler "github.com/ltphat2204/domain-driven-golang/modules/search/handler"
+	searchRoutes "github.com/ltphat2204/domain-driven-golang/modules/search/route"
+
+	healthApplication "github.com/ltphat2204/domain-driven-golang/modules/health/application"
+	healthHandler "github.com/ltphat2204/domain-driven-golang/modules/health/handler"
+	healthRoutes "github.com/ltphat2204/domain-driven-golang/modules/health/route"
+
+	viewApplication "github.com/ltphat2204/domain-driven-golang/modules/view/application"
+	viewHandler "github.com/ltphat2204/domain-driven-golang/modules/view/handler"
+	viewRoutes "github.com/ltphat2204/domain-driven-golang/modules/view/route"
+
+	streamApplication "github.com/ltphat2204/domain-driven-golang/modules/stream/application"
+	streamHandler "github.com/ltphat2204/domain-driven-golang/modules/stream/handler"
+	streamRoutes "github.com/ltphat2204/domain-driven-golang/modules/stream/route"
+
+	transferApplication "github.com/ltphat2204/domain-driven-golang/modules/transfer/application"
+	transferHandler "github.com/ltphat2204/domain-driven-golang/modules/transfer/handler"
+	transferRoutes "github.com/ltphat2204/domain-driven-golang/modules/transfer/route"
+)
+
+// Services are the application services the API is built on.
+type Services struct {
+	Tasks      taskApplication.TaskService
+	Bulk       taskApplication.BulkService
+	Categories categoryApplication.CategoryService
+	Views      viewApplication.ViewService
+	Transfer   transferApplication.TransferService
+	Search     searchApplication.SearchService
+	Health     healthApplication.HealthService
+	// Metrics, when set, records every request and serves /metrics.
+	Metrics *metrics.Metrics
+	// GraphQL bounds the queries /graphql accepts.
+	GraphQL graphql.Limits
+	// Stream, when set, serves its events on /tasks/stream and /tasks/ws,
+	// with a keep-alive on streams idle for StreamHeartbeat.
+	Stream          *streamApplication.Hub
+	StreamHeartbeat time.Duration
+}
+
+// New returns the API router with every module's routes registered against
+// the given services.
+func New(services Services) *gin.Engine {
+	r := gin.New()
+	r.Use(logging.Middleware(), logging.Recovery(), tracing.Middleware())
+	if services.Metrics != nil {
+		r.Use(services.Metrics.Middleware())
+		r.GET("/metrics", gin.WrapH(services.Metrics.Handler()))
+	}
+
+	categoryRoutes.SetupRoutes(r, categoryHandler.NewCategoryHandler(services.Categories))
+	taskRoutes.SetupRoutes(r, taskHandler.NewTaskHandler(services.Tasks), taskHandler.NewBulkHandler(services.Bulk))
+	viewRoutes.SetupRoutes(r, viewHandler.NewViewHandler(services.Views))
+	transferRoutes.SetupRoutes(r, transferHandler.NewTransferHandler(services.Transfer))
+	searchRoutes.SetupRoutes(r, searchHandler.NewSearchHandler(services.Search))
+	healthRoutes.SetupRoutes(r, healthHandler.NewHealthHandler(services.Health))
+	if services.Stream != nil {
+		streamRoutes.SetupRoutes(r, streamHandler.NewStreamHandler(services.Stream, services.StreamHeartbeat))
+	}
+
+	api, err := graphqlAPI.New(services.Tasks, services.Categories)
+	if err != nil {
+		panic(err)
+	}
+	api.SetupRoutes(r, services.GraphQL)
+
+	doc, err := openapi.New(apiInfo, operations(services))
+	if err != nil {
+		panic(err)
+	}
+	r.GET("/openapi.json", doc.Handler())
+	r.GET("/docs", openapi.DocsHandler())
+	r.GET("/docs/swagger-ui-bundle.js", openapi.AssetHandler("swagger-ui-bundle.js"))
+	r.GET("/docs/swagger-ui.css", openapi.AssetHandler("swagger-ui.css"))
+
+	return r
+}
+
+var apiInfo = openapi.Info{
+	Title:       "Domain-Driven Golang Task Manager",
+	Version:     "1.0.0",
+	Description: "Tasks, categories, saved views and search. Errors carry the request's X-Request-ID as request_id.",
+}
+
+// operations lists the documented routes; TestEveryRouteIsDocumented keeps it
+// in step with the registered ones.
+func operations(services Services) []openapi.Operation {
+	var ops []openapi.Operation
+	ops = append(ops, categoryRoutes.Operations()...)
+	ops = append(ops, taskRoutes.Operations()...)
+	ops = append(ops, viewRoutes.Operations()...)
+	ops = append(ops, transferRoutes.Operations()...)
+	ops = append(ops, searchRoutes.Operations()...)
+	ops = append(ops, healthRoutes.Operations()...)
+	if services.Stream != nil {
+		ops = append(ops, streamRoutes.Operations()...)
+	}
+	ops = append(ops, graphqlAPI.Operations()...)
+	if services.Metrics != nil {
+		ops = append(ops, openapi.Operation{
+			Method: "GET", Path: "/metrics", ID: "metrics", Summary: "Prometheus metrics", Tags: []string{"operations"},
+			ContentType: "text/plain",
+		})
+	}
+	return append(ops,
+		openapi.Operation{
+			Method: "GET", Path: "/openapi.json", ID: "openapi", Summary: "This OpenAPI document", Tags: []string{"operations"},
+			Response: map[string]interface{}{}, Raw: true,
+		},
+		openapi.Operation{
+			Method: "GET", Path: "/docs", ID: "docs", Summary: "API reference page", Tags: []string{"operations"},
+			ContentType: "text/html",
+		},
+		openapi.Operation{
+			Method: "GET", Path: "/docs/swagger-ui-bundle.js", ID: "docsScript", Summary: "Swagger UI script used by the reference page", Tags: []string{"operations"},
+			ContentType: "text/javascript",
+		},
+		openapi.Operation{
+			Method: "GET", Path: "/docs/swagger-ui.css", ID: "docsStylesheet", Summary: "Swagger UI stylesheet used by the reference page", Tags: []string{"operations"},
+			ContentType: "text/css",
+		},
+	)
+}
@@ -0,0 +1,531 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ltphat2204/domain-driven-golang/common"
+	"github.com/ltphat2204/domain-driven-golang/config"
+	"github.com/ltphat2204/domain-driven-golang/database"
+	"github.com/ltphat2204/domain-driven-golang/graphql"
+	"github.com/ltphat2204/domain-driven-golang/metrics"
+	categoryApplication "github.com/ltphat2204/domain-driven-golang/modules/category/application"
+	categoryInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/category/infrastructure"
+	healthApplication "github.com/ltphat2204/domain-driven-golang/modules/health/application"
+	searchApplication "github.com/ltphat2204/domain-driven-golang/modules/search/application"
+	streamApplication "github.com/ltphat2204/domain-driven-golang/modules/stream/application"
+	taskApplication "github.com/ltphat2204/domain-driven-golang/modules/task/application"
+	taskInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/task/infrastructure"
+	transferApplication "github.com/ltphat2204/domain-driven-golang/modules/transfer/application"
+	transferInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/transfer/infrastructure"
+	viewApplication "github.com/ltphat2204/domain-driven-golang/modules/view/application"
+	viewInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/view/infrastructure"
+	"github.com/ltphat2204/domain-driven-golang/openapi"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// goldenRequest is one step of the API walkthrough, recorded in
+// testdata/<name>.golden. The placeholder {cursor} in a path is replaced with
+// the next_cursor of the most recent response in its group that had one.
+// Generated request IDs are replaced with <request-id>; a requestID sent by
+// the step is echoed and kept.
+type goldenRequest struct {
+	name      string
+	method    string
+	path      string
+	body      string
+	user      string
+	requestID string
+}
+
+// goldenGroup is a run of steps against a router of its own, so a group can
+// be run, changed or regenerated without the others. Its seed requests run
+// first to create the data the steps expect, and are not recorded.
+type goldenGroup struct {
+	name  string
+	seed  []goldenRequest
+	steps []goldenRequest
+}
+
+var seedCategories = []goldenRequest{
+	{method: "POST", path: "/categories", body: `{"name":"Work","description":"office work and meetings"}`},
+	{method: "POST", path: "/categories", body: `{"name":"Household","description":"chores"}`},
+	{method: "POST", path: "/categories", body: `{"name":"Errands"}`},
+}
+
+var seedAll = slices.Concat(seedCategories, []goldenRequest{
+	{method: "POST", path: "/tasks", body: `{"title":"Write quarterly report","description":"numbers for the board report","due_at":"2025-03-01T12:00:00Z","category_id":1}`},
+	{method: "POST", path: "/tasks", body: `{"title":"Board slides","description":"based on the report","due_at":"2025-02-01T12:00:00Z","category_id":1}`},
+	{method: "POST", path: "/tasks", body: `{"title":"Clean kitchen","category_id":2}`},
+	{method: "POST", path: "/tasks", body: `{"title":"Buy milk"}`},
+	{method: "PATCH", path: "/tasks/2", body: `{"status":"Done","category_id":2}`},
+})
+
+var goldenGroups = []goldenGroup{
+	{
+		name: "categories",
+		steps: []goldenRequest{
+			{name: "category_create", method: "POST", path: "/categories", body: `{"name":"Work","description":"office work and meetings"}`},
+			{name: "category_create_second", method: "POST", path: "/categories", body: `{"name":"Home","description":"chores"}`},
+			{name: "category_create_third", method: "POST", path: "/categories", body: `{"name":"Errands"}`},
+			{name: "category_create_missing_name", method: "POST", path: "/categories", body: `{"description":"no name"}`},
+			{name: "category_create_malformed", method: "POST", path: "/categories", body: `{"name":`},
+			{name: "category_get", method: "GET", path: "/categories/1"},
+			{name: "category_get_not_found", method: "GET", path: "/categories/99"},
+			{name: "category_get_invalid_id", method: "GET", path: "/categories/abc"},
+			{name: "category_list", method: "GET", path: "/categories"},
+			{name: "category_list_page", method: "GET", path: "/categories?page=2&page_size=2"},
+			{name: "category_list_without_total", method: "GET", path: "/categories?page_size=2&include_total=false"},
+			{name: "category_list_sorted_first_page", method: "GET", path: "/categories?sort_by=name&sort_order=asc&page_size=2"},
+			{name: "category_list_sorted_next_page", method: "GET", path: "/categories?sort_by=name&sort_order=asc&page_size=2&cursor={cursor}"},
+			{name: "category_list_cursor_sort_mismatch", method: "GET", path: "/categories?sort_by=name&sort_order=desc&cursor={cursor}"},
+			{name: "category_list_invalid_cursor", method: "GET", path: "/categories?cursor=not-a-cursor"},
+			{name: "category_list_invalid_sort_by", method: "GET", path: "/categories?sort_by=color"},
+			{name: "category_list_invalid_sort_order", method: "GET", path: "/categories?sort_by=name&sort_order=up"},
+			{name: "category_list_invalid_page", method: "GET", path: "/categories?page=0"},
+			{name: "category_list_search", method: "GET", path: "/categories?search=meetings"},
+			{name: "category_list_relevance_without_search", method: "GET", path: "/categories?sort_by=relevance"},
+			{name: "category_update", method: "PATCH", path: "/categories/2", body: `{"name":"Household","color":"#3cb44b"}`},
+			{name: "category_update_invalid_color", method: "PATCH", path: "/categories/2", body: `{"color":"#000000"}`},
+			{name: "category_update_not_found", method: "PATCH", path: "/categories/99", body: `{"name":"Nobody"}`},
+			{name: "category_update_invalid_id", method: "PATCH", path: "/categories/abc", body: `{}`},
+			{name: "category_delete", method: "DELETE", path: "/categories/3"},
+			{name: "category_delete_invalid_id", method: "DELETE", path: "/categories/abc"},
+		},
+	},
+	{
+		name: "tasks",
+		seed: seedCategories,
+		steps: []goldenRequest{
+			{name: "task_create", method: "POST", path: "/tasks", body: `{"title":"Write quarterly report","description":"numbers for the board report","due_at":"2025-03-01T12:00:00Z","category_id":1}`},
+			{name: "task_create_second", method: "POST", path: "/tasks", body: `{"title":"Board slides","description":"based on the report","due_at":"2025-02-01T12:00:00Z","category_id":1}`},
+			{name: "task_create_third", method: "POST", path: "/tasks", body: `{"title":"Clean kitchen","category_id":2}`},
+			{name: "task_create_fourth", method: "POST", path: "/tasks", body: `{"title":"Buy milk"}`},
+			{name: "task_create_missing_title", method: "POST", path: "/tasks", body: `{"description":"no title"}`},
+			{name: "task_create_malformed", method: "POST", path: "/tasks", body: `[]`},
+			{name: "task_create_unknown_category", method: "POST", path: "/tasks", body: `{"title":"Orphan","category_id":99}`},
+			{name: "task_get", method: "GET", path: "/tasks/1"},
+			{name: "task_get_not_found", method: "GET", path: "/tasks/99"},
+			{name: "task_get_invalid_id", method: "GET", path: "/tasks/abc"},
+			{name: "task_update", method: "PATCH", path: "/tasks/2", body: `{"status":"Done","category_id":2}`},
+			{name: "task_update_invalid_status", method: "PATCH", path: "/tasks/2", body: `{"status":"Finished"}`},
+			{name: "task_update_not_found", method: "PATCH", path: "/tasks/99", body: `{"title":"Nobody"}`},
+			{name: "task_update_invalid_id", method: "PATCH", path: "/tasks/abc", body: `{}`},
+			{name: "task_list", method: "GET", path: "/tasks"},
+			{name: "task_list_page", method: "GET", path: "/tasks?page=2&page_size=3"},
+			{name: "task_list_without_total", method: "GET", path: "/tasks?page_size=2&include_total=false"},
+			{name: "task_list_sorted_first_page", method: "GET", path: "/tasks?sort_by=due_at&sort_order=asc&page_size=2"},
+			{name: "task_list_sorted_next_page", method: "GET", path: "/tasks?sort_by=due_at&sort_order=asc&page_size=2&cursor={cursor}"},
+			{name: "task_list_sorted_by_title", method: "GET", path: "/tasks?sort_by=title&sort_order=desc"},
+			{name: "task_list_cursor_sort_mismatch", method: "GET", path: "/tasks?cursor={cursor}"},
+			{name: "task_list_invalid_cursor", method: "GET", path: "/tasks?cursor=not-a-cursor"},
+			{name: "task_list_invalid_sort_by", method: "GET", path: "/tasks?sort_by=status"},
+			{name: "task_list_invalid_sort_order", method: "GET", path: "/tasks?sort_by=title&sort_order=up"},
+			{name: "task_list_invalid_page_size", method: "GET", path: "/tasks?page_size=0"},
+			{name: "task_list_status", method: "GET", path: "/tasks?status=Done"},
+			{name: "task_list_invalid_status", method: "GET", path: "/tasks?status=Finished"},
+			{name: "task_list_filter", method: "GET", path: "/tasks?filter=" + url.QueryEscape(`category_id != 1 and title ~ "k"`)},
+			{name: "task_list_invalid_filter", method: "GET", path: "/tasks?filter=" + url.QueryEscape(`priority = high`)},
+			{name: "task_list_search", method: "GET", path: "/tasks?search=report"},
+			{name: "task_list_relevance", method: "GET", path: "/tasks?search=report&sort_by=relevance"},
+			{name: "task_list_relevance_without_search", method: "GET", path: "/tasks?sort_by=relevance"},
+			{name: "task_delete", method: "DELETE", path: "/tasks/4"},
+			{name: "task_delete_invalid_id", method: "DELETE", path: "/tasks/abc"},
+			{name: "task_bulk_atomic_rolled_back", method: "POST", path: "/tasks/bulk", body: `{"operations":[{"op":"set_status","id":1,"status":"Done"},{"op":"move","id":3,"category_id":1},{"op":"update","id":99,"title":"Nobody"},{"op":"delete","id":2}]}`},
+			{name: "task_bulk_best_effort", method: "POST", path: "/tasks/bulk", body: `{"mode":"best_effort","operations":[{"op":"set_status","id":2,"status":"Done"},{"op":"move","id":3,"category_id":99},{"op":"update","id":3,"title":"Clean kitchen"}]}`},
+			{name: "task_bulk_invalid", method: "POST", path: "/tasks/bulk", body: `{"mode":"all","operations":[{"op":"archive","id":1},{"op":"delete"},{"op":"set_status","id":1,"status":"Finished"},{"op":"create","id":5,"title":"Copy"},{"op":"move","id":1,"title":"Renamed"}]}`},
+			{name: "task_bulk_empty", method: "POST", path: "/tasks/bulk", body: `{"operations":[]}`},
+		},
+	},
+	{
+		name: "views",
+		seed: seedAll,
+		steps: []goldenRequest{
+			{name: "view_create_without_user", method: "POST", path: "/views", body: `{"name":"Done"}`},
+			{name: "view_create", method: "POST", path: "/views", user: "alice", body: `{"name":"Work board","filter":"category_id = 1","sort_by":"due_at","sort_order":"asc","group_by":"status"}`},
+			{name: "view_create_shared", method: "POST", path: "/views", user: "bob", body: `{"name":"Everything","visibility":"shared","pinned":true}`},
+			{name: "view_create_invalid", method: "POST", path: "/views", user: "alice", body: `{"name":"Broken","filter":"title ~"}`},
+			{name: "view_list", method: "GET", path: "/views", user: "alice"},
+			{name: "view_get", method: "GET", path: "/views/1", user: "alice"},
+			{name: "view_get_forbidden", method: "GET", path: "/views/1", user: "bob"},
+			{name: "view_get_not_found", method: "GET", path: "/views/99", user: "alice"},
+			{name: "view_tasks", method: "GET", path: "/views/1/tasks", user: "alice"},
+			{name: "view_update", method: "PATCH", path: "/views/1", user: "alice", body: `{"pinned":true,"group_by":"category"}`},
+			{name: "view_update_forbidden", method: "PATCH", path: "/views/2", user: "alice", body: `{"name":"Mine now"}`},
+			{name: "view_pin_shared", method: "PATCH", path: "/views/2", user: "alice", body: `{"pinned":false,"position":1}`},
+			{name: "view_list_pinned", method: "GET", path: "/views", user: "alice"},
+			{name: "view_list_owner_pin", method: "GET", path: "/views", user: "bob"},
+			{name: "view_delete", method: "DELETE", path: "/views/1", user: "alice"},
+		},
+	},
+	{
+		name: "search",
+		seed: seedAll,
+		steps: []goldenRequest{
+			{name: "search", method: "GET", path: "/search?q=report"},
+			{name: "search_types", method: "GET", path: "/search?q=household&types=category"},
+			{name: "search_missing_query", method: "GET", path: "/search"},
+			{name: "search_invalid_type", method: "GET", path: "/search?q=report&types=user"},
+		},
+	},
+	{
+		name: "transfer",
+		seed: seedAll,
+		steps: []goldenRequest{
+			{name: "transfer_import_csv", method: "POST", path: "/import?format=csv&map=title:Task&map=due_at:Deadline", body: "Task,Deadline,Status,Category,External_ID\nBuy milk,2025-05-01,done,Errands,sheet-1\nPay rent,,Doing,Bills,sheet-2\n,2025-05-02,,,sheet-3\nCall mom,tomorrow,,,\nToo,many,values,in,this,row\n"},
+			{name: "transfer_import_dry_run", method: "POST", path: "/import?format=json&dry_run=true", body: `[{"external_id":"sheet-1","title":"Buy oat milk"},{"type":"category","title":"Bills","color":"#000000"},{"type":"category","title":"Garden"},5]`},
+			{name: "transfer_import_ndjson", method: "POST", path: "/import?format=ndjson", body: "{\"external_id\":\"sheet-1\",\"title\":\"Buy oat milk\",\"category\":\"\"}\n\n{\"title\":\"Plan trip\",\"status\":\"Blocked\"}\nnot json\n"},
+			{name: "transfer_import_not_an_array", method: "POST", path: "/import?format=json", body: `{"title":"Loose"}`},
+			{name: "transfer_import_unknown_field", method: "POST", path: "/import?format=csv&map=priority:P", body: "title,P\nShip it,high\n"},
+			{name: "transfer_import_invalid_map", method: "POST", path: "/import?format=csv&map=title", body: "title\nShip it\n"},
+			{name: "transfer_import_github", method: "POST", path: "/import?format=github", body: `[{"number":7,"title":"Add dark mode","state":"open","labels":[{"name":"Household"},{"name":"ui"}],"body":null,"html_url":"https://github.com/acme/app/issues/7"},{"number":8,"title":"Bump deps","state":"open","labels":[],"pull_request":{}}]`},
+			{name: "transfer_import_trello_mapped", method: "POST", path: "/import?format=trello&map=title:name", body: `{"lists":[],"cards":[]}`},
+			{name: "transfer_export", method: "GET", path: "/export?format=json"},
+			{name: "transfer_export_invalid_format", method: "GET", path: "/export?format=xml"},
+		},
+	},
+	{
+		name: "graphql",
+		seed: seedAll,
+		steps: []goldenRequest{
+			{name: "graphql_tasks", method: "POST", path: "/graphql", body: `{"query":"{ tasks { items { id title status dueAt categoryId category { name } } pageInfo { total page hasMore } } }"}`},
+			{name: "graphql_categories_with_tasks", method: "POST", path: "/graphql", body: `{"query":"{ categories(sortBy: NAME, sortOrder: ASC) { items { id name tasks(filter: \"status != Done\") { title status } } } }"}`},
+			{name: "graphql_task_variables", method: "POST", path: "/graphql", body: `{"query":"query Task($id: ID!) { task(id: $id) { id title createdAt category { name color } } }","variables":{"id":"1"}}`},
+			{name: "graphql_task_not_found", method: "POST", path: "/graphql", body: `{"query":"{ task(id: \"99\") { title } }"}`},
+			{name: "graphql_tasks_first_page", method: "GET", path: graphqlQuery(`{ tasks(sortBy: DUE_AT, sortOrder: ASC, first: 1) { items { title dueAt } pageInfo { hasMore nextCursor } } }`)},
+			{name: "graphql_tasks_next_page", method: "GET", path: graphqlQuery(`{ tasks(sortBy: DUE_AT, sortOrder: ASC, first: 1, after: "{cursor}") { items { title dueAt } pageInfo { hasMore nextCursor } } }`)},
+			{name: "graphql_tasks_invalid_filter", method: "POST", path: "/graphql", body: `{"query":"{ tasks(filter: \"priority = high\") { items { id } } }"}`},
+			{name: "graphql_create_task", method: "POST", path: "/graphql", body: `{"query":"mutation { createTask(input: {title: \"Water plants\", dueAt: \"2025-04-01T08:00:00Z\", categoryId: \"2\"}) { id title status dueAt category { name } } }"}`},
+			{name: "graphql_update_task", method: "POST", path: "/graphql", body: `{"query":"mutation Update($id: ID!) { updateTask(id: $id, input: {status: DOING}) { title status category { name } } }","variables":{"id":"1"}}`},
+			{name: "graphql_update_task_clear_category", method: "POST", path: "/graphql", body: `{"query":"mutation { updateTask(id: \"1\", input: {removeCategory: true}) { title categoryId category { name } } }"}`},
+			{name: "graphql_update_task_category_conflict", method: "POST", path: "/graphql", body: `{"query":"mutation { updateTask(id: \"1\", input: {categoryId: \"2\", removeCategory: true}) { id } }"}`},
+			{name: "graphql_update_task_not_found", method: "POST", path: "/graphql", body: `{"query":"mutation { updateTask(id: \"99\", input: {title: \"Nobody\"}) { id } }"}`},
+			{name: "graphql_create_category", method: "POST", path: "/graphql", body: `{"query":"mutation { createCategory(input: {name: \"Garden\"}) { id name color tasks { id } } }"}`},
+			{name: "graphql_update_category_invalid_color", method: "POST", path: "/graphql", body: `{"query":"mutation { updateCategory(id: \"1\", input: {color: \"#000000\"}) { color } }"}`},
+			{name: "graphql_delete_category", method: "POST", path: "/graphql", body: `{"query":"mutation { deleteCategory(id: \"4\") }"}`},
+			{name: "graphql_delete_task", method: "POST", path: "/graphql", body: `{"query":"mutation { deleteTask(id: \"5\") }"}`},
+			{name: "graphql_unknown_field", method: "POST", path: "/graphql", body: `{"query":"{ tasks { items { priority } } }"}`},
+			{name: "graphql_syntax_error", method: "POST", path: "/graphql", body: `{"query":"{ tasks { "}`},
+			{name: "graphql_too_complex", method: "POST", path: "/graphql", body: `{"query":"{ tasks(first: 100) { items { category { tasks(first: 100) { title } } } } }"}`},
+			{name: "graphql_mutation_over_get", method: "GET", path: graphqlQuery(`mutation { deleteTask(id: "1") }`)},
+			{name: "graphql_schema", method: "GET", path: "/graphql/schema.graphql"},
+		},
+	},
+	// Only requests answered before the stream starts.
+	{
+		name: "stream",
+		steps: []goldenRequest{
+			{name: "stream_invalid_status", method: "GET", path: "/tasks/stream?status=Blocked"},
+			{name: "stream_invalid_last_event_id", method: "GET", path: "/tasks/stream?last_event_id=latest"},
+			{name: "stream_ws_without_upgrade", method: "GET", path: "/tasks/ws?category_id=1"},
+		},
+	},
+	{
+		name: "health",
+		steps: []goldenRequest{
+			{name: "healthz", method: "GET", path: "/healthz"},
+			{name: "readyz", method: "GET", path: "/readyz"},
+		},
+	},
+	{
+		name: "metrics",
+		seed: seedAll,
+		steps: []goldenRequest{
+			{name: "metrics", method: "GET", path: "/metrics"},
+		},
+	},
+	{
+		name: "request_ids",
+		steps: []goldenRequest{
+			{name: "request_id_propagated", method: "GET", path: "/tasks/999", requestID: "golden-request-1"},
+		},
+	},
+	{
+		name: "docs",
+		steps: []goldenRequest{
+			{name: "openapi", method: "GET", path: "/openapi.json"},
+			{name: "docs", method: "GET", path: "/docs"},
+			{name: "docs_script", method: "GET", path: "/docs/swagger-ui-bundle.js"},
+			{name: "docs_stylesheet", method: "GET", path: "/docs/swagger-ui.css"},
+		},
+	},
+}
+
+// graphqlQuery returns the path of a GET /graphql request for query, leaving
+// any {cursor} placeholder unescaped.
+func graphqlQuery(query string) string {
+	return "/graphql?query=" + strings.ReplaceAll(url.QueryEscape(query), "%7Bcursor%7D", "{cursor}")
+}
+
+func TestMain(m *testing.M) {
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	os.Exit(m.Run())
+}
+
+func TestRoutesGolden(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	gin.DefaultWriter = io.Discard
+	common.SetCursorSecret([]byte("golden-test-secret"))
+
+	seen := make(map[string]bool)
+	for _, group := range goldenGroups {
+		for _, req := range group.steps {
+			if seen[req.name] {
+				t.Fatalf("two steps are named %s", req.name)
+			}
+			seen[req.name] = true
+		}
+	}
+
+	for _, group := range goldenGroups {
+		t.Run(group.name, func(t *testing.T) {
+			r := newTestRouter()
+			for _, req := range group.seed {
+				if rec := serveGolden(r, req, ""); rec.Code >= 400 {
+					t.Fatalf("seed %s %s: %d %s", req.method, req.path, rec.Code, rec.Body)
+				}
+			}
+			var cursor string
+			for _, req := range group.steps {
+				t.Run(req.name, func(t *testing.T) {
+					got, next := renderGolden(t, req, serveGolden(r, req, cursor))
+					if next != "" {
+						cursor = next
+					}
+
+					file := filepath.Join("testdata", req.name+".golden")
+					if *update {
+						if err := os.WriteFile(file, got, 0o644); err != nil {
+							t.Fatal(err)
+						}
+						return
+					}
+					want, err := os.ReadFile(file)
+					if err != nil {
+						t.Fatalf("%v (run go test ./router -update to create it)", err)
+					}
+					if !bytes.Equal(got, want) {
+						t.Errorf("response differs from %s\n--- got\n%s\n--- want\n%s", file, got, want)
+					}
+				})
+			}
+		})
+	}
+}
+
+// serveGolden sends req to r, with cursor in place of {cursor}.
+func serveGolden(r *gin.Engine, req goldenRequest, cursor string) *httptest.ResponseRecorder {
+	path := strings.ReplaceAll(req.path, "{cursor}", cursor)
+	var body io.Reader
+	if req.body != "" {
+		body = strings.NewReader(req.body)
+	}
+	httpReq := httptest.NewRequest(req.method, path, body)
+	if req.body != "" {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if req.user != "" {
+		httpReq.Header.Set("X-User-ID", req.user)
+	}
+	if req.requestID != "" {
+		httpReq.Header.Set(common.RequestIDHeader, req.requestID)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httpReq)
+	return rec
+}
+
+func TestEveryRouteHasGoldenRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := newTestRouter()
+
+	covered := make(map[string]bool)
+	for _, route := range r.Routes() {
+		for _, group := range goldenGroups {
+			for _, req := range group.steps {
+				path, _, _ := strings.Cut(req.path, "?")
+				if req.method == route.Method && matchesRoute(route.Path, path) {
+					covered[route.Method+" "+route.Path] = true
+				}
+			}
+		}
+		if !covered[route.Method+" "+route.Path] {
+			t.Errorf("%s %s has no golden request", route.Method, route.Path)
+		}
+	}
+}
+
+// TestEveryRouteIsDocumented fails when a route is registered without an
+// OpenAPI operation, or an operation describes a route that does not exist.
+func TestEveryRouteIsDocumented(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := newTestRouter()
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", "/openapi.json", nil))
+	var doc openapi.Document
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("decoding /openapi.json: %v", err)
+	}
+
+	registered := make(map[string]bool)
+	for _, route := range r.Routes() {
+		if !doc.Has(route.Method, route.Path) {
+			t.Errorf("%s %s is not in the OpenAPI document; add it to its module's Operations", route.Method, route.Path)
+		}
+		registered[route.Method+" "+pathParam.ReplaceAllString(route.Path, "{$1}")] = true
+	}
+	for _, route := range doc.Routes() {
+		if !registered[route] {
+			t.Errorf("the OpenAPI document describes %s, which is not registered", route)
+		}
+	}
+}
+
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+func newTestRouter() *gin.Engine {
+	categoryRepo := categoryInfrastructure.NewMemoryCategoryRepository()
+	taskRepo := taskInfrastructure.NewMemoryTaskRepository(categoryRepo)
+	viewRepo := viewInfrastructure.NewMemoryViewRepository()
+	refRepo := transferInfrastructure.NewMemoryExternalRefRepository()
+	transactor := database.NewMemoryTransactor(taskRepo.(database.Snapshotter), categoryRepo.(database.Snapshotter), refRepo.(database.Snapshotter))
+
+	m := metrics.New(false)
+	taskService := m.TaskService(taskApplication.NewTaskService(taskRepo))
+	categoryService := categoryApplication.NewCategoryService(categoryRepo, config.ColorPalette)
+	return New(Services{
+		Tasks:      taskService,
+		Bulk:       taskApplication.NewBulkService(taskService, transactor, 100),
+		Categories: categoryService,
+		Views:      viewApplication.NewViewService(viewRepo, taskService),
+		Transfer:   transferApplication.NewTransferService(taskService, categoryService, refRepo, transactor, transferInfrastructure.Codecs(), transferInfrastructure.Importers()),
+		Search:     searchApplication.NewSearchService(taskService, categoryService),
+		Health:     healthApplication.NewHealthService(),
+		Metrics:    m,
+		GraphQL:    graphql.Limits{MaxComplexity: 1000, MaxDepth: 10},
+		Stream:     streamApplication.NewHub(100),
+	})
+}
+
+// renderGolden formats a response for comparison. Values that change between
+// runs (timestamps, randomly assigned colors and cursors, which embed
+// timestamps) are replaced with placeholders; the real next_cursor is returned
+// so later requests can use it.
+func renderGolden(t *testing.T, req goldenRequest, rec *httptest.ResponseRecorder) ([]byte, string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s %s\n", req.method, req.path)
+	if req.body != "" {
+		fmt.Fprintf(&buf, "%s\n", req.body)
+	}
+	fmt.Fprintf(&buf, "\n%d\n", rec.Code)
+	if req.requestID != "" {
+		fmt.Fprintf(&buf, "%s: %s\n", common.RequestIDHeader, rec.Header().Get(common.RequestIDHeader))
+	}
+
+	if req.path == "/metrics" {
+		renderMetrics(&buf, rec.Body.String())
+		return buf.Bytes(), ""
+	}
+	if strings.HasPrefix(req.path, "/docs/swagger-ui") {
+		// Vendored files are too large to keep twice and change with every
+		// upgrade, so only their type and presence are recorded.
+		fmt.Fprintf(&buf, "Content-Type: %s\nnon-empty body: %v\n", rec.Header().Get("Content-Type"), rec.Body.Len() > 0)
+		return buf.Bytes(), ""
+	}
+	if strings.HasPrefix(rec.Header().Get("Content-Type"), "text/") {
+		buf.Write(rec.Body.Bytes())
+		return buf.Bytes(), ""
+	}
+
+	decoder := json.NewDecoder(rec.Body)
+	decoder.UseNumber()
+	var body interface{}
+	if err := decoder.Decode(&body); err != nil {
+		t.Fatalf("response is not JSON: %v", err)
+	}
+	var cursor string
+	body = normalize(body, "", &cursor)
+	if req.requestID == "" {
+		if m, ok := body.(map[string]interface{}); ok && m["request_id"] != nil {
+			m["request_id"] = "<request-id>"
+		}
+	}
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(body); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes(), cursor
+}
+
+// renderMetrics keeps the parts of a Prometheus exposition that do not vary
+// between runs: the metric types and the counter values. Histograms record
+// latencies and are left out.
+func renderMetrics(buf *bytes.Buffer, exposition string) {
+	for _, line := range strings.Split(exposition, "\n") {
+		name, _, _ := strings.Cut(strings.TrimPrefix(line, "# TYPE "), "{")
+		name, _, _ = strings.Cut(name, " ")
+		if strings.HasPrefix(line, "# TYPE ") || strings.HasSuffix(name, "_total") {
+			fmt.Fprintln(buf, line)
+		}
+	}
+}
+
+func normalize(value interface{}, key string, cursor *string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = normalize(item, k, cursor)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalize(item, key, cursor)
+		}
+	case string:
+		switch key {
+		case "CreatedAt", "UpdatedAt", "createdAt", "updatedAt", "created_at", "updated_at":
+			return "<time>"
+		case "Color", "color":
+			return "<color>"
+		case "next_cursor", "nextCursor":
+			*cursor = v
+			return "<cursor>"
+		}
+	}
+	return value
+}
+
+// matchesRoute reports whether path matches a route pattern such as
+// /tasks/:id.
+func matchesRoute(pattern, path string) bool {
+	patternParts := strings.Split(pattern, "/")
+	pathParts := strings.Split(path, "/")
+	if len(patternParts) != len(pathParts) {
+		return false
+	}
+	for i, part := range patternParts {
+		if !strings.HasPrefix(part, ":") && part != pathParts[i] {
+			return false
+		}
+	}
+	return true
+}
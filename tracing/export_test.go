@@ -0,0 +1,8 @@
+package tracing
+
+import "io"
+
+// SetStdout redirects the stdout exporter for tests.
+func SetStdout(w io.Writer) {
+	stdout = w
+}
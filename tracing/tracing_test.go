@@ -0,0 +1,93 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ltphat2204/domain-driven-golang/config"
+	"github.com/ltphat2204/domain-driven-golang/database/repotest"
+	taskApplication "github.com/ltphat2204/domain-driven-golang/modules/task/application"
+	"github.com/ltphat2204/domain-driven-golang/modules/task/domain"
+	taskInfrastructure "github.com/ltphat2204/domain-driven-golang/modules/task/infrastructure"
+	"github.com/ltphat2204/domain-driven-golang/tracing"
+)
+
+type exportedSpan struct {
+	Name        string
+	SpanContext struct{ TraceID, SpanID string }
+	Parent      struct{ TraceID, SpanID string }
+}
+
+func TestRequestTrace(t *testing.T) {
+	var out bytes.Buffer
+	tracing.SetStdout(&out)
+	cfg := config.Default().Tracing
+	cfg.Exporter = config.TracingExporterStdout
+	flush, err := tracing.Setup(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+
+	db := repotest.OpenSQLite(t)
+	if err := db.Use(tracing.GormPlugin{}); err != nil {
+		t.Fatal(err)
+	}
+	tasks := taskApplication.NewTaskService(taskInfrastructure.NewTaskRepository(db))
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(tracing.Middleware())
+	r.GET("/tasks", func(c *gin.Context) {
+		if _, _, err := tasks.GetTasks(c.Request.Context(), &domain.TaskQuery{}); err != nil {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	const traceID, callerSpanID = "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7"
+	req := httptest.NewRequest("GET", "/tasks", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-"+callerSpanID+"-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	if err := flush(context.Background()); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	spans := make(map[string]exportedSpan)
+	decoder := json.NewDecoder(&out)
+	for {
+		var span exportedSpan
+		if err := decoder.Decode(&span); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("decoding exported spans: %v", err)
+		}
+		if span.SpanContext.TraceID != traceID {
+			t.Errorf("span %q has trace %s, want the caller's %s", span.Name, span.SpanContext.TraceID, traceID)
+		}
+		spans[span.Name] = span
+	}
+
+	server, service, sql := spans["GET /tasks"], spans["TaskService.GetTasks"], spans["gorm.query tasks"]
+	for name, span := range map[string]exportedSpan{"server": server, "service": service, "sql": sql} {
+		if span.Name == "" {
+			t.Fatalf("no %s span among %v", name, spans)
+		}
+	}
+	if server.Parent.SpanID != callerSpanID {
+		t.Errorf("server span parent = %s, want the caller's %s", server.Parent.SpanID, callerSpanID)
+	}
+	if service.Parent.SpanID != server.SpanContext.SpanID {
+		t.Errorf("service span parent = %s, want the server span %s", service.Parent.SpanID, server.SpanContext.SpanID)
+	}
+	if sql.Parent.SpanID != service.SpanContext.SpanID {
+		t.Errorf("sql span parent = %s, want the service span %s", sql.Parent.SpanID, service.SpanContext.SpanID)
+	}
+}
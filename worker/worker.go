@@ -0,0 +1,127 @@
+// Package worker runs background jobs next to the HTTP server and stops them
+// during shutdown.
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// Func is a background job. It must return once ctx is cancelled.
+type Func func(ctx context.Context) error
+
+const (
+	StatePending = "pending"
+	StateRunning = "running"
+	StateStopped = "stopped"
+	StateFailed  = "failed"
+)
+
+// Status is the state of one worker. Error is set when it failed.
+type Status struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+	Error string `json:"error,omitempty"`
+}
+
+type entry struct {
+	name string
+	run  Func
+	// state and err are guarded by Group.mu.
+	state string
+	err   error
+}
+
+// Group starts a set of workers together and stops them together. Workers
+// are added before Start; the zero value is ready to use.
+type Group struct {
+	mu      sync.Mutex
+	workers []*entry
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// Add registers a worker to run on Start.
+func (g *Group) Add(name string, run Func) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.workers = append(g.workers, &entry{name: name, run: run, state: StatePending})
+}
+
+// Start runs every worker in its own goroutine. A worker that returns an
+// error or panics is marked failed; the others keep running.
+func (g *Group) Start() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	g.cancel = cancel
+
+	for _, w := range g.workers {
+		w.state = StateRunning
+		g.wg.Add(1)
+		go func(w *entry) {
+			defer g.wg.Done()
+			err := runSafely(ctx, w)
+
+			g.mu.Lock()
+			defer g.mu.Unlock()
+			if err != nil && !(errors.Is(err, context.Canceled) && ctx.Err() != nil) {
+				slog.Error("worker failed", "worker", w.name, "error", err)
+				w.state, w.err = StateFailed, err
+				return
+			}
+			w.state = StateStopped
+		}(w)
+	}
+}
+
+func runSafely(ctx context.Context, w *entry) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return w.run(ctx)
+}
+
+// Stop cancels the workers and waits for them to return or for ctx to end,
+// whichever comes first.
+func (g *Group) Stop(ctx context.Context) error {
+	g.mu.Lock()
+	if g.cancel != nil {
+		g.cancel()
+	}
+	g.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for workers: %w", ctx.Err())
+	}
+}
+
+// Status reports every worker in the order they were added.
+func (g *Group) Status() []Status {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	statuses := make([]Status, len(g.workers))
+	for i, w := range g.workers {
+		statuses[i] = Status{Name: w.name, State: w.state}
+		if w.err != nil {
+			statuses[i].Error = w.err.Error()
+		}
+	}
+	return statuses
+}
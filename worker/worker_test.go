@@ -0,0 +1,71 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestGroup(t *testing.T) {
+	var g Group
+	g.Add("ticker", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	g.Add("broken", func(ctx context.Context) error {
+		return errors.New("boom")
+	})
+	g.Add("panics", func(ctx context.Context) error {
+		<-ctx.Done()
+		panic("on the way out")
+	})
+
+	if got := states(g.Status()); !slices.Equal(got, []string{StatePending, StatePending, StatePending}) {
+		t.Errorf("before Start = %v", got)
+	}
+	g.Start()
+	for deadline := time.Now().Add(time.Second); g.Status()[1].State != StateFailed && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	if got := states(g.Status()); !slices.Equal(got, []string{StateRunning, StateFailed, StateRunning}) {
+		t.Errorf("after Start = %v", got)
+	}
+
+	if err := g.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	status := g.Status()
+	if got := states(status); !slices.Equal(got, []string{StateStopped, StateFailed, StateFailed}) {
+		t.Errorf("after Stop = %v", got)
+	}
+	if status[1].Error != "boom" || status[2].Error != "panic: on the way out" {
+		t.Errorf("errors = %q, %q", status[1].Error, status[2].Error)
+	}
+}
+
+func TestStopDeadline(t *testing.T) {
+	var g Group
+	release := make(chan struct{})
+	defer close(release)
+	g.Add("stuck", func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+	g.Start()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := g.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Stop = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func states(statuses []Status) []string {
+	var out []string
+	for _, s := range statuses {
+		out = append(out, s.State)
+	}
+	return out
+}